@@ -0,0 +1,82 @@
+// Command treeserve serves the tree algorithms over HTTP so they can be
+// called from services not written in Go.
+//
+// Usage:
+//
+//	treeserve [-addr :8080] [-max-body bytes] [-max-nodes n] [-timeout d]
+//
+// The limits apply to every request. See core.NewHandler for the
+// endpoints and the request and response formats. For example:
+//
+//	curl -d '{"val":1,"left":{"val":3},"right":{"val":2}}' localhost:8080/rowwise-max
+//	{"levels":[1,3]}
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	core "core/main"
+)
+
+func main() {
+	srv, err := newServer(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	log.Printf("treeserve listening on %s", srv.Addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
+
+// newServer parses the command line into a server that has not been
+// started. Flag errors are reported on stderr and returned.
+func newServer(args []string, stderr io.Writer) (*http.Server, error) {
+	fs := flag.NewFlagSet("treeserve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	maxBody := fs.Int64("max-body", core.DefaultMaxBodyBytes, "maximum request body size in bytes")
+	maxNodes := fs.Int("max-nodes", -1, "maximum nodes visited per request; -1 for no limit")
+	timeout := fs.Duration("timeout", 5*time.Second, "maximum traversal time per request; 0 for no limit")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	var traversal []core.TraversalOption
+	if *maxNodes >= 0 {
+		traversal = append(traversal, core.WithMaxNodes(*maxNodes))
+	}
+	if *timeout > 0 {
+		traversal = append(traversal, core.WithTimeout(*timeout))
+	}
+	return &http.Server{
+		Addr: *addr,
+		Handler: core.NewHandler(
+			core.WithMaxBodyBytes(*maxBody),
+			core.WithTraversalOptions(traversal...),
+		),
+		ReadHeaderTimeout: 10 * time.Second,
+	}, nil
+}
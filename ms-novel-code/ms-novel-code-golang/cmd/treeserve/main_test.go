@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// serve sends body to path on srv's handler and returns the status.
+func serve(srv *http.Server, path, body string) int {
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return rec.Code
+}
+
+const fourNodes = `{"val":1,"left":{"val":2,"left":{"val":4}},"right":{"val":3}}`
+
+// 1. Without flags the server listens on :8080 with no node limit.
+func TestNewServerDefaults(t *testing.T) {
+	srv, err := newServer(nil, io.Discard)
+	require.NoError(t, err)
+	require.Equal(t, ":8080", srv.Addr)
+	require.Equal(t, http.StatusOK, serve(srv, "/rowwise-max", fourNodes))
+	require.Equal(t, http.StatusOK, serve(srv, "/stats", fourNodes))
+}
+
+// 2. The limit flags reach the handler.
+func TestNewServerLimits(t *testing.T) {
+	srv, err := newServer([]string{"-addr", "127.0.0.1:0", "-max-nodes", "3", "-max-body", "80"}, io.Discard)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:0", srv.Addr)
+	require.Equal(t, http.StatusUnprocessableEntity, serve(srv, "/rowwise-max", fourNodes))
+	require.Equal(t, http.StatusUnprocessableEntity, serve(srv, "/stats", fourNodes))
+	require.Equal(t, http.StatusOK, serve(srv, "/rowwise-max", `{"val":1,"left":{"val":2}}`))
+	require.Equal(t, http.StatusRequestEntityTooLarge, serve(srv, "/rowwise-max", `{"val":1,`+strings.Repeat(" ", 80)+`"left":null}`))
+
+	srv, err = newServer([]string{"-timeout", "1ns"}, io.Discard)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnprocessableEntity, serve(srv, "/rowwise-max", fourNodes))
+}
+
+// 3. Bad flags are reported.
+func TestNewServerBadFlags(t *testing.T) {
+	var stderr bytes.Buffer
+	_, err := newServer([]string{"-max-nodes", "many"}, &stderr)
+	require.Error(t, err)
+	require.Contains(t, stderr.String(), "-max-nodes")
+}
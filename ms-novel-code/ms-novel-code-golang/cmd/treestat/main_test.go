@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// treestat runs the command and returns its exit status and output.
+func treestat(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+const wantReport = "size:     6\n" +
+	"height:   3\n" +
+	"balanced: true\n" +
+	"complete: false\n" +
+	"row max:  [1 3 9]\n" +
+	"row min:  [1 2 3]\n" +
+	"row sum:  [1 5 17]\n" +
+	"\n" +
+	"1\n" +
+	"|-- L: 3\n" +
+	"|   |-- L: 5\n" +
+	"|   `-- R: 3\n" +
+	"`-- R: 2\n" +
+	"    `-- R: 9\n"
+
+// 1. Level-order and JSON input on stdin give the same report.
+func TestRunStdin(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		args []string
+	}{
+		{"[1,3,2,5,3,null,9]\n", nil},
+		{"1 3 2 5 3 nil 9", []string{"-format", "level"}},
+		{`{"val":1,"left":{"val":3,"left":{"val":5},"right":{"val":3}},"right":{"val":2,"right":{"val":9}}}`, nil},
+		{` {"val":1,"left":{"val":3,"left":{"val":5},"right":{"val":3}},"right":{"val":2,"right":{"val":9}}}`, []string{"-format=json"}},
+	} {
+		code, out, errOut := treestat(tc.in, tc.args...)
+		require.Equal(t, 0, code, errOut)
+		require.Equal(t, wantReport, out, tc.in)
+	}
+
+	code, out, _ := treestat("null")
+	require.Equal(t, 0, code)
+	require.Contains(t, out, "size:     0\n")
+	require.Contains(t, out, "row max:  []\n")
+}
+
+// 2. A file argument is read instead of stdin.
+func TestRunFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.txt")
+	require.NoError(t, os.WriteFile(path, []byte("[1,3,2,5,3,null,9]"), 0o644))
+	code, out, errOut := treestat("ignored", path)
+	require.Equal(t, 0, code, errOut)
+	require.Equal(t, wantReport, out)
+
+	code, _, errOut = treestat("", filepath.Join(t.TempDir(), "missing.txt"))
+	require.Equal(t, 1, code)
+	require.Contains(t, errOut, "treestat:")
+}
+
+// 3. Malformed input and bad usage exit non-zero with a message.
+func TestRunErrors(t *testing.T) {
+	code, out, errOut := treestat("[1,x]")
+	require.Equal(t, 1, code)
+	require.Empty(t, out)
+	require.Contains(t, errOut, `invalid value "x"`)
+
+	code, _, errOut = treestat(`{"val":`)
+	require.Equal(t, 1, code)
+	require.Contains(t, errOut, "parse json")
+
+	code, _, errOut = treestat("1", "-format", "xml")
+	require.Equal(t, 2, code)
+	require.Contains(t, errOut, `unknown format "xml"`)
+
+	code, _, _ = treestat("1", "a", "b")
+	require.Equal(t, 2, code)
+}
@@ -0,0 +1,306 @@
+package core
+
+import (
+	"fmt"
+	"iter"
+)
+
+// avlNode is a tree node that also tracks the height of its subtree.
+type avlNode struct {
+	val         int
+	left, right *avlNode
+	height      int // 1 for a leaf
+}
+
+// AVL is a self-balancing binary search tree: the heights of every
+// node's subtrees differ by at most one, keeping all operations
+// O(log n). It implements SearchTree, so it is a drop-in replacement
+// for BST.
+type AVL struct {
+	root *avlNode
+	size int
+}
+
+var _ SearchTree = (*AVL)(nil)
+
+// NewAVL returns an AVL tree holding vals.
+func NewAVL(vals ...int) *AVL {
+	t := &AVL{}
+	for _, v := range vals {
+		t.Insert(v)
+	}
+	return t
+}
+
+// Insert adds v and reports whether it was not already present.
+func (t *AVL) Insert(v int) bool {
+	var inserted bool
+	t.root = avlInsert(t.root, v, &inserted)
+	if inserted {
+		t.size++
+	}
+	return inserted
+}
+
+// Delete removes v and reports whether it was present.
+func (t *AVL) Delete(v int) bool {
+	var deleted bool
+	t.root = avlDelete(t.root, v, &deleted)
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+// Search reports whether v is present.
+func (t *AVL) Search(v int) bool {
+	node := t.root
+	for node != nil {
+		switch {
+		case v < node.val:
+			node = node.left
+		case v > node.val:
+			node = node.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Min returns the smallest value, or false if the tree is empty.
+func (t *AVL) Min() (int, bool) {
+	if t.root == nil {
+		return 0, false
+	}
+	return avlMin(t.root).val, true
+}
+
+// Max returns the largest value, or false if the tree is empty.
+func (t *AVL) Max() (int, bool) {
+	if t.root == nil {
+		return 0, false
+	}
+	node := t.root
+	for node.right != nil {
+		node = node.right
+	}
+	return node.val, true
+}
+
+// InOrder returns all values in ascending order.
+func (t *AVL) InOrder() []int {
+	res := make([]int, 0, t.size)
+	var stack []*avlNode
+	node := t.root
+	for node != nil || len(stack) > 0 {
+		for node != nil {
+			stack = append(stack, node)
+			node = node.left
+		}
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		res = append(res, node.val)
+		node = node.right
+	}
+	return res
+}
+
+// Range returns an iterator over the values in [lo, hi] in ascending
+// order. Subtrees entirely outside the range are skipped. The tree must
+// not be modified during iteration.
+func (t *AVL) Range(lo, hi int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		var stack []*avlNode
+		node := t.root
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				if node.val < lo {
+					node = node.right // node and its left subtree are below lo
+					continue
+				}
+				stack = append(stack, node)
+				node = node.left
+			}
+			if len(stack) == 0 {
+				return
+			}
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if node.val > hi || !yield(node.val) {
+				return
+			}
+			node = node.right
+		}
+	}
+}
+
+// Len returns the number of values stored.
+func (t *AVL) Len() int {
+	return t.size
+}
+
+// Height returns the height of the tree (0 when empty).
+func (t *AVL) Height() int {
+	return avlHeight(t.root)
+}
+
+// Tree returns a copy of the tree as plain Nodes, so the rest of the
+// package (rowWiseMax, LevelStats, ExportDOT, ...) can run on it.
+func (t *AVL) Tree() *Node {
+	var convert func(n *avlNode) *Node
+	convert = func(n *avlNode) *Node {
+		if n == nil {
+			return nil
+		}
+		return &Node{Val: n.val, Left: convert(n.left), Right: convert(n.right)}
+	}
+	return convert(t.root)
+}
+
+// Validate checks the AVL invariants: strict BST ordering, correct
+// cached heights, balance factors within [-1, 1], and a node count that
+// matches Len. It is meant for tests and debugging.
+func (t *AVL) Validate() error {
+	count := 0
+	var check func(n *avlNode, lo, hi *int) error
+	check = func(n *avlNode, lo, hi *int) error {
+		if n == nil {
+			return nil
+		}
+		count++
+		if (lo != nil && n.val <= *lo) || (hi != nil && n.val >= *hi) {
+			return fmt.Errorf("avl: node %d violates BST ordering", n.val)
+		}
+		if err := check(n.left, lo, &n.val); err != nil {
+			return err
+		}
+		if err := check(n.right, &n.val, hi); err != nil {
+			return err
+		}
+		if want := 1 + max(avlHeight(n.left), avlHeight(n.right)); n.height != want {
+			return fmt.Errorf("avl: node %d has height %d, want %d", n.val, n.height, want)
+		}
+		if bf := avlBalance(n); bf < -1 || bf > 1 {
+			return fmt.Errorf("avl: node %d has balance factor %d", n.val, bf)
+		}
+		return nil
+	}
+	if err := check(t.root, nil, nil); err != nil {
+		return err
+	}
+	if count != t.size {
+		return fmt.Errorf("avl: counted %d nodes, Len reports %d", count, t.size)
+	}
+	return nil
+}
+
+func avlHeight(n *avlNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func avlBalance(n *avlNode) int {
+	return avlHeight(n.left) - avlHeight(n.right)
+}
+
+func avlUpdate(n *avlNode) {
+	n.height = 1 + max(avlHeight(n.left), avlHeight(n.right))
+}
+
+func avlMin(n *avlNode) *avlNode {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// avlRotateRight lifts y's left child into y's place:
+//
+//	    y          x
+//	   / \        / \
+//	  x   C  ->  A   y
+//	 / \            / \
+//	A   B          B   C
+func avlRotateRight(y *avlNode) *avlNode {
+	x := y.left
+	y.left = x.right
+	x.right = y
+	avlUpdate(y)
+	avlUpdate(x)
+	return x
+}
+
+// avlRotateLeft is the mirror image of avlRotateRight.
+func avlRotateLeft(x *avlNode) *avlNode {
+	y := x.right
+	x.right = y.left
+	y.left = x
+	avlUpdate(x)
+	avlUpdate(y)
+	return y
+}
+
+// avlRebalance restores the balance of n after one of its subtrees
+// changed height by one, returning the new subtree root.
+func avlRebalance(n *avlNode) *avlNode {
+	avlUpdate(n)
+	switch bf := avlBalance(n); {
+	case bf > 1:
+		if avlBalance(n.left) < 0 {
+			n.left = avlRotateLeft(n.left)
+		}
+		return avlRotateRight(n)
+	case bf < -1:
+		if avlBalance(n.right) > 0 {
+			n.right = avlRotateRight(n.right)
+		}
+		return avlRotateLeft(n)
+	}
+	return n
+}
+
+func avlInsert(n *avlNode, v int, inserted *bool) *avlNode {
+	if n == nil {
+		*inserted = true
+		return &avlNode{val: v, height: 1}
+	}
+	switch {
+	case v < n.val:
+		n.left = avlInsert(n.left, v, inserted)
+	case v > n.val:
+		n.right = avlInsert(n.right, v, inserted)
+	default:
+		return n
+	}
+	return avlRebalance(n)
+}
+
+func avlDelete(n *avlNode, v int, deleted *bool) *avlNode {
+	if n == nil {
+		return nil
+	}
+	switch {
+	case v < n.val:
+		n.left = avlDelete(n.left, v, deleted)
+	case v > n.val:
+		n.right = avlDelete(n.right, v, deleted)
+	default:
+		*deleted = true
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		// Replace with the in-order successor, then delete it from the
+		// right subtree.
+		succ := avlMin(n.right)
+		n.val = succ.val
+		var ignored bool
+		n.right = avlDelete(n.right, succ.val, &ignored)
+	}
+	return avlRebalance(n)
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// binaryVersion1 identifies the first binary tree format:
+//
+//	version byte (1)
+//	uvarint      node count
+//	per node, in preorder:
+//	    flags byte  (bit 0: has left child, bit 1: has right child)
+//	    varint      value
+//
+// Future formats must use a new version byte so old data stays readable.
+const binaryVersion1 byte = 1
+
+const (
+	binaryHasLeft  byte = 1 << 0
+	binaryHasRight byte = 1 << 1
+)
+
+// ErrBinaryFormat is returned (wrapped) when UnmarshalBinary,
+// UnmarshalMsgpack or UnmarshalCBOR is given data that is not a valid
+// encoded tree.
+var ErrBinaryFormat = errors.New("invalid binary tree encoding")
+
+// MarshalBinary implements encoding.BinaryMarshaler, which also makes
+// Tree usable with encoding/gob.
+func (t Tree) MarshalBinary() ([]byte, error) {
+	nodes := 0
+	eachNode(t.Root, func(*Node) { nodes++ })
+
+	buf := []byte{binaryVersion1}
+	buf = binary.AppendUvarint(buf, uint64(nodes))
+	eachNode(t.Root, func(n *Node) {
+		buf = append(buf, childFlags(n))
+		buf = binary.AppendVarint(buf, int64(n.Val))
+	})
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces
+// t.Root with the decoded tree and leaves t untouched on error.
+func (t *Tree) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("%w: empty input", ErrBinaryFormat)
+	}
+	if data[0] != binaryVersion1 {
+		return fmt.Errorf("%w: unsupported version %d", ErrBinaryFormat, data[0])
+	}
+	data = data[1:]
+
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return fmt.Errorf("%w: bad node count", ErrBinaryFormat)
+	}
+	data = data[n:]
+	// Every node takes at least two bytes, which bounds a hostile count.
+	if count > uint64(len(data)/2) {
+		return fmt.Errorf("%w: node count %d exceeds input size", ErrBinaryFormat, count)
+	}
+
+	var b preorderBuilder
+	for i := uint64(0); i < count; i++ {
+		if b.done() {
+			return fmt.Errorf("%w: node %d has no parent slot", ErrBinaryFormat, i)
+		}
+		if len(data) == 0 {
+			return fmt.Errorf("%w: truncated at node %d", ErrBinaryFormat, i)
+		}
+		flags := data[0]
+		if flags&^(binaryHasLeft|binaryHasRight) != 0 {
+			return fmt.Errorf("%w: node %d has unknown flags %#x", ErrBinaryFormat, i, flags)
+		}
+		v, n := binary.Varint(data[1:])
+		if n <= 0 {
+			return fmt.Errorf("%w: bad value for node %d", ErrBinaryFormat, i)
+		}
+		data = data[1+n:]
+		b.add(flags, int(v))
+	}
+	if b.pending() > 0 {
+		return fmt.Errorf("%w: %d children announced but missing", ErrBinaryFormat, b.pending())
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrBinaryFormat, len(data))
+	}
+
+	t.Root = b.root
+	return nil
+}
+
+// childFlags returns the binaryHasLeft/binaryHasRight flags of n.
+func childFlags(n *Node) byte {
+	var flags byte
+	if n.Left != nil {
+		flags |= binaryHasLeft
+	}
+	if n.Right != nil {
+		flags |= binaryHasRight
+	}
+	return flags
+}
+
+// preorderBuilder rebuilds a tree from its nodes in preorder, each with
+// the binaryHasLeft/binaryHasRight flags saying which children follow.
+// It is shared by the binary, MessagePack and CBOR decoders. The zero
+// value expects the root.
+type preorderBuilder struct {
+	root    *Node
+	slots   []**Node // links still waiting for a node, in preorder
+	started bool
+}
+
+// done reports whether the tree is complete, so no node may follow.
+func (b *preorderBuilder) done() bool {
+	return b.started && len(b.slots) == 0
+}
+
+// pending returns the number of announced children still missing.
+func (b *preorderBuilder) pending() int {
+	return len(b.slots)
+}
+
+// add attaches the next node. The caller must check done first.
+func (b *preorderBuilder) add(flags byte, val int) {
+	slot := &b.root
+	if b.started {
+		slot = b.slots[len(b.slots)-1]
+		b.slots = b.slots[:len(b.slots)-1]
+	}
+	b.started = true
+	node := &Node{Val: val}
+	*slot = node
+	if flags&binaryHasRight != 0 {
+		b.slots = append(b.slots, &node.Right)
+	}
+	if flags&binaryHasLeft != 0 {
+		b.slots = append(b.slots, &node.Left)
+	}
+}
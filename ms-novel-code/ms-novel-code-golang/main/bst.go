@@ -0,0 +1,168 @@
+package core
+
+// SearchTree is the interface shared by the package's binary search
+// trees, so callers can swap implementations without code changes.
+// Trees hold a set of distinct ints: inserting a value that is already
+// present is a no-op.
+type SearchTree interface {
+	// Insert adds v and reports whether it was not already present.
+	Insert(v int) bool
+	// Delete removes v and reports whether it was present.
+	Delete(v int) bool
+	// Search reports whether v is present.
+	Search(v int) bool
+	// Min returns the smallest value, or false if the tree is empty.
+	Min() (int, bool)
+	// Max returns the largest value, or false if the tree is empty.
+	Max() (int, bool)
+	// InOrder returns all values in ascending order.
+	InOrder() []int
+	// Len returns the number of values stored.
+	Len() int
+}
+
+// BST is an unbalanced binary search tree built directly on Node:
+// every value in a node's left subtree is smaller than the node's value
+// and every value in its right subtree is larger. Root is exported so
+// the rest of the package (rowWiseMax, LevelStats, ...) can run on it;
+// modifying it by hand must preserve that invariant.
+type BST struct {
+	Root *Node
+	size int
+}
+
+var _ SearchTree = (*BST)(nil)
+
+// NewBST returns a BST holding vals.
+func NewBST(vals ...int) *BST {
+	t := &BST{}
+	for _, v := range vals {
+		t.Insert(v)
+	}
+	return t
+}
+
+// Insert adds v and reports whether it was not already present.
+func (t *BST) Insert(v int) bool {
+	link := &t.Root
+	for *link != nil {
+		switch {
+		case v < (*link).Val:
+			link = &(*link).Left
+		case v > (*link).Val:
+			link = &(*link).Right
+		default:
+			return false
+		}
+	}
+	*link = &Node{Val: v}
+	t.size++
+	return true
+}
+
+// Delete removes v and reports whether it was present. A node with two
+// children is replaced by its in-order successor.
+func (t *BST) Delete(v int) bool {
+	link := t.find(v)
+	if *link == nil {
+		return false
+	}
+
+	node := *link
+	switch {
+	case node.Left == nil:
+		*link = node.Right
+	case node.Right == nil:
+		*link = node.Left
+	default:
+		// Unlink the successor (left-most node of the right subtree)
+		// and move its value into node.
+		succ := &node.Right
+		for (*succ).Left != nil {
+			succ = &(*succ).Left
+		}
+		node.Val = (*succ).Val
+		*succ = (*succ).Right
+	}
+	t.size--
+	return true
+}
+
+// Search reports whether v is present.
+func (t *BST) Search(v int) bool {
+	return *t.find(v) != nil
+}
+
+// Node returns the node holding v, or nil if v is absent.
+func (t *BST) Node(v int) *Node {
+	return *t.find(v)
+}
+
+// Min returns the smallest value, or false if the tree is empty.
+func (t *BST) Min() (int, bool) {
+	if t.Root == nil {
+		return 0, false
+	}
+	node := t.Root
+	for node.Left != nil {
+		node = node.Left
+	}
+	return node.Val, true
+}
+
+// Max returns the largest value, or false if the tree is empty.
+func (t *BST) Max() (int, bool) {
+	if t.Root == nil {
+		return 0, false
+	}
+	node := t.Root
+	for node.Right != nil {
+		node = node.Right
+	}
+	return node.Val, true
+}
+
+// InOrder returns all values in ascending order. It walks the tree with
+// an explicit stack, so degenerate (list-shaped) trees are safe.
+func (t *BST) InOrder() []int {
+	res := make([]int, 0, t.size)
+	var stack []*Node
+	node := t.Root
+	for node != nil || len(stack) > 0 {
+		for node != nil {
+			stack = append(stack, node)
+			node = node.Left
+		}
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		res = append(res, node.Val)
+		node = node.Right
+	}
+	return res
+}
+
+// Len returns the number of values stored.
+func (t *BST) Len() int {
+	return t.size
+}
+
+// find returns the link (the root pointer or a parent's child pointer)
+// that holds v, or the nil link where v would be inserted.
+func (t *BST) find(v int) **Node {
+	link := &t.Root
+	for *link != nil && (*link).Val != v {
+		if v < (*link).Val {
+			link = &(*link).Left
+		} else {
+			link = &(*link).Right
+		}
+	}
+	return link
+}
+
+// Rebalance rebuilds the tree into height-balanced form in O(n),
+// reusing the existing nodes. Useful after bulk inserts into a
+// read-heavy tree.
+func (t *BST) Rebalance() {
+	t.Root = RebalanceBST(t.Root)
+}
@@ -0,0 +1,138 @@
+package core
+
+import "cmp"
+
+// RangeValues returns the values of a binary search tree that lie in
+// [lo, hi], in ascending order. Subtrees entirely outside the range are
+// never entered, so it costs O(h + m) for m results. The returned slice
+// is never nil, even when nothing matches.
+func RangeValues[T cmp.Ordered](root *TreeNode[T], lo, hi T) []T {
+	res := []T{}
+	bstRange(root, lo, hi, func(v T) { res = append(res, v) })
+	return res
+}
+
+// RangeCount returns how many values of a binary search tree lie in
+// [lo, hi], visiting only the nodes RangeValues would.
+func RangeCount[T cmp.Ordered](root *TreeNode[T], lo, hi T) int {
+	count := 0
+	bstRange(root, lo, hi, func(T) { count++ })
+	return count
+}
+
+// RangeSum returns the sum of the values of a binary search tree that
+// lie in [lo, hi], visiting only the nodes RangeValues would.
+func RangeSum(root *Node, lo, hi int) int {
+	sum := 0
+	bstRange(root, lo, hi, func(v int) { sum += v })
+	return sum
+}
+
+// bstRange calls fn, in ascending order, for each value in [lo, hi].
+// It is an inorder walk that skips left subtrees of nodes below lo and
+// stops at the first node above hi.
+func bstRange[T cmp.Ordered](root *TreeNode[T], lo, hi T, fn func(T)) {
+	var stack []*TreeNode[T]
+	node := root
+	for node != nil || len(stack) > 0 {
+		for node != nil {
+			if node.Val < lo {
+				node = node.Right // node and its left subtree are too small
+				continue
+			}
+			stack = append(stack, node)
+			node = node.Left
+		}
+		if len(stack) == 0 {
+			return
+		}
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if node.Val > hi {
+			return // every later value is larger still
+		}
+		fn(node.Val)
+		node = node.Right
+	}
+}
+
+// TrimBST removes, in place, every node of a binary search tree whose
+// value lies outside [lo, hi], and returns the new root. The remaining
+// nodes keep their relative structure, so the result is still a valid
+// BST. Only the two boundary spines are walked, so it costs O(h) rather
+// than O(n).
+func TrimBST[T cmp.Ordered](root *TreeNode[T], lo, hi T) *TreeNode[T] {
+	return trimBST(root, lo, hi, false)
+}
+
+// TrimBSTCopy is the copy-on-write form of TrimBST: it returns the
+// trimmed tree without modifying root. Nodes whose children change are
+// copied; every other subtree is shared with root, so root must not be
+// modified while the result is in use.
+func TrimBSTCopy[T cmp.Ordered](root *TreeNode[T], lo, hi T) *TreeNode[T] {
+	return trimBST(root, lo, hi, true)
+}
+
+func trimBST[T cmp.Ordered](root *TreeNode[T], lo, hi T, cow bool) *TreeNode[T] {
+	for root != nil && (root.Val < lo || root.Val > hi) {
+		if root.Val < lo {
+			root = root.Right
+		} else {
+			root = root.Left
+		}
+	}
+	if root == nil {
+		return nil
+	}
+	// Below an in-range node, the left subtree can only violate lo and
+	// the right subtree only hi.
+	left := trimSide(root.Left, lo, true, cow)
+	right := trimSide(root.Right, hi, false, cow)
+	return relink(root, left, right, cow)
+}
+
+// trimSide trims a subtree against a single bound: lo if low is set,
+// otherwise hi. The nodes that survive on the side facing the bound form
+// a spine; each is relinked to the next, bottom-up.
+func trimSide[T cmp.Ordered](n *TreeNode[T], bound T, low, cow bool) *TreeNode[T] {
+	var spine []*TreeNode[T]
+	for n != nil {
+		switch {
+		case low && n.Val < bound:
+			n = n.Right
+		case !low && n.Val > bound:
+			n = n.Left
+		case low:
+			spine = append(spine, n)
+			n = n.Left
+		default:
+			spine = append(spine, n)
+			n = n.Right
+		}
+	}
+
+	var child *TreeNode[T]
+	for i := len(spine) - 1; i >= 0; i-- {
+		s := spine[i]
+		if low {
+			child = relink(s, child, s.Right, cow)
+		} else {
+			child = relink(s, s.Left, child, cow)
+		}
+	}
+	return child
+}
+
+// relink gives n the children left and right, returning n itself when
+// they are unchanged. With cow set, n is copied rather than modified.
+func relink[T any](n, left, right *TreeNode[T], cow bool) *TreeNode[T] {
+	if n.Left == left && n.Right == right {
+		return n
+	}
+	if cow {
+		cp := *n
+		n = &cp
+	}
+	n.Left, n.Right = left, right
+	return n
+}
@@ -0,0 +1,398 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// btreeNode holds between degree-1 and 2*degree-1 sorted keys (the root
+// may hold fewer) and, unless it is a leaf, one more child than keys.
+type btreeNode[K cmp.Ordered, V any] struct {
+	keys     []K
+	vals     []V
+	children []*btreeNode[K, V]
+}
+
+func (n *btreeNode[K, V]) leaf() bool { return len(n.children) == 0 }
+
+// BTree is an in-memory B-tree mapping keys to values. Each node stores
+// many keys in contiguous slices, so lookups touch a handful of cache
+// lines per level instead of one per key as in a binary tree, and the
+// height stays around log_degree(n). All leaves are at the same depth.
+//
+// A BTree is not safe for concurrent use.
+type BTree[K cmp.Ordered, V any] struct {
+	root   *btreeNode[K, V]
+	degree int
+	size   int
+}
+
+var _ OrderedMap[int, int] = (*BTree[int, int])(nil)
+
+// NewBTree returns an empty B-tree with the given minimum degree: every
+// node except the root holds between degree-1 and 2*degree-1 keys, so
+// the branching factor is between degree and 2*degree. It panics if
+// degree is less than 2.
+func NewBTree[K cmp.Ordered, V any](degree int) *BTree[K, V] {
+	if degree < 2 {
+		panic(fmt.Sprintf("core: B-tree degree %d is less than 2", degree))
+	}
+	return &BTree[K, V]{degree: degree}
+}
+
+// Len returns the number of keys stored.
+func (t *BTree[K, V]) Len() int { return t.size }
+
+// Height returns the number of levels, or 0 for an empty tree.
+func (t *BTree[K, V]) Height() int {
+	h := 0
+	for n := t.root; n != nil; h++ {
+		if n.leaf() {
+			return h + 1
+		}
+		n = n.children[0]
+	}
+	return h
+}
+
+// Get returns the value stored under key.
+func (t *BTree[K, V]) Get(key K) (V, bool) {
+	for n := t.root; n != nil; {
+		i, found := slices.BinarySearch(n.keys, key)
+		if found {
+			return n.vals[i], true
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	var zero V
+	return zero, false
+}
+
+// Insert stores val under key, replacing any previous value, and
+// reports whether key was not already present. Full nodes are split on
+// the way down, so the insertion never has to back up the tree.
+func (t *BTree[K, V]) Insert(key K, val V) bool {
+	if t.root == nil {
+		t.root = &btreeNode[K, V]{keys: []K{key}, vals: []V{val}}
+		t.size++
+		return true
+	}
+	if len(t.root.keys) == 2*t.degree-1 {
+		t.root = &btreeNode[K, V]{children: []*btreeNode[K, V]{t.root}}
+		t.splitChild(t.root, 0)
+	}
+
+	n := t.root
+	for {
+		i, found := slices.BinarySearch(n.keys, key)
+		if found {
+			n.vals[i] = val
+			return false
+		}
+		if n.leaf() {
+			n.keys = slices.Insert(n.keys, i, key)
+			n.vals = slices.Insert(n.vals, i, val)
+			t.size++
+			return true
+		}
+		if len(n.children[i].keys) == 2*t.degree-1 {
+			t.splitChild(n, i)
+			switch c := cmp.Compare(key, n.keys[i]); {
+			case c == 0:
+				n.vals[i] = val
+				return false
+			case c > 0:
+				i++
+			}
+		}
+		n = n.children[i]
+	}
+}
+
+// Put is Insert under the name OrderedMap uses.
+func (t *BTree[K, V]) Put(key K, val V) bool {
+	return t.Insert(key, val)
+}
+
+// splitChild splits the full child n.children[i] around its median key,
+// which moves up into n.
+func (t *BTree[K, V]) splitChild(n *btreeNode[K, V], i int) {
+	d := t.degree
+	y := n.children[i]
+	z := &btreeNode[K, V]{
+		keys: slices.Clone(y.keys[d:]),
+		vals: slices.Clone(y.vals[d:]),
+	}
+	if !y.leaf() {
+		z.children = slices.Clone(y.children[d:])
+		clear(y.children[d:])
+		y.children = y.children[:d]
+	}
+	midKey, midVal := y.keys[d-1], y.vals[d-1]
+	clear(y.keys[d-1:])
+	clear(y.vals[d-1:])
+	y.keys, y.vals = y.keys[:d-1], y.vals[:d-1]
+
+	n.keys = slices.Insert(n.keys, i, midKey)
+	n.vals = slices.Insert(n.vals, i, midVal)
+	n.children = slices.Insert(n.children, i+1, z)
+}
+
+// Delete removes key and reports whether it was present.
+func (t *BTree[K, V]) Delete(key K) bool {
+	if t.root == nil {
+		return false
+	}
+	deleted := t.delete(t.root, key)
+	if len(t.root.keys) == 0 {
+		if t.root.leaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+// delete removes key from the subtree rooted at n, which holds at least
+// degree keys unless it is the root. Before descending it tops up the
+// child it will enter to degree keys, by borrowing from a sibling or
+// merging with one, so a key can always be removed from a leaf without
+// underflow. The recursion is bounded by the tree's height.
+func (t *BTree[K, V]) delete(n *btreeNode[K, V], key K) bool {
+	d := t.degree
+	i, found := slices.BinarySearch(n.keys, key)
+	if n.leaf() {
+		if found {
+			n.keys = slices.Delete(n.keys, i, i+1)
+			n.vals = slices.Delete(n.vals, i, i+1)
+		}
+		return found
+	}
+
+	if found {
+		switch left, right := n.children[i], n.children[i+1]; {
+		case len(left.keys) >= d:
+			// Replace key with its predecessor and delete that instead.
+			p := left
+			for !p.leaf() {
+				p = p.children[len(p.children)-1]
+			}
+			last := len(p.keys) - 1
+			n.keys[i], n.vals[i] = p.keys[last], p.vals[last]
+			return t.delete(left, n.keys[i])
+		case len(right.keys) >= d:
+			s := right
+			for !s.leaf() {
+				s = s.children[0]
+			}
+			n.keys[i], n.vals[i] = s.keys[0], s.vals[0]
+			return t.delete(right, n.keys[i])
+		default:
+			t.merge(n, i)
+			return t.delete(left, key)
+		}
+	}
+
+	if len(n.children[i].keys) < d {
+		switch {
+		case i > 0 && len(n.children[i-1].keys) >= d:
+			t.borrowFromLeft(n, i)
+		case i < len(n.keys) && len(n.children[i+1].keys) >= d:
+			t.borrowFromRight(n, i)
+		case i < len(n.keys):
+			t.merge(n, i)
+		default:
+			t.merge(n, i-1)
+			i--
+		}
+	}
+	return t.delete(n.children[i], key)
+}
+
+// merge folds n.keys[i] and n.children[i+1] into n.children[i].
+func (t *BTree[K, V]) merge(n *btreeNode[K, V], i int) {
+	left, right := n.children[i], n.children[i+1]
+	left.keys = append(append(left.keys, n.keys[i]), right.keys...)
+	left.vals = append(append(left.vals, n.vals[i]), right.vals...)
+	left.children = append(left.children, right.children...)
+
+	n.keys = slices.Delete(n.keys, i, i+1)
+	n.vals = slices.Delete(n.vals, i, i+1)
+	n.children = slices.Delete(n.children, i+1, i+2)
+}
+
+// borrowFromLeft rotates a key from n.children[i-1] through n into
+// n.children[i].
+func (t *BTree[K, V]) borrowFromLeft(n *btreeNode[K, V], i int) {
+	child, sib := n.children[i], n.children[i-1]
+	last := len(sib.keys) - 1
+
+	child.keys = slices.Insert(child.keys, 0, n.keys[i-1])
+	child.vals = slices.Insert(child.vals, 0, n.vals[i-1])
+	n.keys[i-1], n.vals[i-1] = sib.keys[last], sib.vals[last]
+	sib.keys = slices.Delete(sib.keys, last, last+1)
+	sib.vals = slices.Delete(sib.vals, last, last+1)
+	if !sib.leaf() {
+		lc := len(sib.children) - 1
+		child.children = slices.Insert(child.children, 0, sib.children[lc])
+		sib.children = slices.Delete(sib.children, lc, lc+1)
+	}
+}
+
+// borrowFromRight rotates a key from n.children[i+1] through n into
+// n.children[i].
+func (t *BTree[K, V]) borrowFromRight(n *btreeNode[K, V], i int) {
+	child, sib := n.children[i], n.children[i+1]
+
+	child.keys = append(child.keys, n.keys[i])
+	child.vals = append(child.vals, n.vals[i])
+	n.keys[i], n.vals[i] = sib.keys[0], sib.vals[0]
+	sib.keys = slices.Delete(sib.keys, 0, 1)
+	sib.vals = slices.Delete(sib.vals, 0, 1)
+	if !sib.leaf() {
+		child.children = append(child.children, sib.children[0])
+		sib.children = slices.Delete(sib.children, 0, 1)
+	}
+}
+
+// Min returns the smallest key and its value, or false if the tree is
+// empty.
+func (t *BTree[K, V]) Min() (K, V, bool) {
+	if t.root == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	n := t.root
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return n.keys[0], n.vals[0], true
+}
+
+// Max returns the largest key and its value, or false if the tree is
+// empty.
+func (t *BTree[K, V]) Max() (K, V, bool) {
+	if t.root == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	n := t.root
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	last := len(n.keys) - 1
+	return n.keys[last], n.vals[last], true
+}
+
+// All returns an iterator over the keys and values in ascending key
+// order. The tree must not be modified during iteration.
+func (t *BTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t.root != nil {
+			t.root.scan(nil, nil, yield)
+		}
+	}
+}
+
+// Range returns an iterator over the keys in [lo, hi] and their values,
+// in ascending key order. Only the nodes overlapping the range are
+// visited, so a scan costs O(log n + m) for m results. The tree must
+// not be modified during iteration.
+func (t *BTree[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t.root != nil && lo <= hi {
+			t.root.scan(&lo, &hi, yield)
+		}
+	}
+}
+
+// scan yields the entries of n's subtree within the optional bounds, in
+// order, and reports whether yield asked to continue.
+func (n *btreeNode[K, V]) scan(lo, hi *K, yield func(K, V) bool) bool {
+	i := 0
+	if lo != nil {
+		i, _ = slices.BinarySearch(n.keys, *lo)
+	}
+	for ; i <= len(n.keys); i++ {
+		if !n.leaf() && !n.children[i].scan(lo, hi, yield) {
+			return false
+		}
+		if i == len(n.keys) {
+			break
+		}
+		if hi != nil && n.keys[i] > *hi {
+			return false
+		}
+		if !yield(n.keys[i], n.vals[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks the B-tree invariants: keys sorted within and across
+// nodes, key counts within the degree's bounds, one more child than keys
+// in internal nodes, every leaf at the same depth, and a key count that
+// matches Len. It is meant for tests and debugging.
+func (t *BTree[K, V]) Validate() error {
+	if t.root == nil {
+		if t.size != 0 {
+			return fmt.Errorf("btree: empty tree has Len %d", t.size)
+		}
+		return nil
+	}
+	count, leafDepth := 0, -1
+	var prev *K
+	var check func(n *btreeNode[K, V], depth int) error
+	check = func(n *btreeNode[K, V], depth int) error {
+		if n != t.root && (len(n.keys) < t.degree-1 || len(n.keys) > 2*t.degree-1) {
+			return fmt.Errorf("btree: node at depth %d holds %d keys", depth, len(n.keys))
+		}
+		if len(n.vals) != len(n.keys) {
+			return fmt.Errorf("btree: node at depth %d has %d keys but %d values", depth, len(n.keys), len(n.vals))
+		}
+		if n.leaf() {
+			if leafDepth < 0 {
+				leafDepth = depth
+			} else if depth != leafDepth {
+				return fmt.Errorf("btree: leaves at depths %d and %d", leafDepth, depth)
+			}
+		} else if len(n.children) != len(n.keys)+1 {
+			return fmt.Errorf("btree: node at depth %d has %d keys but %d children", depth, len(n.keys), len(n.children))
+		}
+		for i := 0; i <= len(n.keys); i++ {
+			if !n.leaf() {
+				if err := check(n.children[i], depth+1); err != nil {
+					return err
+				}
+			}
+			if i == len(n.keys) {
+				break
+			}
+			if prev != nil && n.keys[i] <= *prev {
+				return fmt.Errorf("btree: key %v follows %v", n.keys[i], *prev)
+			}
+			prev = &n.keys[i]
+			count++
+		}
+		return nil
+	}
+	if err := check(t.root, 0); err != nil {
+		return err
+	}
+	if count != t.size {
+		return fmt.Errorf("btree: found %d keys but Len is %d", count, t.size)
+	}
+	return nil
+}
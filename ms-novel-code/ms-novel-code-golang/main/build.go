@@ -0,0 +1,218 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// BuildFromLevelOrder builds a tree from a LeetCode-style level-order
+// slice, where a nil entry marks a missing child. Children are only
+// listed for nodes that exist, and trailing nils may be omitted.
+// An empty slice or a nil first entry yields a nil tree.
+func BuildFromLevelOrder[T any](vals []*T) *TreeNode[T] {
+	if len(vals) == 0 || vals[0] == nil {
+		return nil
+	}
+
+	root := &TreeNode[T]{Val: *vals[0]}
+	var queue ringQueue[*TreeNode[T]]
+	queue.Push(root)
+	i := 1
+
+	for queue.Len() > 0 && i < len(vals) {
+		node := queue.Pop()
+
+		if vals[i] != nil {
+			node.Left = &TreeNode[T]{Val: *vals[i]}
+			queue.Push(node.Left)
+		}
+		i++
+		if i < len(vals) && vals[i] != nil {
+			node.Right = &TreeNode[T]{Val: *vals[i]}
+			queue.Push(node.Right)
+		}
+		i++
+	}
+
+	return root
+}
+
+// ToLevelOrder is the inverse of BuildFromLevelOrder: it lists the
+// tree's values level by level with nil for missing children of
+// existing nodes, trimming trailing nils. The returned slice is never
+// nil, even for an empty tree.
+func ToLevelOrder[T any](root *TreeNode[T]) []*T {
+	res := []*T{}
+	if root == nil {
+		return res
+	}
+
+	var queue ringQueue[*TreeNode[T]]
+	queue.Push(root)
+	for queue.Len() > 0 {
+		node := queue.Pop()
+
+		if node == nil {
+			res = append(res, nil)
+			continue
+		}
+		v := node.Val
+		res = append(res, &v)
+		queue.Push(node.Left)
+		queue.Push(node.Right)
+	}
+
+	// Drop the nils recorded for the children of the last level.
+	for len(res) > 0 && res[len(res)-1] == nil {
+		res = res[:len(res)-1]
+	}
+	return res
+}
+
+// ErrDuplicateValue is returned (wrapped) by BuildFromPreIn and
+// BuildFromPostIn when a value occurs more than once, which makes the
+// tree ambiguous.
+var ErrDuplicateValue = errors.New("duplicate value")
+
+// ErrInconsistentTraversals is returned (wrapped) by BuildFromPreIn and
+// BuildFromPostIn when the two traversals cannot describe the same tree.
+var ErrInconsistentTraversals = errors.New("traversals are inconsistent")
+
+// BuildFromPreIn rebuilds the tree whose preorder and inorder
+// traversals are pre and in. Values must be distinct.
+func BuildFromPreIn[T comparable](pre, in []T) (*TreeNode[T], error) {
+	if err := checkTraversalPair("preorder", pre, in); err != nil {
+		return nil, err
+	}
+	root := buildFromOrderAndIn(pre, in, false)
+	if !slices.Equal(Inorder(root), in) {
+		return nil, fmt.Errorf("%w: no tree has this preorder and inorder", ErrInconsistentTraversals)
+	}
+	return root, nil
+}
+
+// BuildFromPostIn rebuilds the tree whose postorder and inorder
+// traversals are post and in. Values must be distinct.
+func BuildFromPostIn[T comparable](post, in []T) (*TreeNode[T], error) {
+	if err := checkTraversalPair("postorder", post, in); err != nil {
+		return nil, err
+	}
+	// Reversed postorder is node-right-left preorder and reversed inorder
+	// is right-node-left, so the preorder algorithm applies with the
+	// children swapped.
+	root := buildFromOrderAndIn(reversed(post), reversed(in), true)
+	if !slices.Equal(Inorder(root), in) {
+		return nil, fmt.Errorf("%w: no tree has this postorder and inorder", ErrInconsistentTraversals)
+	}
+	return root, nil
+}
+
+// checkTraversalPair verifies that order and in have the same length,
+// contain no duplicates, and hold the same values.
+func checkTraversalPair[T comparable](name string, order, in []T) error {
+	if len(order) != len(in) {
+		return fmt.Errorf("%w: %s has %d values, inorder has %d",
+			ErrInconsistentTraversals, name, len(order), len(in))
+	}
+	seen := make(map[T]bool, len(in))
+	for _, v := range in {
+		if seen[v] {
+			return fmt.Errorf("%w: %v appears twice in inorder", ErrDuplicateValue, v)
+		}
+		seen[v] = true
+	}
+	for _, v := range order {
+		if _, ok := seen[v]; !ok {
+			return fmt.Errorf("%w: %v is in %s but not in inorder", ErrInconsistentTraversals, v, name)
+		}
+		if !seen[v] {
+			return fmt.Errorf("%w: %v appears twice in %s", ErrDuplicateValue, v, name)
+		}
+		seen[v] = false
+	}
+	return nil
+}
+
+// buildFromOrderAndIn is the O(n) stack-based construction from a
+// preorder and an inorder sequence. With mirror set, "left" and "right"
+// are swapped, which handles the reversed postorder case.
+func buildFromOrderAndIn[T comparable](pre, in []T, mirror bool) *TreeNode[T] {
+	if len(pre) == 0 {
+		return nil
+	}
+	attach := func(parent, child *TreeNode[T], first bool) {
+		if first != mirror {
+			parent.Left = child
+		} else {
+			parent.Right = child
+		}
+	}
+
+	root := &TreeNode[T]{Val: pre[0]}
+	stack := []*TreeNode[T]{root}
+	j := 0 // next unmatched position in in
+	for _, v := range pre[1:] {
+		node := &TreeNode[T]{Val: v}
+		parent := stack[len(stack)-1]
+		if parent.Val != in[j] {
+			// parent's first subtree is not finished: descend into it.
+			attach(parent, node, true)
+		} else {
+			// Pop every ancestor whose first subtree is complete; node
+			// starts the second subtree of the last one popped.
+			for len(stack) > 0 && stack[len(stack)-1].Val == in[j] {
+				parent = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				j++
+			}
+			attach(parent, node, false)
+		}
+		stack = append(stack, node)
+	}
+	return root
+}
+
+// reversed returns a reversed copy of s.
+func reversed[T any](s []T) []T {
+	out := slices.Clone(s)
+	slices.Reverse(out)
+	return out
+}
+
+// BuildBalancedBST builds a height-balanced binary search tree from
+// values in ascending order: each subtree's root is the middle of its
+// range, so the tree has the minimum possible height. It does not check
+// that sorted is actually sorted.
+func BuildBalancedBST[T any](sorted []T) *TreeNode[T] {
+	nodes := make([]*TreeNode[T], len(sorted))
+	for i, v := range sorted {
+		nodes[i] = &TreeNode[T]{Val: v}
+	}
+	return linkBalanced(nodes)
+}
+
+// RebalanceBST rebuilds a binary search tree into height-balanced form
+// and returns the new root. The existing nodes are relinked in place
+// rather than copied, so no nodes are allocated.
+func RebalanceBST[T any](root *TreeNode[T]) *TreeNode[T] {
+	var nodes []*TreeNode[T]
+	walkDepthFirst(root, OrderIn, func(n *TreeNode[T], _ int) bool {
+		nodes = append(nodes, n)
+		return true
+	})
+	return linkBalanced(nodes)
+}
+
+// linkBalanced rewires nodes, given in inorder, into a height-balanced
+// tree and returns its root. Recursion depth is O(log n).
+func linkBalanced[T any](nodes []*TreeNode[T]) *TreeNode[T] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	mid := len(nodes) / 2
+	root := nodes[mid]
+	root.Left = linkBalanced(nodes[:mid])
+	root.Right = linkBalanced(nodes[mid+1:])
+	return root
+}
@@ -0,0 +1,253 @@
+package core
+
+import "fmt"
+
+// cacheConfig holds the settings applied by CacheOption values.
+type cacheConfig[K comparable, V any] struct {
+	onEvict func(K, V)
+}
+
+// CacheOption customises an LRUCache or LFUCache.
+type CacheOption[K comparable, V any] func(*cacheConfig[K, V])
+
+// WithOnEvict registers fn to be called with every entry the cache
+// evicts to make room. Entries removed by Delete or replaced by Put are
+// not reported.
+func WithOnEvict[K comparable, V any](fn func(K, V)) CacheOption[K, V] {
+	return func(c *cacheConfig[K, V]) { c.onEvict = fn }
+}
+
+func newCacheConfig[K comparable, V any](capacity int, opts []CacheOption[K, V]) cacheConfig[K, V] {
+	if capacity < 1 {
+		panic(fmt.Sprintf("core: cache capacity %d must be at least 1", capacity))
+	}
+	var cfg cacheConfig[K, V]
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// lruEntry is a node of an LRUCache's recency list.
+type lruEntry[K comparable, V any] struct {
+	key        K
+	val        V
+	prev, next *lruEntry[K, V]
+}
+
+// LRUCache is a fixed-capacity cache that evicts the least recently used
+// entry when full. Every operation is O(1): a map finds entries and a
+// circular doubly-linked list keeps them in recency order. It is not
+// safe for concurrent use.
+type LRUCache[K comparable, V any] struct {
+	cfg      cacheConfig[K, V]
+	capacity int
+	entries  map[K]*lruEntry[K, V]
+	list     lruEntry[K, V] // sentinel: list.next is the most recent
+}
+
+// NewLRUCache returns an empty LRUCache holding at most capacity
+// entries. It panics if capacity < 1.
+func NewLRUCache[K comparable, V any](capacity int, opts ...CacheOption[K, V]) *LRUCache[K, V] {
+	c := &LRUCache[K, V]{
+		cfg:      newCacheConfig(capacity, opts),
+		capacity: capacity,
+		entries:  make(map[K]*lruEntry[K, V], capacity),
+	}
+	c.list.prev, c.list.next = &c.list, &c.list
+	return c
+}
+
+// Len returns the number of entries cached.
+func (c *LRUCache[K, V]) Len() int { return len(c.entries) }
+
+// Cap returns the cache's capacity.
+func (c *LRUCache[K, V]) Cap() int { return c.capacity }
+
+// Get returns the value cached under key, marking it most recently
+// used, or false if key is not cached.
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.unlink(e)
+	c.pushFront(e)
+	return e.val, true
+}
+
+// Peek is Get without marking key as used.
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	if e, ok := c.entries[key]; ok {
+		return e.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put caches val under key as the most recently used entry, evicting
+// the least recently used one if the cache is full, and reports whether
+// key was not already cached.
+func (c *LRUCache[K, V]) Put(key K, val V) bool {
+	if e, ok := c.entries[key]; ok {
+		e.val = val
+		c.unlink(e)
+		c.pushFront(e)
+		return false
+	}
+	if len(c.entries) == c.capacity {
+		victim := c.list.prev
+		c.unlink(victim)
+		delete(c.entries, victim.key)
+		if c.cfg.onEvict != nil {
+			c.cfg.onEvict(victim.key, victim.val)
+		}
+	}
+	e := &lruEntry[K, V]{key: key, val: val}
+	c.entries[key] = e
+	c.pushFront(e)
+	return true
+}
+
+// Delete removes key and reports whether it was cached.
+func (c *LRUCache[K, V]) Delete(key K) bool {
+	e, ok := c.entries[key]
+	if ok {
+		c.unlink(e)
+		delete(c.entries, key)
+	}
+	return ok
+}
+
+// Keys returns the cached keys from most to least recently used. The
+// returned slice is never nil.
+func (c *LRUCache[K, V]) Keys() []K {
+	keys := make([]K, 0, len(c.entries))
+	for e := c.list.next; e != &c.list; e = e.next {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+func (c *LRUCache[K, V]) unlink(e *lruEntry[K, V]) {
+	e.prev.next, e.next.prev = e.next, e.prev
+}
+
+func (c *LRUCache[K, V]) pushFront(e *lruEntry[K, V]) {
+	e.prev, e.next = &c.list, c.list.next
+	c.list.next.prev = e
+	c.list.next = e
+}
+
+// lfuEntry is an LFUCache entry; uses and tick order it for eviction.
+type lfuEntry[K comparable, V any] struct {
+	key  K
+	val  V
+	uses int
+	tick uint64 // time of last use, to break ties by recency
+}
+
+// LFUCache is a fixed-capacity cache that evicts the least frequently
+// used entry when full, breaking ties by evicting the least recently
+// used of them. Entries sit in a PriorityQueue ordered by (uses, last
+// use), so Get and Put are O(log n). Peek does not count as a use. It
+// is not safe for concurrent use.
+type LFUCache[K comparable, V any] struct {
+	cfg      cacheConfig[K, V]
+	capacity int
+	entries  map[K]*PQHandle[lfuEntry[K, V]]
+	queue    *PriorityQueue[lfuEntry[K, V]]
+	clock    uint64
+}
+
+// NewLFUCache returns an empty LFUCache holding at most capacity
+// entries. It panics if capacity < 1.
+func NewLFUCache[K comparable, V any](capacity int, opts ...CacheOption[K, V]) *LFUCache[K, V] {
+	return &LFUCache[K, V]{
+		cfg:      newCacheConfig(capacity, opts),
+		capacity: capacity,
+		entries:  make(map[K]*PQHandle[lfuEntry[K, V]], capacity),
+		queue: NewPriorityQueue(func(a, b lfuEntry[K, V]) bool {
+			if a.uses != b.uses {
+				return a.uses < b.uses
+			}
+			return a.tick < b.tick
+		}),
+	}
+}
+
+// Len returns the number of entries cached.
+func (c *LFUCache[K, V]) Len() int { return len(c.entries) }
+
+// Cap returns the cache's capacity.
+func (c *LFUCache[K, V]) Cap() int { return c.capacity }
+
+// Get returns the value cached under key, counting a use, or false if
+// key is not cached.
+func (c *LFUCache[K, V]) Get(key K) (V, bool) {
+	h, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	e := h.Value()
+	c.touch(h, e.val)
+	return e.val, true
+}
+
+// Peek is Get without counting a use.
+func (c *LFUCache[K, V]) Peek(key K) (V, bool) {
+	if h, ok := c.entries[key]; ok {
+		return h.Value().val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Uses returns how many times key has been put or read since it was
+// cached, or 0 if it is not cached.
+func (c *LFUCache[K, V]) Uses(key K) int {
+	if h, ok := c.entries[key]; ok {
+		return h.Value().uses
+	}
+	return 0
+}
+
+// Put caches val under key, evicting the least frequently used entry if
+// the cache is full, and reports whether key was not already cached.
+// Replacing the value of a cached key counts as a use.
+func (c *LFUCache[K, V]) Put(key K, val V) bool {
+	if h, ok := c.entries[key]; ok {
+		c.touch(h, val)
+		return false
+	}
+	if len(c.entries) == c.capacity {
+		victim, _ := c.queue.Pop()
+		delete(c.entries, victim.key)
+		if c.cfg.onEvict != nil {
+			c.cfg.onEvict(victim.key, victim.val)
+		}
+	}
+	c.clock++
+	c.entries[key] = c.queue.Push(lfuEntry[K, V]{key: key, val: val, uses: 1, tick: c.clock})
+	return true
+}
+
+// Delete removes key and reports whether it was cached.
+func (c *LFUCache[K, V]) Delete(key K) bool {
+	h, ok := c.entries[key]
+	if ok {
+		c.queue.Remove(h)
+		delete(c.entries, key)
+	}
+	return ok
+}
+
+// touch records a use of h's entry and stores val in it.
+func (c *LFUCache[K, V]) touch(h *PQHandle[lfuEntry[K, V]], val V) {
+	e := h.Value()
+	c.clock++
+	e.val, e.uses, e.tick = val, e.uses+1, c.clock
+	c.queue.UpdatePriority(h, e)
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// CBOR major types used by the tree encoding.
+const (
+	cborUnsigned byte = 0 << 5
+	cborNegative byte = 1 << 5
+	cborArray    byte = 4 << 5
+)
+
+// MarshalCBOR encodes the tree as CBOR (RFC 8949) in the same layout as
+// MarshalMsgpack: a flat array holding, for each node in preorder, its
+// child flags and then its value, each as an integer in its shortest
+// form. The method matches the Marshaler interface of the common Go
+// CBOR libraries, so a Tree can be embedded in their messages without
+// this package depending on them.
+func (t Tree) MarshalCBOR() ([]byte, error) {
+	nodes := 0
+	eachNode(t.Root, func(*Node) { nodes++ })
+
+	buf := cborAppendHead(nil, cborArray, uint64(2*nodes))
+	eachNode(t.Root, func(n *Node) {
+		buf = cborAppendHead(buf, cborUnsigned, uint64(childFlags(n)))
+		if n.Val >= 0 {
+			buf = cborAppendHead(buf, cborUnsigned, uint64(n.Val))
+		} else {
+			buf = cborAppendHead(buf, cborNegative, uint64(-1-int64(n.Val)))
+		}
+	})
+	return buf, nil
+}
+
+// UnmarshalCBOR decodes the format written by MarshalCBOR, accepting
+// any definite-length integer and array encoding. It replaces t.Root
+// with the decoded tree and leaves t untouched on error, which wraps
+// ErrBinaryFormat.
+func (t *Tree) UnmarshalCBOR(data []byte) error {
+	major, count, data, err := cborHead(data)
+	if err != nil {
+		return err
+	}
+	// Every integer takes at least one byte, which bounds a hostile count.
+	if major != cborArray || count%2 != 0 || count > uint64(len(data)) {
+		return fmt.Errorf("%w: expected an array of even length", ErrBinaryFormat)
+	}
+
+	var b preorderBuilder
+	for i := uint64(0); i < count/2; i++ {
+		if b.done() {
+			return fmt.Errorf("%w: node %d has no parent slot", ErrBinaryFormat, i)
+		}
+		var flags, v int64
+		if flags, data, err = cborInt(data); err != nil {
+			return fmt.Errorf("%w: node %d", err, i)
+		}
+		if flags&^int64(binaryHasLeft|binaryHasRight) != 0 {
+			return fmt.Errorf("%w: node %d has unknown flags %#x", ErrBinaryFormat, i, flags)
+		}
+		if v, data, err = cborInt(data); err != nil {
+			return fmt.Errorf("%w: node %d", err, i)
+		}
+		b.add(byte(flags), int(v))
+	}
+	if b.pending() > 0 {
+		return fmt.Errorf("%w: %d children announced but missing", ErrBinaryFormat, b.pending())
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrBinaryFormat, len(data))
+	}
+
+	t.Root = b.root
+	return nil
+}
+
+// cborAppendHead appends a data item head: the major type with its
+// argument in the shortest encoding.
+func cborAppendHead(buf []byte, major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return append(buf, major|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(buf, major|24, byte(arg))
+	case arg <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, major|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, major|26), uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, major|27), arg)
+	}
+}
+
+// cborHead reads a data item head, returning the major type, the
+// argument and the rest of data. Indefinite lengths are rejected.
+func cborHead(data []byte) (major byte, arg uint64, rest []byte, err error) {
+	if len(data) == 0 {
+		return 0, 0, nil, fmt.Errorf("%w: truncated", ErrBinaryFormat)
+	}
+	major, info, rest := data[0]&0xe0, data[0]&0x1f, data[1:]
+	if info < 24 {
+		return major, uint64(info), rest, nil
+	}
+	if info > 27 {
+		return 0, 0, nil, fmt.Errorf("%w: unsupported additional information %d", ErrBinaryFormat, info)
+	}
+	size := 1 << (info - 24)
+	if len(rest) < size {
+		return 0, 0, nil, fmt.Errorf("%w: truncated", ErrBinaryFormat)
+	}
+	for _, c := range rest[:size] {
+		arg = arg<<8 | uint64(c)
+	}
+	return major, arg, rest[size:], nil
+}
+
+// cborInt reads an integer, returning it and the rest of data.
+func cborInt(data []byte) (int64, []byte, error) {
+	major, arg, rest, err := cborHead(data)
+	if err != nil {
+		return 0, nil, err
+	}
+	if major != cborUnsigned && major != cborNegative {
+		return 0, nil, fmt.Errorf("%w: expected an integer, got major type %d", ErrBinaryFormat, major>>5)
+	}
+	if arg > math.MaxInt64 {
+		return 0, nil, fmt.Errorf("%w: integer overflows int64", ErrBinaryFormat)
+	}
+	if major == cborNegative {
+		return -1 - int64(arg), rest, nil
+	}
+	return int64(arg), rest, nil
+}
@@ -0,0 +1,83 @@
+package core
+
+// Equal reports whether a and b have the same shape and the same value
+// at every position. Two nil trees are equal.
+func Equal[T comparable](a, b *TreeNode[T]) bool {
+	return zipWalk(a, b, false, func(x, y *TreeNode[T]) bool { return x.Val == y.Val })
+}
+
+// SameShape reports whether a and b have the same shape, ignoring values.
+func SameShape[T any](a, b *TreeNode[T]) bool {
+	return zipWalk(a, b, false, func(_, _ *TreeNode[T]) bool { return true })
+}
+
+// IsMirror reports whether b is the mirror image of a: the same values
+// with every node's left and right children swapped. Two nil trees are
+// mirrors of each other.
+func IsMirror[T comparable](a, b *TreeNode[T]) bool {
+	return zipWalk(a, b, true, func(x, y *TreeNode[T]) bool { return x.Val == y.Val })
+}
+
+// IsSymmetric reports whether the tree is its own mirror image, that is
+// symmetric around its root. A nil tree is symmetric.
+func IsSymmetric[T comparable](root *TreeNode[T]) bool {
+	return root == nil || IsMirror(root.Left, root.Right)
+}
+
+// IsSubtree reports whether needle equals some complete subtree of
+// haystack (a node together with all of its descendants). A nil needle
+// is a subtree of every tree.
+func IsSubtree[T comparable](haystack, needle *TreeNode[T]) bool {
+	if needle == nil {
+		return true
+	}
+	stack := []*TreeNode[T]{}
+	if haystack != nil {
+		stack = append(stack, haystack)
+	}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if node.Val == needle.Val && Equal(node, needle) {
+			return true
+		}
+		if node.Left != nil {
+			stack = append(stack, node.Left)
+		}
+		if node.Right != nil {
+			stack = append(stack, node.Right)
+		}
+	}
+	return false
+}
+
+// zipWalk walks a and b in lockstep with an explicit stack and reports
+// whether they have the same shape and match(x, y) holds for every pair
+// of nodes at the same position. With mirror set, b is walked with left
+// and right swapped, so positions are compared across the mirror.
+func zipWalk[T any](a, b *TreeNode[T], mirror bool, match func(x, y *TreeNode[T]) bool) bool {
+	type pair struct{ x, y *TreeNode[T] }
+
+	stack := []pair{{a, b}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if p.x == nil || p.y == nil {
+			if p.x != p.y {
+				return false
+			}
+			continue
+		}
+		if !match(p.x, p.y) {
+			return false
+		}
+		if mirror {
+			stack = append(stack, pair{p.x.Left, p.y.Right}, pair{p.x.Right, p.y.Left})
+		} else {
+			stack = append(stack, pair{p.x.Left, p.y.Left}, pair{p.x.Right, p.y.Right})
+		}
+	}
+	return true
+}
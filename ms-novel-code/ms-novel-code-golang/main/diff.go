@@ -0,0 +1,196 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// EditOp is the kind of change an Edit makes.
+type EditOp int
+
+const (
+	// EditInsert adds a leaf holding New at Path.
+	EditInsert EditOp = iota
+	// EditDelete removes the leaf holding Old at Path.
+	EditDelete
+	// EditRelabel changes the value at Path from Old to New.
+	EditRelabel
+)
+
+// String returns the operation's name.
+func (op EditOp) String() string {
+	switch op {
+	case EditInsert:
+		return "insert"
+	case EditDelete:
+		return "delete"
+	case EditRelabel:
+		return "relabel"
+	default:
+		return fmt.Sprintf("EditOp(%d)", int(op))
+	}
+}
+
+// Edit is one step of an edit script produced by Diff. Path spells the
+// route from the root as a string of 'L' and 'R' steps ("" is the
+// root), as in HashDiff. Old is unused by inserts and New by deletes.
+type Edit struct {
+	Op       EditOp
+	Path     string
+	Old, New int
+}
+
+// String describes the edit, e.g. "relabel LR 5 -> 9".
+func (e Edit) String() string {
+	path := e.Path
+	if path == "" {
+		path = "root"
+	}
+	switch e.Op {
+	case EditInsert:
+		return fmt.Sprintf("insert %s %d", path, e.New)
+	case EditDelete:
+		return fmt.Sprintf("delete %s %d", path, e.Old)
+	default:
+		return fmt.Sprintf("%v %s %d -> %d", e.Op, path, e.Old, e.New)
+	}
+}
+
+// ErrInvalidEdit is returned by ApplyDiff when an edit does not fit the
+// tree it is applied to.
+var ErrInvalidEdit = errors.New("core: invalid edit")
+
+// Diff returns an edit script that turns a into b. The diff is
+// positional: nodes are matched by their path from the root, so a node
+// present in both trees at the same position is relabelled if its value
+// changed, and subtrees present in only one tree are inserted or deleted
+// node by node. Edits are ordered so that they can be applied in
+// sequence: parents are inserted before their children and deleted
+// after them. The returned slice is never nil; it is empty when the
+// trees are equal.
+func Diff(a, b *Node) []Edit {
+	edits := []Edit{}
+	type frame struct {
+		path string
+		x, y *Node
+	}
+	stack := []frame{{"", a, b}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		switch {
+		case f.x == f.y:
+			// Identical (or both nil) subtrees need no edits.
+		case f.x == nil:
+			eachPath(f.y, f.path, func(path string, n *Node) {
+				edits = append(edits, Edit{Op: EditInsert, Path: path, New: n.Val})
+			})
+		case f.y == nil:
+			// Reverse preorder visits every child before its parent.
+			var dels []Edit
+			eachPath(f.x, f.path, func(path string, n *Node) {
+				dels = append(dels, Edit{Op: EditDelete, Path: path, Old: n.Val})
+			})
+			slices.Reverse(dels)
+			edits = append(edits, dels...)
+		default:
+			if f.x.Val != f.y.Val {
+				edits = append(edits, Edit{Op: EditRelabel, Path: f.path, Old: f.x.Val, New: f.y.Val})
+			}
+			stack = append(stack,
+				frame{f.path + "R", f.x.Right, f.y.Right},
+				frame{f.path + "L", f.x.Left, f.y.Left})
+		}
+	}
+	return edits
+}
+
+// ApplyDiff applies edits, in order, to a copy of root and returns the
+// result; root itself is not modified. ApplyDiff(a, Diff(a, b)) is equal
+// to b. It returns an error wrapping ErrInvalidEdit if an edit's path
+// does not exist, an insert targets an occupied position, a delete
+// targets a node with children, or an edit's Old value does not match
+// the tree.
+func ApplyDiff(root *Node, edits []Edit) (*Node, error) {
+	root = Clone(root)
+	for i, e := range edits {
+		link, err := editLink(&root, e.Path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: edit %d (%v): %v", ErrInvalidEdit, i, e, err)
+		}
+		node := *link
+
+		switch {
+		case e.Op == EditInsert && node != nil:
+			err = fmt.Errorf("position holds %d", node.Val)
+		case e.Op != EditInsert && node == nil:
+			err = errors.New("no node at position")
+		case e.Op != EditInsert && node.Val != e.Old:
+			err = fmt.Errorf("node holds %d, want %d", node.Val, e.Old)
+		case e.Op == EditDelete && (node.Left != nil || node.Right != nil):
+			err = errors.New("node has children")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: edit %d (%v): %v", ErrInvalidEdit, i, e, err)
+		}
+
+		switch e.Op {
+		case EditInsert:
+			*link = &Node{Val: e.New}
+		case EditDelete:
+			*link = nil
+		case EditRelabel:
+			node.Val = e.New
+		default:
+			return nil, fmt.Errorf("%w: edit %d: unknown op %v", ErrInvalidEdit, i, e.Op)
+		}
+	}
+	return root, nil
+}
+
+// editLink returns the link (the root pointer or a parent's child
+// pointer) that path leads to. Every node along the way must exist.
+func editLink(root **Node, path string) (**Node, error) {
+	link := root
+	for i := 0; i < len(path); i++ {
+		if *link == nil {
+			return nil, fmt.Errorf("no node at %q", path[:i])
+		}
+		switch path[i] {
+		case 'L':
+			link = &(*link).Left
+		case 'R':
+			link = &(*link).Right
+		default:
+			return nil, fmt.Errorf("bad step %q in path", path[i])
+		}
+	}
+	return link, nil
+}
+
+// eachPath calls fn for every node of root in preorder, together with
+// its path, where prefix is the path of root itself.
+func eachPath(root *Node, prefix string, fn func(path string, n *Node)) {
+	if root == nil {
+		return
+	}
+	type frame struct {
+		path string
+		n    *Node
+	}
+	stack := []frame{{prefix, root}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		fn(f.path, f.n)
+		if f.n.Right != nil {
+			stack = append(stack, frame{f.path + "R", f.n.Right})
+		}
+		if f.n.Left != nil {
+			stack = append(stack, frame{f.path + "L", f.n.Left})
+		}
+	}
+}
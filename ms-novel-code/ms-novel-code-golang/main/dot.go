@@ -0,0 +1,91 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// dotConfig holds the settings applied by DOTOption values.
+type dotConfig struct {
+	label        func(*Node) string
+	highlightMax bool
+	leftStyle    string
+	rightStyle   string
+}
+
+// DOTOption customises the output of ExportDOT.
+type DOTOption func(*dotConfig)
+
+// WithDOTLabel sets the function used to label each node.
+// By default a node is labelled with its value.
+func WithDOTLabel(label func(*Node) string) DOTOption {
+	return func(c *dotConfig) { c.label = label }
+}
+
+// WithDOTHighlightMax fills the nodes holding their level's maximum,
+// the same values rowWiseMax reports.
+func WithDOTHighlightMax() DOTOption {
+	return func(c *dotConfig) { c.highlightMax = true }
+}
+
+// WithDOTEdgeStyles sets the Graphviz style attribute (e.g. "solid",
+// "dashed", "dotted", "bold") of edges to left and right children.
+func WithDOTEdgeStyles(left, right string) DOTOption {
+	return func(c *dotConfig) { c.leftStyle, c.rightStyle = left, right }
+}
+
+// ExportDOT writes the tree as a Graphviz digraph to w. Nodes are named
+// n0, n1, ... in level order; edges carry an L or R label so the shape
+// stays readable even when one child is missing. A nil tree produces an
+// empty digraph.
+func ExportDOT(root *Node, w io.Writer, opts ...DOTOption) error {
+	cfg := dotConfig{
+		label:      func(n *Node) string { return strconv.Itoa(n.Val) },
+		leftStyle:  "solid",
+		rightStyle: "solid",
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph tree {")
+	fmt.Fprintln(bw, "\tnode [shape=circle];")
+
+	ids := map[*Node]int{}
+	walkLevels(root, func(_ int, level []*Node) bool {
+		levelMax := level[0].Val
+		for _, node := range level {
+			levelMax = max(levelMax, node.Val)
+		}
+		for _, node := range level {
+			id := len(ids)
+			ids[node] = id
+			attrs := "label=" + strconv.Quote(cfg.label(node))
+			if cfg.highlightMax && node.Val == levelMax {
+				attrs += ", style=filled, fillcolor=gold"
+			}
+			fmt.Fprintf(bw, "\tn%d [%s];\n", id, attrs)
+		}
+		return true
+	})
+
+	walkLevels(root, func(_ int, level []*Node) bool {
+		for _, node := range level {
+			if node.Left != nil {
+				fmt.Fprintf(bw, "\tn%d -> n%d [label=\"L\", style=%s];\n",
+					ids[node], ids[node.Left], strconv.Quote(cfg.leftStyle))
+			}
+			if node.Right != nil {
+				fmt.Fprintf(bw, "\tn%d -> n%d [label=\"R\", style=%s];\n",
+					ids[node], ids[node.Right], strconv.Quote(cfg.rightStyle))
+			}
+		}
+		return true
+	})
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
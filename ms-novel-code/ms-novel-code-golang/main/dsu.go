@@ -0,0 +1,94 @@
+package core
+
+// DSU is a disjoint-set forest (union-find) over the elements 0..n-1.
+// Find uses path compression and Union joins by rank, so any sequence
+// of m operations runs in O(m α(n)), effectively constant per call.
+type DSU struct {
+	parent []int
+	rank   []uint8
+	sets   int
+}
+
+// NewDSU returns a DSU with n elements, each in a set of its own.
+func NewDSU(n int) *DSU {
+	d := &DSU{}
+	for range n {
+		d.Add()
+	}
+	return d
+}
+
+// Add appends a new element in a set of its own and returns it.
+func (d *DSU) Add() int {
+	x := len(d.parent)
+	d.parent = append(d.parent, x)
+	d.rank = append(d.rank, 0)
+	d.sets++
+	return x
+}
+
+// Len returns the number of elements.
+func (d *DSU) Len() int {
+	return len(d.parent)
+}
+
+// Sets returns the number of disjoint sets.
+func (d *DSU) Sets() int {
+	return d.sets
+}
+
+// Find returns the representative of x's set. It panics if x is not an
+// element.
+func (d *DSU) Find(x int) int {
+	root := x
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	// Point everything on the path straight at the root.
+	for d.parent[x] != root {
+		d.parent[x], x = root, d.parent[x]
+	}
+	return root
+}
+
+// Union merges the sets holding a and b and reports whether they were
+// separate.
+func (d *DSU) Union(a, b int) bool {
+	a, b = d.Find(a), d.Find(b)
+	if a == b {
+		return false
+	}
+	if d.rank[a] < d.rank[b] {
+		a, b = b, a
+	}
+	d.parent[b] = a
+	if d.rank[a] == d.rank[b] {
+		d.rank[a]++
+	}
+	d.sets--
+	return true
+}
+
+// Connected reports whether a and b are in the same set.
+func (d *DSU) Connected(a, b int) bool {
+	return d.Find(a) == d.Find(b)
+}
+
+// ConnectedComponents returns every set as a slice of its elements in
+// ascending order. Sets are ordered by their smallest element. The
+// returned slice is never nil.
+func (d *DSU) ConnectedComponents() [][]int {
+	comps := [][]int{}
+	index := map[int]int{} // representative -> position in comps
+	for x := range d.parent {
+		r := d.Find(x)
+		i, ok := index[r]
+		if !ok {
+			i = len(comps)
+			index[r] = i
+			comps = append(comps, nil)
+		}
+		comps[i] = append(comps[i], x)
+	}
+	return comps
+}
@@ -0,0 +1,261 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidExpression is returned for token sequences or trees that do
+// not form a valid expression.
+var ErrInvalidExpression = errors.New("core: invalid expression")
+
+// ErrDivisionByZero is returned by Evaluate when a divisor is zero.
+var ErrDivisionByZero = errors.New("core: division by zero")
+
+// ExprToken is the value of an expression-tree node: an operator, or an
+// operand when Op is empty.
+type ExprToken struct {
+	Op    string // one of + - * / ^, or "" for an operand
+	Value float64
+}
+
+// String returns the operator, or the operand formatted compactly.
+func (t ExprToken) String() string {
+	if t.Op != "" {
+		return t.Op
+	}
+	return strconv.FormatFloat(t.Value, 'g', -1, 64)
+}
+
+// ExprNode is a node of an expression tree. Operators have exactly two
+// children and operands none. Since it is an ordinary TreeNode, the rest
+// of the package (Height, traversals, MapTree, ...) works on it too.
+type ExprNode = TreeNode[ExprToken]
+
+// exprPrec gives the binding strength of each supported operator.
+var exprPrec = map[string]int{"+": 1, "-": 1, "*": 2, "/": 2, "^": 3}
+
+// exprRightAssoc reports whether op groups right to left.
+func exprRightAssoc(op string) bool { return op == "^" }
+
+// BuildExpressionTree parses tokens, one operator, parenthesis or number
+// per element, into an expression tree. Input whose last token is an
+// operator is read as postfix ("3 4 + 2 *"), anything else as infix
+// ("( 3 + 4 ) * 2"), which never ends in an operator. Infix follows the
+// usual precedence, with ^ binding tightest and grouping to the right.
+func BuildExpressionTree(tokens []string) (*ExprNode, error) {
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: no tokens", ErrInvalidExpression)
+	}
+	if _, ok := exprPrec[tokens[len(tokens)-1]]; ok && len(tokens) > 1 {
+		return buildPostfix(tokens)
+	}
+	return buildInfix(tokens)
+}
+
+func buildPostfix(tokens []string) (*ExprNode, error) {
+	var stack []*ExprNode
+	for i, tok := range tokens {
+		if _, ok := exprPrec[tok]; ok {
+			if len(stack) < 2 {
+				return nil, fmt.Errorf("%w: operator %q at %d lacks operands", ErrInvalidExpression, tok, i)
+			}
+			l, r := stack[len(stack)-2], stack[len(stack)-1]
+			stack = append(stack[:len(stack)-2], &ExprNode{Val: ExprToken{Op: tok}, Left: l, Right: r})
+			continue
+		}
+		operand, err := parseOperand(tok, i)
+		if err != nil {
+			return nil, err
+		}
+		stack = append(stack, operand)
+	}
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("%w: %d operands left over", ErrInvalidExpression, len(stack)-1)
+	}
+	return stack[0], nil
+}
+
+// buildInfix is the shunting-yard algorithm, building subtrees instead of
+// emitting postfix output.
+func buildInfix(tokens []string) (*ExprNode, error) {
+	var (
+		operands  []*ExprNode
+		operators []string
+	)
+	reduce := func() {
+		op := operators[len(operators)-1]
+		operators = operators[:len(operators)-1]
+		l, r := operands[len(operands)-2], operands[len(operands)-1]
+		operands = append(operands[:len(operands)-2], &ExprNode{Val: ExprToken{Op: op}, Left: l, Right: r})
+	}
+
+	expectOperand := true
+	for i, tok := range tokens {
+		prec, isOp := exprPrec[tok]
+		switch {
+		case tok == "(":
+			if !expectOperand {
+				return nil, fmt.Errorf("%w: unexpected ( at %d", ErrInvalidExpression, i)
+			}
+			operators = append(operators, tok)
+		case tok == ")":
+			if expectOperand {
+				return nil, fmt.Errorf("%w: unexpected ) at %d", ErrInvalidExpression, i)
+			}
+			for len(operators) > 0 && operators[len(operators)-1] != "(" {
+				reduce()
+			}
+			if len(operators) == 0 {
+				return nil, fmt.Errorf("%w: unmatched ) at %d", ErrInvalidExpression, i)
+			}
+			operators = operators[:len(operators)-1]
+		case isOp:
+			if expectOperand {
+				return nil, fmt.Errorf("%w: unexpected operator %q at %d", ErrInvalidExpression, tok, i)
+			}
+			for len(operators) > 0 {
+				top := operators[len(operators)-1]
+				if top == "(" || exprPrec[top] < prec || (exprPrec[top] == prec && exprRightAssoc(tok)) {
+					break
+				}
+				reduce()
+			}
+			operators = append(operators, tok)
+			expectOperand = true
+		default:
+			if !expectOperand {
+				return nil, fmt.Errorf("%w: unexpected operand %q at %d", ErrInvalidExpression, tok, i)
+			}
+			operand, err := parseOperand(tok, i)
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, operand)
+			expectOperand = false
+		}
+	}
+	if expectOperand {
+		return nil, fmt.Errorf("%w: expression ends early", ErrInvalidExpression)
+	}
+	for len(operators) > 0 {
+		if operators[len(operators)-1] == "(" {
+			return nil, fmt.Errorf("%w: unmatched (", ErrInvalidExpression)
+		}
+		reduce()
+	}
+	return operands[0], nil
+}
+
+func parseOperand(tok string, i int) (*ExprNode, error) {
+	v, err := strconv.ParseFloat(tok, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad token %q at %d", ErrInvalidExpression, tok, i)
+	}
+	return &ExprNode{Val: ExprToken{Value: v}}, nil
+}
+
+// checkExprNode reports whether n has the children its token requires.
+func checkExprNode(n *ExprNode) error {
+	if n.Val.Op == "" {
+		if n.Left != nil || n.Right != nil {
+			return fmt.Errorf("%w: operand %v has children", ErrInvalidExpression, n.Val)
+		}
+		return nil
+	}
+	if _, ok := exprPrec[n.Val.Op]; !ok {
+		return fmt.Errorf("%w: unknown operator %q", ErrInvalidExpression, n.Val.Op)
+	}
+	if n.Left == nil || n.Right == nil {
+		return fmt.Errorf("%w: operator %q needs two operands", ErrInvalidExpression, n.Val.Op)
+	}
+	return nil
+}
+
+// Evaluate computes the value of the expression tree. It fails with
+// ErrInvalidExpression for malformed trees (including nil) and with
+// ErrDivisionByZero when dividing by zero.
+func Evaluate(root *ExprNode) (float64, error) {
+	if root == nil {
+		return 0, fmt.Errorf("%w: empty tree", ErrInvalidExpression)
+	}
+	var stack []float64
+	for _, n := range postorderNodes(root) {
+		if err := checkExprNode(n); err != nil {
+			return 0, err
+		}
+		if n.Val.Op == "" {
+			stack = append(stack, n.Val.Value)
+			continue
+		}
+		l, r := stack[len(stack)-2], stack[len(stack)-1]
+		stack = stack[:len(stack)-2]
+		var v float64
+		switch n.Val.Op {
+		case "+":
+			v = l + r
+		case "-":
+			v = l - r
+		case "*":
+			v = l * r
+		case "/":
+			if r == 0 {
+				return 0, ErrDivisionByZero
+			}
+			v = l / r
+		case "^":
+			v = math.Pow(l, r)
+		}
+		stack = append(stack, v)
+	}
+	return stack[0], nil
+}
+
+// ToInfix prints the expression in infix form with space-separated
+// tokens, adding only the parentheses the precedence rules require. It
+// returns "" for a nil tree and panics on a malformed one.
+func ToInfix(root *ExprNode) string {
+	if root == nil {
+		return ""
+	}
+	type printed struct {
+		s    string
+		prec int // math.MaxInt for operands, which never need parentheses
+	}
+	out := map[*ExprNode]printed{}
+	for _, n := range postorderNodes(root) {
+		if err := checkExprNode(n); err != nil {
+			panic("core: " + err.Error())
+		}
+		if n.Val.Op == "" {
+			out[n] = printed{n.Val.String(), math.MaxInt}
+			continue
+		}
+		prec := exprPrec[n.Val.Op]
+		l, r := out[n.Left], out[n.Right]
+		// The operand on the side the operator groups from may share its
+		// precedence; the other side needs parentheses in that case.
+		if l.prec < prec || (l.prec == prec && exprRightAssoc(n.Val.Op)) {
+			l.s = "( " + l.s + " )"
+		}
+		if r.prec < prec || (r.prec == prec && !exprRightAssoc(n.Val.Op)) {
+			r.s = "( " + r.s + " )"
+		}
+		out[n] = printed{l.s + " " + n.Val.Op + " " + r.s, prec}
+	}
+	return out[root].s
+}
+
+// ToPostfix prints the expression in postfix form with space-separated
+// tokens. It returns "" for a nil tree.
+func ToPostfix(root *ExprNode) string {
+	nodes := postorderNodes(root)
+	toks := make([]string, len(nodes))
+	for i, n := range nodes {
+		toks[i] = n.Val.String()
+	}
+	return strings.Join(toks, " ")
+}
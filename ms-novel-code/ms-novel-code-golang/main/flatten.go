@@ -0,0 +1,55 @@
+package core
+
+// FlattenPreorder rewires the tree in place into a linked list that
+// follows preorder through Right pointers, with every Left pointer nil,
+// and returns root for convenience. It uses O(1) extra memory: each
+// left subtree is spliced in between a node and its right subtree.
+func FlattenPreorder[T any](root *TreeNode[T]) *TreeNode[T] {
+	for cur := root; cur != nil; cur = cur.Right {
+		if cur.Left == nil {
+			continue
+		}
+		tail := cur.Left
+		for tail.Right != nil {
+			tail = tail.Right
+		}
+		tail.Right = cur.Right
+		cur.Right, cur.Left = cur.Left, nil
+	}
+	return root
+}
+
+// BSTToSortedDoublyLinkedList rewires a binary search tree in place into
+// a sorted, non-circular doubly linked list, where Left points to the
+// previous node and Right to the next, and returns the head (the
+// smallest node). The list follows inorder, so for trees that are not
+// BSTs the result is in inorder rather than sorted. The walk uses an
+// explicit stack, so degenerate trees are safe.
+func BSTToSortedDoublyLinkedList[T any](root *TreeNode[T]) *TreeNode[T] {
+	var head, prev *TreeNode[T]
+	var stack []*TreeNode[T]
+	node := root
+	for node != nil || len(stack) > 0 {
+		for node != nil {
+			stack = append(stack, node)
+			node = node.Left
+		}
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		// node.Right is read before it is overwritten by the next link.
+		next := node.Right
+		node.Left = prev
+		if prev != nil {
+			prev.Right = node
+		} else {
+			head = node
+		}
+		prev = node
+		node = next
+	}
+	if prev != nil {
+		prev.Right = nil
+	}
+	return head
+}
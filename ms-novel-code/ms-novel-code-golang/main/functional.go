@@ -0,0 +1,155 @@
+package core
+
+// MapTree returns a new tree of the same shape as root with every value
+// replaced by fn applied to it. The value type may change, so MapTree
+// can also convert between trees (for example *Node to
+// *TreeNode[string]). root is not modified.
+func MapTree[T, U any](root *TreeNode[T], fn func(T) U) *TreeNode[U] {
+	if root == nil {
+		return nil
+	}
+	type pair struct {
+		src *TreeNode[T]
+		dst *TreeNode[U]
+	}
+
+	out := &TreeNode[U]{Val: fn(root.Val)}
+	stack := []pair{{root, out}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if p.src.Left != nil {
+			p.dst.Left = &TreeNode[U]{Val: fn(p.src.Left.Val)}
+			stack = append(stack, pair{p.src.Left, p.dst.Left})
+		}
+		if p.src.Right != nil {
+			p.dst.Right = &TreeNode[U]{Val: fn(p.src.Right.Val)}
+			stack = append(stack, pair{p.src.Right, p.dst.Right})
+		}
+	}
+	return out
+}
+
+// Clone returns a deep copy of root: a new tree of the same shape and
+// values sharing no nodes with it. Mutating algorithms can then run on
+// the copy while the original stays intact.
+func Clone[T any](root *TreeNode[T]) *TreeNode[T] {
+	return MapTree(root, identity[T])
+}
+
+// ExtractSubtree returns a detached deep copy of the first subtree, in
+// preorder, whose root satisfies pred, or nil if no node does. root is
+// not modified.
+func ExtractSubtree[T any](root *TreeNode[T], pred func(*TreeNode[T]) bool) *TreeNode[T] {
+	if root == nil {
+		return nil
+	}
+	stack := []*TreeNode[T]{root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if pred(node) {
+			return Clone(node)
+		}
+		if node.Right != nil {
+			stack = append(stack, node.Right)
+		}
+		if node.Left != nil {
+			stack = append(stack, node.Left)
+		}
+	}
+	return nil
+}
+
+// Prune removes, in place, every subtree in which no node satisfies
+// keep, and returns the new root (nil if nothing is kept). A node
+// survives if keep holds for it or for any of its descendants, so the
+// paths from the root to every kept node are preserved. keep is called
+// once per node, bottom-up, before the tree is modified.
+func Prune[T any](root *TreeNode[T], keep func(*TreeNode[T]) bool) *TreeNode[T] {
+	order := postorderNodes(root)
+	kept := make(map[*TreeNode[T]]bool, len(order))
+	for _, node := range order {
+		if keep(node) {
+			kept[node] = true
+		}
+	}
+
+	// Children come before parents, so each child's fate is settled by
+	// the time its parent is examined.
+	for _, node := range order {
+		if node.Left != nil && !kept[node.Left] {
+			node.Left = nil
+		}
+		if node.Right != nil && !kept[node.Right] {
+			node.Right = nil
+		}
+		if node.Left != nil || node.Right != nil {
+			kept[node] = true
+		}
+	}
+
+	if root == nil || !kept[root] {
+		return nil
+	}
+	return root
+}
+
+// FilterValues returns the values satisfying pred, in preorder. The
+// returned slice is never nil, even for an empty tree.
+func FilterValues[T any](root *TreeNode[T], pred func(T) bool) []T {
+	res := []T{}
+	eachNode(root, func(n *TreeNode[T]) {
+		if pred(n.Val) {
+			res = append(res, n.Val)
+		}
+	})
+	return res
+}
+
+// MergeTrees overlays a and b into a new tree. Where both trees have a
+// node at the same position the result holds combine(x, y) of their
+// values; where only one does, its value is copied. A nil combine adds
+// the values. Neither input is modified.
+func MergeTrees(a, b *Node, combine func(x, y int) int) *Node {
+	if combine == nil {
+		combine = func(x, y int) int { return x + y }
+	}
+	return MergeTreesFunc(a, b, combine)
+}
+
+// MergeTreesFunc is MergeTrees for trees over any type; combine must
+// not be nil.
+func MergeTreesFunc[T any](a, b *TreeNode[T], combine func(x, y T) T) *TreeNode[T] {
+	type frame struct {
+		x, y *TreeNode[T]
+		dst  **TreeNode[T]
+	}
+
+	var out *TreeNode[T]
+	stack := []frame{{a, b, &out}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		switch {
+		case f.x == nil && f.y == nil:
+			continue
+		case f.x == nil:
+			*f.dst = Clone(f.y)
+			continue
+		case f.y == nil:
+			*f.dst = Clone(f.x)
+			continue
+		}
+		node := &TreeNode[T]{Val: combine(f.x.Val, f.y.Val)}
+		*f.dst = node
+		stack = append(stack,
+			frame{f.x.Right, f.y.Right, &node.Right},
+			frame{f.x.Left, f.y.Left, &node.Left})
+	}
+	return out
+}
+
+func identity[T any](v T) T { return v }
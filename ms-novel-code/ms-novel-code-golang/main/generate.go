@@ -0,0 +1,109 @@
+package core
+
+import "math/rand/v2"
+
+// Shape selects the structure produced by GenerateRandomTree.
+type Shape int
+
+const (
+	// ShapeRandom attaches each node to a uniformly chosen free child
+	// slot, giving trees of typical O(log n) but varied height.
+	ShapeRandom Shape = iota
+	// ShapeBalanced produces a complete tree of minimum height.
+	ShapeBalanced
+	// ShapeLeftSkewed produces a chain where every node is a left child.
+	ShapeLeftSkewed
+	// ShapeRightSkewed produces a chain where every node is a right child.
+	ShapeRightSkewed
+)
+
+// genConfig holds the settings applied by GenOption values.
+type genConfig struct {
+	lo, hi int
+	shape  Shape
+	seed   *uint64
+}
+
+// GenOption customises GenerateRandomTree.
+type GenOption func(*genConfig)
+
+// WithValueRange draws node values uniformly from [lo, hi]
+// (default [-1000000, 1000000]). It panics if lo > hi.
+func WithValueRange(lo, hi int) GenOption {
+	if lo > hi {
+		panic("core: WithValueRange: lo > hi")
+	}
+	return func(c *genConfig) { c.lo, c.hi = lo, hi }
+}
+
+// WithShape selects the tree's structure (ShapeRandom by default).
+func WithShape(s Shape) GenOption {
+	return func(c *genConfig) { c.shape = s }
+}
+
+// WithSeed makes generation deterministic: the same seed and options
+// always produce the same tree. Without it every call differs.
+func WithSeed(seed uint64) GenOption {
+	return func(c *genConfig) { c.seed = &seed }
+}
+
+// GenerateRandomTree returns a tree of n nodes with random values, for
+// tests, fuzzing, and benchmarks. It returns nil when n <= 0.
+func GenerateRandomTree(n int, opts ...GenOption) *Node {
+	cfg := genConfig{lo: -1_000_000, hi: 1_000_000}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if n <= 0 {
+		return nil
+	}
+
+	var rng *rand.Rand
+	if cfg.seed != nil {
+		rng = rand.New(rand.NewPCG(*cfg.seed, 0))
+	} else {
+		rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	span := uint64(cfg.hi) - uint64(cfg.lo) + 1 // 0 when the range covers every int
+	value := func() int {
+		if span == 0 {
+			return int(rng.Uint64())
+		}
+		return cfg.lo + int(rng.Uint64N(span))
+	}
+
+	nodes := make([]*Node, n)
+	for i := range nodes {
+		nodes[i] = &Node{Val: value()}
+	}
+
+	switch cfg.shape {
+	case ShapeBalanced:
+		for i, node := range nodes {
+			if l := 2*i + 1; l < n {
+				node.Left = nodes[l]
+			}
+			if r := 2*i + 2; r < n {
+				node.Right = nodes[r]
+			}
+		}
+	case ShapeLeftSkewed:
+		for i := 1; i < n; i++ {
+			nodes[i-1].Left = nodes[i]
+		}
+	case ShapeRightSkewed:
+		for i := 1; i < n; i++ {
+			nodes[i-1].Right = nodes[i]
+		}
+	default:
+		// Every attached node opens two free slots; fill a random one.
+		slots := []**Node{&nodes[0].Left, &nodes[0].Right}
+		for _, node := range nodes[1:] {
+			i := rng.IntN(len(slots))
+			*slots[i] = node
+			slots[i] = slots[len(slots)-1]
+			slots = append(slots[:len(slots)-1], &node.Left, &node.Right)
+		}
+	}
+	return nodes[0]
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotATree is returned by FromAdjacencyList when the edges do not
+// describe a binary tree rooted at the given node.
+var ErrNotATree = errors.New("core: edges do not form a binary tree")
+
+// ToAdjacencyList returns the tree as a directed graph: every node maps
+// to its non-nil children, left first. Leaves map to an empty list, so
+// the map's keys are exactly the tree's nodes. The returned map is never
+// nil.
+func ToAdjacencyList[T any](root *TreeNode[T]) map[*TreeNode[T]][]*TreeNode[T] {
+	adj := map[*TreeNode[T]][]*TreeNode[T]{}
+	eachNode(root, func(n *TreeNode[T]) {
+		children := []*TreeNode[T]{}
+		if n.Left != nil {
+			children = append(children, n.Left)
+		}
+		if n.Right != nil {
+			children = append(children, n.Right)
+		}
+		adj[n] = children
+	})
+	return adj
+}
+
+// FromAdjacencyList links the nodes of edges into a binary tree rooted at
+// root and returns root. Each node's list gives its children: the first
+// becomes Left and the second Right. A list cannot say which side a
+// lone child was on, so it becomes Left unless the parent's Right
+// already points at it; relinking the nodes ToAdjacencyList came from
+// therefore leaves them unchanged. Nodes missing from edges keep their
+// links.
+//
+// Before changing anything it checks that the edges form a tree: at
+// most two children per node, no nil children, no node with two
+// parents, nothing pointing back at root, and every node reachable from
+// root, which also rules out cycles. Otherwise it returns an error
+// wrapping ErrNotATree and leaves every node untouched.
+func FromAdjacencyList[T any](edges map[*TreeNode[T]][]*TreeNode[T], root *TreeNode[T]) (*TreeNode[T], error) {
+	if root == nil {
+		if len(edges) > 0 {
+			return nil, fmt.Errorf("%w: nil root", ErrNotATree)
+		}
+		return nil, nil
+	}
+
+	parent := map[*TreeNode[T]]*TreeNode[T]{}
+	for n, children := range edges {
+		if n == nil {
+			return nil, fmt.Errorf("%w: nil node", ErrNotATree)
+		}
+		if len(children) > 2 {
+			return nil, fmt.Errorf("%w: node %v has %d children", ErrNotATree, n.Val, len(children))
+		}
+		for _, c := range children {
+			switch {
+			case c == nil:
+				return nil, fmt.Errorf("%w: node %v has a nil child", ErrNotATree, n.Val)
+			case c == root:
+				return nil, fmt.Errorf("%w: root is a child of %v", ErrNotATree, n.Val)
+			}
+			if p, ok := parent[c]; ok {
+				return nil, fmt.Errorf("%w: node %v has two parents, %v and %v", ErrNotATree, c.Val, p.Val, n.Val)
+			}
+			parent[c] = n
+		}
+	}
+
+	// With one parent per node, anything unreachable from root is either
+	// a second root or sits on a cycle.
+	reached := map[*TreeNode[T]]bool{root: true}
+	stack := []*TreeNode[T]{root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, c := range edges[n] {
+			reached[c] = true
+			stack = append(stack, c)
+		}
+	}
+	for n := range edges {
+		if reached[n] {
+			continue
+		}
+		if _, ok := parent[n]; !ok {
+			return nil, fmt.Errorf("%w: node %v is not reachable from the root", ErrNotATree, n.Val)
+		}
+		return nil, fmt.Errorf("%w: node %v is on a cycle", ErrNotATree, n.Val)
+	}
+
+	for n, children := range edges {
+		var left, right *TreeNode[T]
+		switch {
+		case len(children) == 2:
+			left, right = children[0], children[1]
+		case len(children) == 1 && n.Right == children[0]:
+			right = children[0]
+		case len(children) == 1:
+			left = children[0]
+		}
+		n.Left, n.Right = left, right
+	}
+	return root, nil
+}
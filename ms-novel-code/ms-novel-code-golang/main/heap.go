@@ -0,0 +1,249 @@
+package core
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+// Heap is an array-backed binary heap. The element for which less
+// reports true against every other element is at the top, so a less of
+// a < b gives a min-heap and a > b a max-heap.
+type Heap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewHeap returns an empty heap ordered by less.
+func NewHeap[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// NewMinHeap returns an empty heap with the smallest value on top.
+func NewMinHeap[T cmp.Ordered]() *Heap[T] {
+	return NewHeap(cmp.Less[T])
+}
+
+// NewMaxHeap returns an empty heap with the largest value on top.
+func NewMaxHeap[T cmp.Ordered]() *Heap[T] {
+	return NewHeap(func(a, b T) bool { return cmp.Less(b, a) })
+}
+
+// Heapify builds a heap from items in O(n). The heap takes ownership of
+// the slice, which must not be used by the caller afterwards.
+func Heapify[T any](items []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{items: items, less: less}
+	for i := len(items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Push adds v in O(log n).
+func (h *Heap[T]) Push(v T) {
+	h.items = append(h.items, v)
+	h.up(len(h.items) - 1)
+}
+
+// Peek returns the top element without removing it, or false if the
+// heap is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// Pop removes and returns the top element in O(log n), or false if the
+// heap is empty.
+func (h *Heap[T]) Pop() (T, bool) {
+	var zero T
+	if len(h.items) == 0 {
+		return zero, false
+	}
+	top := h.items[0]
+	last := len(h.items) - 1
+	h.items[0] = h.items[last]
+	h.items[last] = zero
+	h.items = h.items[:last]
+	h.down(0)
+	return top, true
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i], h.items[parent]) {
+			return
+		}
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i = parent
+	}
+}
+
+func (h *Heap[T]) down(i int) {
+	n := len(h.items)
+	for {
+		best := i
+		if l := 2*i + 1; l < n && h.less(h.items[l], h.items[best]) {
+			best = l
+		}
+		if r := 2*i + 2; r < n && h.less(h.items[r], h.items[best]) {
+			best = r
+		}
+		if best == i {
+			return
+		}
+		h.items[i], h.items[best] = h.items[best], h.items[i]
+		i = best
+	}
+}
+
+// NodeHeap is a pointer-backed binary heap: a complete tree of TreeNodes
+// in heap order, so the package's tree functions (RowWiseMaxOf,
+// PrintTree, IsComplete, ...) can inspect it via Root. The node at
+// 1-based position k is found by following the bits of k below its
+// leading one from the root (0 = left, 1 = right), so every operation
+// stays O(log n) without parent pointers.
+type NodeHeap[T any] struct {
+	root *TreeNode[T]
+	size int
+	less func(a, b T) bool
+}
+
+// NewNodeHeap returns an empty pointer-backed heap ordered by less.
+func NewNodeHeap[T any](less func(a, b T) bool) *NodeHeap[T] {
+	return &NodeHeap[T]{less: less}
+}
+
+// HeapifyNodes builds a pointer-backed heap holding vals in O(n).
+func HeapifyNodes[T any](vals []T, less func(a, b T) bool) *NodeHeap[T] {
+	h := &NodeHeap[T]{size: len(vals), less: less}
+	if len(vals) == 0 {
+		return h
+	}
+	nodes := make([]*TreeNode[T], len(vals))
+	for i, v := range vals {
+		nodes[i] = &TreeNode[T]{Val: v}
+		if i > 0 {
+			parent := nodes[(i-1)/2]
+			if i%2 == 1 {
+				parent.Left = nodes[i]
+			} else {
+				parent.Right = nodes[i]
+			}
+		}
+	}
+	h.root = nodes[0]
+	for i := len(nodes)/2 - 1; i >= 0; i-- {
+		h.down(nodes[i])
+	}
+	return h
+}
+
+// Root returns the root of the underlying tree. It must not be modified.
+func (h *NodeHeap[T]) Root() *TreeNode[T] {
+	return h.root
+}
+
+// Len returns the number of elements in the heap.
+func (h *NodeHeap[T]) Len() int {
+	return h.size
+}
+
+// Push adds v in O(log n).
+func (h *NodeHeap[T]) Push(v T) {
+	node := &TreeNode[T]{Val: v}
+	h.size++
+	if h.size == 1 {
+		h.root = node
+		return
+	}
+	path := h.pathTo(h.size / 2)
+	parent := path[len(path)-1]
+	if h.size%2 == 0 {
+		parent.Left = node
+	} else {
+		parent.Right = node
+	}
+	// Sift up by swapping values along the root-to-node path.
+	path = append(path, node)
+	for i := len(path) - 1; i > 0 && h.less(path[i].Val, path[i-1].Val); i-- {
+		path[i].Val, path[i-1].Val = path[i-1].Val, path[i].Val
+	}
+}
+
+// Peek returns the top element without removing it, or false if the
+// heap is empty.
+func (h *NodeHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.Val, true
+}
+
+// Pop removes and returns the top element in O(log n), or false if the
+// heap is empty.
+func (h *NodeHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	top := h.root.Val
+	if h.size == 1 {
+		h.root, h.size = nil, 0
+		return top, true
+	}
+
+	// Detach the last node and move its value to the root.
+	path := h.pathTo(h.size)
+	last, parent := path[len(path)-1], path[len(path)-2]
+	if h.size%2 == 0 {
+		parent.Left = nil
+	} else {
+		parent.Right = nil
+	}
+	h.size--
+	h.root.Val = last.Val
+	h.down(h.root)
+	return top, true
+}
+
+// pathTo returns the nodes from the root to 1-based position k.
+func (h *NodeHeap[T]) pathTo(k int) []*TreeNode[T] {
+	node := h.root
+	path := []*TreeNode[T]{node}
+	for bit := bits.Len(uint(k)) - 2; bit >= 0; bit-- {
+		if k>>bit&1 == 0 {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+		path = append(path, node)
+	}
+	return path
+}
+
+// down sifts node's value down by swapping values with a child.
+func (h *NodeHeap[T]) down(node *TreeNode[T]) {
+	for {
+		best := node
+		if node.Left != nil && h.less(node.Left.Val, best.Val) {
+			best = node.Left
+		}
+		if node.Right != nil && h.less(node.Right.Val, best.Val) {
+			best = node.Right
+		}
+		if best == node {
+			return
+		}
+		node.Val, best.Val = best.Val, node.Val
+		node = best
+	}
+}
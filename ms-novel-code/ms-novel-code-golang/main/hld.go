@@ -0,0 +1,155 @@
+package core
+
+// HLD is a heavy-light decomposition of a static tree, answering path
+// aggregate queries and point updates between any two nodes in
+// O(log² n). Every node's heavy child is the one with the larger
+// subtree; chains of heavy edges are laid out contiguously on a
+// SegmentTree, and any root-ward path crosses at most O(log n) chains.
+// The tree's shape must not change after NewHLD; values change only
+// through Update.
+type HLD struct {
+	id     map[*Node]int // node -> index in the arrays below
+	parent []int         // -1 for the root
+	depth  []int
+	head   []int // top node of the heavy chain
+	pos    []int // position in seg
+	seg    *SegmentTree
+}
+
+// NewHLD decomposes the tree rooted at root, indexing its values.
+func NewHLD(root *Node) *HLD {
+	h := &HLD{id: map[*Node]int{}}
+	if root == nil {
+		h.seg = NewSegmentTree(nil)
+		return h
+	}
+
+	// Number the nodes in preorder, so parents precede children.
+	var nodes []*Node
+	eachNode(root, func(n *Node) {
+		h.id[n] = len(nodes)
+		nodes = append(nodes, n)
+	})
+	h.parent = make([]int, len(nodes))
+	h.depth = make([]int, len(nodes))
+	h.parent[0] = -1
+	for i, n := range nodes {
+		for _, c := range []*Node{n.Left, n.Right} {
+			if c != nil {
+				h.parent[h.id[c]] = i
+				h.depth[h.id[c]] = h.depth[i] + 1
+			}
+		}
+	}
+
+	size := make([]int, len(nodes))
+	heavy := make([]int, len(nodes))
+	for i := len(nodes) - 1; i >= 0; i-- {
+		size[i]++
+		heavy[i] = -1
+		for _, c := range []*Node{nodes[i].Left, nodes[i].Right} {
+			if c != nil && (heavy[i] == -1 || size[h.id[c]] > size[heavy[i]]) {
+				heavy[i] = h.id[c]
+			}
+		}
+		if p := h.parent[i]; p >= 0 {
+			size[p] += size[i]
+		}
+	}
+
+	// Lay out each chain contiguously, starting a new one at every
+	// light child.
+	h.head = make([]int, len(nodes))
+	h.pos = make([]int, len(nodes))
+	vals := make([]int, len(nodes))
+	next := 0
+	heads := []int{0}
+	for len(heads) > 0 {
+		top := heads[len(heads)-1]
+		heads = heads[:len(heads)-1]
+		for i := top; i != -1; i = heavy[i] {
+			h.head[i], h.pos[i] = top, next
+			vals[next] = nodes[i].Val
+			next++
+			for _, c := range []*Node{nodes[i].Left, nodes[i].Right} {
+				if c != nil && h.id[c] != heavy[i] {
+					heads = append(heads, h.id[c])
+				}
+			}
+		}
+	}
+	h.seg = NewSegmentTree(vals)
+	return h
+}
+
+// Len returns the number of nodes indexed.
+func (h *HLD) Len() int {
+	return len(h.id)
+}
+
+// Update sets n's value to v, in the index and in n.Val, and reports
+// whether n is part of the tree.
+func (h *HLD) Update(n *Node, v int) bool {
+	i, ok := h.id[n]
+	if !ok {
+		return false
+	}
+	n.Val = v
+	h.seg.Update(h.pos[i], v)
+	return true
+}
+
+// PathSum returns the sum of the values on the path between a and b,
+// both included, or false if either is not part of the tree.
+func (h *HLD) PathSum(a, b *Node) (int, bool) {
+	res := 0
+	ok := h.path(a, b, func(lo, hi int) { res += h.seg.RangeSum(lo, hi) })
+	return res, ok
+}
+
+// PathMax returns the largest value on the path between a and b, both
+// included, or false if either is not part of the tree.
+func (h *HLD) PathMax(a, b *Node) (int, bool) {
+	res, first := 0, true
+	ok := h.path(a, b, func(lo, hi int) {
+		if m := h.seg.RangeMax(lo, hi); first || m > res {
+			res, first = m, false
+		}
+	})
+	return res, ok
+}
+
+// PathMin returns the smallest value on the path between a and b, both
+// included, or false if either is not part of the tree.
+func (h *HLD) PathMin(a, b *Node) (int, bool) {
+	res, first := 0, true
+	ok := h.path(a, b, func(lo, hi int) {
+		if m := h.seg.RangeMin(lo, hi); first || m < res {
+			res, first = m, false
+		}
+	})
+	return res, ok
+}
+
+// path calls visit with the segment ranges [lo, hi) that make up the
+// path between a and b, climbing from whichever end's chain head is
+// deeper until both ends share a chain.
+func (h *HLD) path(a, b *Node, visit func(lo, hi int)) bool {
+	i, okA := h.id[a]
+	j, okB := h.id[b]
+	if !okA || !okB {
+		return false
+	}
+	for h.head[i] != h.head[j] {
+		if h.depth[h.head[i]] < h.depth[h.head[j]] {
+			i, j = j, i
+		}
+		visit(h.pos[h.head[i]], h.pos[i]+1)
+		i = h.parent[h.head[i]]
+	}
+	if h.depth[i] > h.depth[j] {
+		i, j = j, i
+	}
+	visit(h.pos[i], h.pos[j]+1)
+	return true
+}
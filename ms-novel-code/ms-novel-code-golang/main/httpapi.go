@@ -0,0 +1,142 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxBodyBytes is the request body limit used by NewHandler
+// unless WithMaxBodyBytes says otherwise.
+const DefaultMaxBodyBytes = 1 << 20
+
+// handlerConfig holds the settings applied by HandlerOption values.
+type handlerConfig struct {
+	maxBody   int64
+	traversal []TraversalOption
+}
+
+// HandlerOption customises the handler returned by NewHandler.
+type HandlerOption func(*handlerConfig)
+
+// WithMaxBodyBytes caps the size of a request body; larger requests are
+// rejected with 413 Request Entity Too Large.
+func WithMaxBodyBytes(n int64) HandlerOption {
+	return func(c *handlerConfig) { c.maxBody = n }
+}
+
+// WithTraversalOptions sets the options passed to every traversal,
+// typically limits such as WithMaxNodes or WithTimeout to protect a
+// public endpoint. They apply to every endpoint: /stats first walks the
+// tree under them. A request that hits a limit is answered with 422
+// Unprocessable Entity.
+func WithTraversalOptions(opts ...TraversalOption) HandlerOption {
+	return func(c *handlerConfig) { c.traversal = opts }
+}
+
+// NewHandler returns an http.Handler exposing the tree algorithms to
+// other services. Every endpoint takes a POST whose body is a tree in
+// the JSON format of TreeNode.MarshalJSON, or null for an empty tree,
+// and answers with a JSON object:
+//
+//	POST /rowwise-max   {"levels":[1,3,9]}
+//	POST /rowwise-min   {"levels":[1,2,3]}
+//	POST /rowwise-sum   {"levels":[1,5,17]}
+//	POST /stats         {"size":6,"height":3,"balanced":true,"complete":false}
+//
+// Failures are answered with {"error":"..."} and status 400 for a
+// malformed tree, 413 for an oversized body, and 422 when a traversal
+// limit is reached.
+func NewHandler(opts ...HandlerOption) http.Handler {
+	cfg := handlerConfig{maxBody: DefaultMaxBodyBytes}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	rowWise := func(combine func(a, b int) int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			root, ok := decodeTreeRequest(w, r, cfg.maxBody)
+			if !ok {
+				return
+			}
+			levels, err := RowWiseReduceE(root, combine, cfg.traversal...)
+			if err != nil && !errors.Is(err, ErrNilRoot) {
+				writeJSONError(w, http.StatusUnprocessableEntity, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, struct {
+				Levels []int `json:"levels"`
+			}{levels})
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /rowwise-max", rowWise(func(a, b int) int { return max(a, b) }))
+	mux.Handle("POST /rowwise-min", rowWise(func(a, b int) int { return min(a, b) }))
+	mux.Handle("POST /rowwise-sum", rowWise(func(a, b int) int { return a + b }))
+	mux.HandleFunc("POST /stats", func(w http.ResponseWriter, r *http.Request) {
+		root, ok := decodeTreeRequest(w, r, cfg.maxBody)
+		if !ok {
+			return
+		}
+		// The statistics take no options, so check the limits with a
+		// walk that does before running them.
+		if len(cfg.traversal) > 0 {
+			_, err := RowWiseReduceE(root, func(a, _ int) int { return a }, cfg.traversal...)
+			if err != nil && !errors.Is(err, ErrNilRoot) {
+				writeJSONError(w, http.StatusUnprocessableEntity, err)
+				return
+			}
+		}
+		writeJSON(w, http.StatusOK, struct {
+			Size     int  `json:"size"`
+			Height   int  `json:"height"`
+			Balanced bool `json:"balanced"`
+			Complete bool `json:"complete"`
+		}{Size(root), Height(root), IsBalanced(root), IsComplete(root)})
+	})
+	return mux
+}
+
+// decodeTreeRequest reads the tree in r's body. On failure it writes the
+// error response itself and returns false.
+func decodeTreeRequest(w http.ResponseWriter, r *http.Request, maxBody int64) (*Node, bool) {
+	var root *Node
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBody))
+	err := dec.Decode(&root)
+	if err == nil {
+		// Only whitespace may follow the tree. More is not enough: it
+		// reports false before a stray '}' or ']'.
+		var extra json.RawMessage
+		switch err = dec.Decode(&extra); {
+		case errors.Is(err, io.EOF):
+			err = nil
+		case err == nil:
+			err = errors.New("unexpected data after tree")
+		}
+	}
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, err)
+		} else {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("decode tree: %w", err))
+		}
+		return nil, false
+	}
+	return root, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownSymbol is returned by HuffmanEncode for a byte that has no
+// code in the tree.
+var ErrUnknownSymbol = errors.New("core: symbol not in huffman tree")
+
+// ErrInvalidCode is returned by HuffmanDecode for input that is not a
+// sequence of complete codes.
+var ErrInvalidCode = errors.New("core: invalid huffman code")
+
+// BuildHuffmanTree builds an optimal prefix-code tree for the given
+// symbol frequencies. Leaves hold a symbol as their Val; internal nodes
+// hold the combined frequency of the leaves below them. Going left
+// appends '0' to a code and going right appends '1'. Symbols with a
+// frequency <= 0 are left out, and nil is returned if none remain.
+//
+// Ties are broken by symbol and then by creation order, so the same
+// frequencies always give the same tree. A single symbol gets the
+// one-bit code "0": its leaf hangs as the left child of the root.
+func BuildHuffmanTree(freqs map[byte]int) *Node {
+	type item struct {
+		node   *Node
+		weight int
+		order  int // leaves use their symbol, merged nodes count up past 255
+	}
+	items := make([]item, 0, len(freqs))
+	for sym, f := range freqs {
+		if f > 0 {
+			items = append(items, item{&Node{Val: int(sym)}, f, int(sym)})
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	h := Heapify(items, func(a, b item) bool {
+		if a.weight != b.weight {
+			return a.weight < b.weight
+		}
+		return a.order < b.order
+	})
+	if h.Len() == 1 {
+		only, _ := h.Pop()
+		return &Node{Val: only.weight, Left: only.node}
+	}
+
+	order := 256
+	for h.Len() > 1 {
+		a, _ := h.Pop()
+		b, _ := h.Pop()
+		h.Push(item{&Node{Val: a.weight + b.weight, Left: a.node, Right: b.node}, a.weight + b.weight, order})
+		order++
+	}
+	root, _ := h.Pop()
+	return root.node
+}
+
+// HuffmanCodes returns the code of every symbol in a tree built by
+// BuildHuffmanTree, as strings of '0' and '1'. The returned map is
+// never nil.
+func HuffmanCodes(root *Node) map[byte]string {
+	codes := map[byte]string{}
+	if root == nil {
+		return codes
+	}
+	type frame struct {
+		node *Node
+		code string
+	}
+	stack := []frame{{root, ""}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if f.node.Left == nil && f.node.Right == nil {
+			codes[byte(f.node.Val)] = f.code
+			continue
+		}
+		if f.node.Right != nil {
+			stack = append(stack, frame{f.node.Right, f.code + "1"})
+		}
+		if f.node.Left != nil {
+			stack = append(stack, frame{f.node.Left, f.code + "0"})
+		}
+	}
+	return codes
+}
+
+// HuffmanEncode returns data encoded with the tree's codes as a string
+// of '0' and '1' characters. It fails with ErrUnknownSymbol if data
+// contains a byte the tree has no code for.
+func HuffmanEncode(root *Node, data []byte) (string, error) {
+	codes := HuffmanCodes(root)
+	var sb strings.Builder
+	for i, b := range data {
+		code, ok := codes[b]
+		if !ok {
+			return "", fmt.Errorf("%w: byte %#02x at offset %d", ErrUnknownSymbol, b, i)
+		}
+		sb.WriteString(code)
+	}
+	return sb.String(), nil
+}
+
+// HuffmanDecode reverses HuffmanEncode. It fails with ErrInvalidCode if
+// bits contains anything but '0' and '1', follows a branch the tree
+// does not have, or ends part-way through a code.
+func HuffmanDecode(root *Node, bits string) ([]byte, error) {
+	out := []byte{}
+	if bits == "" {
+		return out, nil
+	}
+	if root == nil {
+		return nil, fmt.Errorf("%w: empty tree", ErrInvalidCode)
+	}
+	node := root
+	for i := 0; i < len(bits); i++ {
+		switch bits[i] {
+		case '0':
+			node = node.Left
+		case '1':
+			node = node.Right
+		default:
+			return nil, fmt.Errorf("%w: bad character %q at offset %d", ErrInvalidCode, bits[i], i)
+		}
+		if node == nil {
+			return nil, fmt.Errorf("%w: no branch at offset %d", ErrInvalidCode, i)
+		}
+		if node.Left == nil && node.Right == nil {
+			out = append(out, byte(node.Val))
+			node = root
+		}
+	}
+	if node != root {
+		return nil, fmt.Errorf("%w: truncated code", ErrInvalidCode)
+	}
+	return out, nil
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"cmp"
+	"errors"
+)
+
+// ErrNotComplete is returned when a tree that must be complete is not.
+var ErrNotComplete = errors.New("core: tree is not complete")
+
+// ImplicitTree stores a complete binary tree in a flat slice in level
+// order: the node at index i has children at 2i+1 and 2i+2 and its
+// parent at (i-1)/2. With no child pointers to chase, level-order work
+// such as RowWiseReduce is a linear scan over contiguous memory.
+type ImplicitTree[T any] struct {
+	Vals []T
+}
+
+// NewImplicitTree returns the complete tree whose level-order values
+// are vals. The slice is used directly, not copied.
+func NewImplicitTree[T any](vals []T) ImplicitTree[T] {
+	return ImplicitTree[T]{Vals: vals}
+}
+
+// ImplicitFromNode converts a complete pointer-based tree to its
+// implicit form. It returns ErrNotComplete if root is not complete
+// (see IsComplete); a nil root yields an empty tree.
+func ImplicitFromNode[T any](root *TreeNode[T]) (ImplicitTree[T], error) {
+	if !IsComplete(root) {
+		return ImplicitTree[T]{}, ErrNotComplete
+	}
+	vals := []T{}
+	walkLevels(root, func(_ int, level []*TreeNode[T]) bool {
+		for _, node := range level {
+			vals = append(vals, node.Val)
+		}
+		return true
+	})
+	return ImplicitTree[T]{Vals: vals}, nil
+}
+
+// ToNode converts t to a pointer-based tree. The nodes are allocated in
+// one block, in level order. An empty tree yields nil.
+func (t ImplicitTree[T]) ToNode() *TreeNode[T] {
+	n := len(t.Vals)
+	if n == 0 {
+		return nil
+	}
+	nodes := make([]TreeNode[T], n)
+	for i := range nodes {
+		nodes[i].Val = t.Vals[i]
+		if l := 2*i + 1; l < n {
+			nodes[i].Left = &nodes[l]
+		}
+		if r := 2*i + 2; r < n {
+			nodes[i].Right = &nodes[r]
+		}
+	}
+	return &nodes[0]
+}
+
+// Len returns the number of nodes in t.
+func (t ImplicitTree[T]) Len() int { return len(t.Vals) }
+
+// Height returns the number of levels in t, or 0 if it is empty.
+func (t ImplicitTree[T]) Height() int {
+	h := 0
+	for width := 1; width-1 < len(t.Vals); width *= 2 {
+		h++
+	}
+	return h
+}
+
+// Level returns the values at depth d, left-to-right, as a subslice of
+// t.Vals. It returns nil if d is out of range.
+func (t ImplicitTree[T]) Level(d int) []T {
+	if d < 0 || d >= t.Height() {
+		return nil
+	}
+	lo := 1<<d - 1
+	hi := min(2*lo+1, len(t.Vals))
+	return t.Vals[lo:hi]
+}
+
+// RowWiseReduce folds the values of each level of t, left-to-right, with
+// combine and returns one result per level, top-to-bottom, like the
+// package-level RowWiseReduce. The returned slice is never nil, even for
+// an empty tree.
+func (t ImplicitTree[T]) RowWiseReduce(combine func(a, b T) T) []T {
+	res := make([]T, 0, t.Height())
+	for lo := 0; lo < len(t.Vals); lo = 2*lo + 1 {
+		hi := min(2*lo+1, len(t.Vals))
+		acc := t.Vals[lo]
+		for _, v := range t.Vals[lo+1 : hi] {
+			acc = combine(acc, v)
+		}
+		res = append(res, acc)
+	}
+	return res
+}
+
+// ImplicitRowWiseMax returns the maximum value at each level of t,
+// top-to-bottom. The returned slice is never nil, even for an empty tree.
+func ImplicitRowWiseMax[T cmp.Ordered](t ImplicitTree[T]) []T {
+	return t.RowWiseReduce(func(a, b T) T { return max(a, b) })
+}
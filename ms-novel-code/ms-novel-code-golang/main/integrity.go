@@ -0,0 +1,120 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrCycle is returned by ValidateTree when a node links back to one
+	// of its own ancestors.
+	ErrCycle = errors.New("core: tree contains a cycle")
+	// ErrSharedNode is returned by ValidateTree when a node is reachable
+	// through more than one parent link.
+	ErrSharedNode = errors.New("core: node reachable through more than one link")
+	// ErrTooDeep is returned by ValidateTree when the tree is deeper than
+	// the configured limit.
+	ErrTooDeep = errors.New("core: tree exceeds the depth limit")
+)
+
+// DefaultDepthLimit is the deepest node ValidateTree accepts by default,
+// counted in edges from the root.
+const DefaultDepthLimit = 1 << 20
+
+// validateConfig holds the settings applied by ValidateOption values.
+type validateConfig struct {
+	depthLimit int
+}
+
+// ValidateOption customises ValidateTree.
+type ValidateOption func(*validateConfig)
+
+// WithDepthLimit sets the deepest node ValidateTree accepts, counted in
+// edges from the root (DefaultDepthLimit by default). A limit < 0 turns
+// the check off.
+func WithDepthLimit(limit int) ValidateOption {
+	return func(c *validateConfig) { c.depthLimit = limit }
+}
+
+// ValidateTree checks that the pointer structure under root really is a
+// tree, which every other function in the package assumes: a node that
+// links back to an ancestor (ErrCycle) makes traversals loop forever,
+// and a subtree linked from two places (ErrSharedNode) is visited twice
+// and corrupted by in-place edits. It also rejects trees deeper than the
+// depth limit (ErrTooDeep). The error names the offending node and its
+// paths from the root, as 'L'/'R' steps; it is nil for a valid tree.
+// ValidateTree visits each node once and never follows a bad link, so
+// it is safe to call on any structure.
+func ValidateTree[T any](root *TreeNode[T], opts ...ValidateOption) error {
+	cfg := validateConfig{depthLimit: DefaultDepthLimit}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if root == nil {
+		return nil
+	}
+
+	// How each node was first reached, to rebuild paths for errors.
+	type link struct {
+		parent *TreeNode[T]
+		step   byte
+	}
+	const (
+		seen = iota + 1 // reached, waiting on the stack
+		open            // entered: on the current root-to-node path
+		done
+	)
+	state := map[*TreeNode[T]]int{}
+	via := map[*TreeNode[T]]link{}
+	pathTo := func(n *TreeNode[T]) string {
+		var steps []byte
+		for ; n != root; n = via[n].parent {
+			steps = append(steps, via[n].step)
+		}
+		for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+			steps[i], steps[j] = steps[j], steps[i]
+		}
+		return string(steps)
+	}
+
+	type frame struct {
+		node  *TreeNode[T]
+		depth int
+		exit  bool
+	}
+	stack := []frame{{node: root}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if f.exit {
+			state[f.node] = done
+			continue
+		}
+		if cfg.depthLimit >= 0 && f.depth > cfg.depthLimit {
+			return fmt.Errorf("%w: node %v at depth %d (limit %d)", ErrTooDeep, f.node.Val, f.depth, cfg.depthLimit)
+		}
+		state[f.node] = open
+		stack = append(stack, frame{node: f.node, exit: true})
+
+		for _, c := range []struct {
+			child *TreeNode[T]
+			step  byte
+		}{{f.node.Right, 'R'}, {f.node.Left, 'L'}} {
+			if c.child == nil {
+				continue
+			}
+			switch state[c.child] {
+			case open:
+				return fmt.Errorf("%w: link %q points back to ancestor %v at %q",
+					ErrCycle, pathTo(f.node)+string(c.step), c.child.Val, pathTo(c.child))
+			case seen, done:
+				return fmt.Errorf("%w: node %v at %q is also linked from %q",
+					ErrSharedNode, c.child.Val, pathTo(c.child), pathTo(f.node)+string(c.step))
+			}
+			state[c.child] = seen
+			via[c.child] = link{f.node, c.step}
+			stack = append(stack, frame{node: c.child, depth: f.depth + 1})
+		}
+	}
+	return nil
+}
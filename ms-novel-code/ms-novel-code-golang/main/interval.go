@@ -0,0 +1,219 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Interval is the closed range [Lo, Hi].
+type Interval struct {
+	Lo, Hi int
+}
+
+// Overlaps reports whether the two closed intervals share a point.
+func (iv Interval) Overlaps(o Interval) bool {
+	return iv.Lo <= o.Hi && o.Lo <= iv.Hi
+}
+
+// compareIntervals orders intervals by Lo, then Hi.
+func compareIntervals(a, b Interval) int {
+	if c := cmp.Compare(a.Lo, b.Lo); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Hi, b.Hi)
+}
+
+// intervalNode is an AVL node augmented with the largest Hi in its subtree.
+type intervalNode struct {
+	iv          Interval
+	left, right *intervalNode
+	height      int
+	maxHi       int
+}
+
+// IntervalTree stores a set of closed intervals and finds those
+// overlapping a point or range in O(log n + k) for k results. It is an
+// AVL tree ordered by (Lo, Hi) in which every node also records the
+// largest Hi below it, so whole subtrees that end too early are skipped.
+type IntervalTree struct {
+	root *intervalNode
+	size int
+}
+
+// NewIntervalTree returns an IntervalTree holding ivs.
+func NewIntervalTree(ivs ...Interval) *IntervalTree {
+	t := &IntervalTree{}
+	for _, iv := range ivs {
+		t.Insert(iv)
+	}
+	return t
+}
+
+// Len returns the number of intervals stored.
+func (t *IntervalTree) Len() int {
+	return t.size
+}
+
+// Insert adds iv and reports whether it was not already present.
+// It panics if iv.Lo > iv.Hi.
+func (t *IntervalTree) Insert(iv Interval) bool {
+	if iv.Lo > iv.Hi {
+		panic(fmt.Sprintf("core: invalid interval [%d, %d]", iv.Lo, iv.Hi))
+	}
+	var inserted bool
+	t.root = intervalInsert(t.root, iv, &inserted)
+	if inserted {
+		t.size++
+	}
+	return inserted
+}
+
+// Delete removes iv and reports whether it was present.
+func (t *IntervalTree) Delete(iv Interval) bool {
+	var deleted bool
+	t.root = intervalDelete(t.root, iv, &deleted)
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+// QueryPoint returns the intervals containing p, ordered by (Lo, Hi).
+func (t *IntervalTree) QueryPoint(p int) []Interval {
+	return t.QueryOverlapping(Interval{p, p})
+}
+
+// QueryOverlapping returns the intervals sharing at least one point with
+// q, ordered by (Lo, Hi). The returned slice is never nil.
+func (t *IntervalTree) QueryOverlapping(q Interval) []Interval {
+	res := []Interval{}
+	var walk func(n *intervalNode)
+	walk = func(n *intervalNode) {
+		if n == nil || n.maxHi < q.Lo {
+			return // nothing below ends late enough
+		}
+		walk(n.left)
+		if n.iv.Lo > q.Hi {
+			return // this node and its right subtree start too late
+		}
+		if n.iv.Overlaps(q) {
+			res = append(res, n.iv)
+		}
+		walk(n.right)
+	}
+	walk(t.root)
+	return res
+}
+
+// All returns every interval ordered by (Lo, Hi).
+func (t *IntervalTree) All() []Interval {
+	res := make([]Interval, 0, t.size)
+	var walk func(n *intervalNode)
+	walk = func(n *intervalNode) {
+		if n != nil {
+			walk(n.left)
+			res = append(res, n.iv)
+			walk(n.right)
+		}
+	}
+	walk(t.root)
+	return res
+}
+
+func intervalHeight(n *intervalNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func intervalUpdate(n *intervalNode) {
+	n.height = 1 + max(intervalHeight(n.left), intervalHeight(n.right))
+	n.maxHi = n.iv.Hi
+	if n.left != nil {
+		n.maxHi = max(n.maxHi, n.left.maxHi)
+	}
+	if n.right != nil {
+		n.maxHi = max(n.maxHi, n.right.maxHi)
+	}
+}
+
+func intervalRotateRight(y *intervalNode) *intervalNode {
+	x := y.left
+	y.left = x.right
+	x.right = y
+	intervalUpdate(y)
+	intervalUpdate(x)
+	return x
+}
+
+func intervalRotateLeft(x *intervalNode) *intervalNode {
+	y := x.right
+	x.right = y.left
+	y.left = x
+	intervalUpdate(x)
+	intervalUpdate(y)
+	return y
+}
+
+// intervalRebalance mirrors avlRebalance, also refreshing maxHi.
+func intervalRebalance(n *intervalNode) *intervalNode {
+	intervalUpdate(n)
+	switch bf := intervalHeight(n.left) - intervalHeight(n.right); {
+	case bf > 1:
+		if intervalHeight(n.left.left) < intervalHeight(n.left.right) {
+			n.left = intervalRotateLeft(n.left)
+		}
+		return intervalRotateRight(n)
+	case bf < -1:
+		if intervalHeight(n.right.right) < intervalHeight(n.right.left) {
+			n.right = intervalRotateRight(n.right)
+		}
+		return intervalRotateLeft(n)
+	}
+	return n
+}
+
+func intervalInsert(n *intervalNode, iv Interval, inserted *bool) *intervalNode {
+	if n == nil {
+		*inserted = true
+		return &intervalNode{iv: iv, height: 1, maxHi: iv.Hi}
+	}
+	switch c := compareIntervals(iv, n.iv); {
+	case c < 0:
+		n.left = intervalInsert(n.left, iv, inserted)
+	case c > 0:
+		n.right = intervalInsert(n.right, iv, inserted)
+	default:
+		return n
+	}
+	return intervalRebalance(n)
+}
+
+func intervalDelete(n *intervalNode, iv Interval, deleted *bool) *intervalNode {
+	if n == nil {
+		return nil
+	}
+	switch c := compareIntervals(iv, n.iv); {
+	case c < 0:
+		n.left = intervalDelete(n.left, iv, deleted)
+	case c > 0:
+		n.right = intervalDelete(n.right, iv, deleted)
+	default:
+		*deleted = true
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		succ := n.right
+		for succ.left != nil {
+			succ = succ.left
+		}
+		n.iv = succ.iv
+		var ignored bool
+		n.right = intervalDelete(n.right, succ.iv, &ignored)
+	}
+	return intervalRebalance(n)
+}
@@ -0,0 +1,28 @@
+package core
+
+import "encoding/json"
+
+// treeNodeJSON is the wire shape of a TreeNode:
+// {"val":10,"left":{...},"right":null}.
+type treeNodeJSON[T any] struct {
+	Val   T            `json:"val"`
+	Left  *TreeNode[T] `json:"left"`
+	Right *TreeNode[T] `json:"right"`
+}
+
+// MarshalJSON encodes the node and its subtrees as nested objects with
+// "val", "left", and "right" keys; missing children encode as null.
+func (n TreeNode[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(treeNodeJSON[T]{Val: n.Val, Left: n.Left, Right: n.Right})
+}
+
+// UnmarshalJSON decodes the format produced by MarshalJSON. Absent
+// "left"/"right" keys are treated the same as null.
+func (n *TreeNode[T]) UnmarshalJSON(data []byte) error {
+	var aux treeNodeJSON[T]
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	n.Val, n.Left, n.Right = aux.Val, aux.Left, aux.Right
+	return nil
+}
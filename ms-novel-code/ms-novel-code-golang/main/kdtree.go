@@ -0,0 +1,207 @@
+package core
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ErrDimensionMismatch is returned when a point does not have the
+// dimension of the KDTree it is used with.
+var ErrDimensionMismatch = errors.New("core: point dimension mismatch")
+
+// kdNode is a point of a KDTree; it splits space on axis depth % dim.
+type kdNode struct {
+	point       []float64
+	left, right *kdNode
+}
+
+// KDTree is a k-d tree: a binary tree over points in dim-dimensional
+// space where each level splits on the next coordinate axis in turn,
+// supporting nearest-neighbour and axis-aligned box queries. Distances
+// are Euclidean.
+//
+// Trees built by BuildKDTree are balanced; points added later with
+// Insert are not rebalanced, so queries may slow down after many
+// inserts. Queries use explicit stacks, so even degenerate trees are
+// safe. A KDTree is not safe for concurrent modification.
+type KDTree struct {
+	root *kdNode
+	dim  int
+	size int
+}
+
+// NewKDTree returns an empty KDTree for points of dimension dim. It
+// panics if dim is less than 1.
+func NewKDTree(dim int) *KDTree {
+	if dim < 1 {
+		panic(fmt.Sprintf("core: k-d tree dimension %d is less than 1", dim))
+	}
+	return &KDTree{dim: dim}
+}
+
+// BuildKDTree returns a balanced KDTree holding copies of points, built
+// by splitting on the median along each axis. It returns an error
+// wrapping ErrDimensionMismatch if any point does not have dimension
+// dim, and panics if dim is less than 1.
+func BuildKDTree(dim int, points [][]float64) (*KDTree, error) {
+	t := NewKDTree(dim)
+	nodes := make([]*kdNode, len(points))
+	for i, p := range points {
+		if len(p) != dim {
+			return nil, fmt.Errorf("%w: point %d has dimension %d, want %d", ErrDimensionMismatch, i, len(p), dim)
+		}
+		nodes[i] = &kdNode{point: slices.Clone(p)}
+	}
+	t.root = buildKD(nodes, 0, dim)
+	t.size = len(points)
+	return t, nil
+}
+
+// buildKD links nodes into a balanced subtree splitting on axis
+// depth % dim. It always splits at the middle, so points equal to the
+// median on the axis may end up on either side; the queries allow for
+// that. Its recursion depth is O(log n), even when every point is the
+// same.
+func buildKD(nodes []*kdNode, depth, dim int) *kdNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+	axis := depth % dim
+	slices.SortFunc(nodes, func(a, b *kdNode) int { return cmp.Compare(a.point[axis], b.point[axis]) })
+	mid := len(nodes) / 2
+	n := nodes[mid]
+	n.left = buildKD(nodes[:mid], depth+1, dim)
+	n.right = buildKD(nodes[mid+1:], depth+1, dim)
+	return n
+}
+
+// Len returns the number of points stored.
+func (t *KDTree) Len() int { return t.size }
+
+// Dim returns the dimension of the tree's points.
+func (t *KDTree) Dim() int { return t.dim }
+
+// Insert adds a copy of p. It returns an error wrapping
+// ErrDimensionMismatch if p does not have the tree's dimension.
+func (t *KDTree) Insert(p []float64) error {
+	if len(p) != t.dim {
+		return fmt.Errorf("%w: point has dimension %d, want %d", ErrDimensionMismatch, len(p), t.dim)
+	}
+	link := &t.root
+	for depth := 0; *link != nil; depth++ {
+		axis := depth % t.dim
+		if p[axis] < (*link).point[axis] {
+			link = &(*link).left
+		} else {
+			link = &(*link).right
+		}
+	}
+	*link = &kdNode{point: slices.Clone(p)}
+	t.size++
+	return nil
+}
+
+// NearestNeighbor returns the stored point closest to q and its
+// distance, or false if the tree is empty. Subtrees whose splitting
+// plane is farther away than the best point found so far are skipped.
+// It panics if q does not have the tree's dimension.
+func (t *KDTree) NearestNeighbor(q []float64) ([]float64, float64, bool) {
+	t.mustDim(q)
+	if t.root == nil {
+		return nil, 0, false
+	}
+
+	type frame struct {
+		n     *kdNode
+		depth int
+		bound float64 // lower bound on the squared distance to any point in n
+	}
+	var best *kdNode
+	bestD2 := math.Inf(1)
+	stack := []frame{{t.root, 0, 0}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if f.n == nil || f.bound >= bestD2 {
+			continue
+		}
+		if d2 := sqDist(q, f.n.point); d2 < bestD2 {
+			best, bestD2 = f.n, d2
+		}
+
+		axis := f.depth % t.dim
+		diff := q[axis] - f.n.point[axis]
+		near, far := f.n.left, f.n.right
+		if diff >= 0 {
+			near, far = far, near
+		}
+		// Push far first so the near side, likelier to improve best, is
+		// searched first and tightens the bound.
+		stack = append(stack,
+			frame{far, f.depth + 1, max(f.bound, diff*diff)},
+			frame{near, f.depth + 1, f.bound})
+	}
+	return slices.Clone(best.point), math.Sqrt(bestD2), true
+}
+
+// RangeSearch returns every stored point inside the axis-aligned box
+// with corners lo and hi, bounds included, in no particular order. The
+// returned slice is never nil. It panics if lo or hi does not have the
+// tree's dimension.
+func (t *KDTree) RangeSearch(lo, hi []float64) [][]float64 {
+	t.mustDim(lo)
+	t.mustDim(hi)
+	res := [][]float64{}
+
+	type frame struct {
+		n     *kdNode
+		depth int
+	}
+	stack := []frame{{t.root, 0}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if f.n == nil {
+			continue
+		}
+		inside := true
+		for i, x := range f.n.point {
+			if x < lo[i] || x > hi[i] {
+				inside = false
+				break
+			}
+		}
+		if inside {
+			res = append(res, slices.Clone(f.n.point))
+		}
+
+		// Both subtrees may hold points on the splitting plane.
+		axis := f.depth % t.dim
+		if lo[axis] <= f.n.point[axis] {
+			stack = append(stack, frame{f.n.left, f.depth + 1})
+		}
+		if hi[axis] >= f.n.point[axis] {
+			stack = append(stack, frame{f.n.right, f.depth + 1})
+		}
+	}
+	return res
+}
+
+func (t *KDTree) mustDim(p []float64) {
+	if len(p) != t.dim {
+		panic(fmt.Sprintf("core: point has dimension %d, k-d tree has %d", len(p), t.dim))
+	}
+}
+
+// sqDist returns the squared Euclidean distance between a and b.
+func sqDist(a, b []float64) float64 {
+	d := 0.0
+	for i := range a {
+		diff := a[i] - b[i]
+		d += diff * diff
+	}
+	return d
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrKOutOfRange is returned by the k-th order statistic queries when k
+// is not between 1 and the number of values.
+var ErrKOutOfRange = errors.New("core: k out of range")
+
+// KthSmallest returns the k-th smallest value (1-based) of a binary
+// search tree: the k-th node in inorder. It walks only the first k nodes
+// with an explicit stack, so it costs O(h + k). For many queries on the
+// same tree, see OrderStatTree.
+func KthSmallest[T any](root *TreeNode[T], k int) (T, error) {
+	return kthInorder(root, k, false)
+}
+
+// KthLargest returns the k-th largest value (1-based) of a binary search
+// tree: the k-th node in reverse inorder. It costs O(h + k).
+func KthLargest[T any](root *TreeNode[T], k int) (T, error) {
+	return kthInorder(root, k, true)
+}
+
+// kthInorder returns the k-th value in inorder, or in reverse inorder
+// when reverse is set.
+func kthInorder[T any](root *TreeNode[T], k int, reverse bool) (T, error) {
+	near := func(n *TreeNode[T]) *TreeNode[T] { return n.Left }
+	far := func(n *TreeNode[T]) *TreeNode[T] { return n.Right }
+	if reverse {
+		near, far = far, near
+	}
+
+	if k >= 1 {
+		var stack []*TreeNode[T]
+		node, seen := root, 0
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = near(node)
+			}
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if seen++; seen == k {
+				return node.Val, nil
+			}
+			node = far(node)
+		}
+	}
+	var zero T
+	return zero, fmt.Errorf("%w: k=%d", ErrKOutOfRange, k)
+}
+
+// OrderStatTree augments a binary search tree with the size of every
+// subtree, so that k-th order statistics take O(h) instead of O(h + k):
+// O(log n) for balanced trees. The sizes are computed once, in O(n), by
+// NewOrderStatTree; the tree must not be modified afterwards.
+//
+// For a tree that changes between queries, Treap keeps its sizes up to
+// date and offers the same queries.
+type OrderStatTree[T any] struct {
+	root  *TreeNode[T]
+	sizes map[*TreeNode[T]]int
+}
+
+// NewOrderStatTree computes the subtree sizes of root.
+func NewOrderStatTree[T any](root *TreeNode[T]) *OrderStatTree[T] {
+	sizes := make(map[*TreeNode[T]]int)
+	for _, node := range postorderNodes(root) {
+		sizes[node] = 1 + sizes[node.Left] + sizes[node.Right]
+	}
+	return &OrderStatTree[T]{root: root, sizes: sizes}
+}
+
+// Len returns the number of values in the tree.
+func (t *OrderStatTree[T]) Len() int {
+	return t.sizes[t.root]
+}
+
+// KthSmallest returns the k-th smallest value (1-based).
+func (t *OrderStatTree[T]) KthSmallest(k int) (T, error) {
+	if k < 1 || k > t.Len() {
+		var zero T
+		return zero, fmt.Errorf("%w: k=%d, size %d", ErrKOutOfRange, k, t.Len())
+	}
+	node := t.root
+	for {
+		left := t.sizes[node.Left]
+		switch {
+		case k <= left:
+			node = node.Left
+		case k == left+1:
+			return node.Val, nil
+		default:
+			k -= left + 1
+			node = node.Right
+		}
+	}
+}
+
+// KthLargest returns the k-th largest value (1-based).
+func (t *OrderStatTree[T]) KthLargest(k int) (T, error) {
+	if k < 1 || k > t.Len() {
+		var zero T
+		return zero, fmt.Errorf("%w: k=%d, size %d", ErrKOutOfRange, k, t.Len())
+	}
+	return t.KthSmallest(t.Len() - k + 1)
+}
@@ -0,0 +1,137 @@
+package core
+
+import "cmp"
+
+// LCA returns the lowest common ancestor of nodes a and b in the tree
+// rooted at root: the deepest node that has both as descendants (a node
+// counts as its own descendant). It returns nil if either node is not
+// part of the tree. Nodes are matched by identity, not by value.
+func LCA[T any](root, a, b *TreeNode[T]) *TreeNode[T] {
+	if root == nil || a == nil || b == nil {
+		return nil
+	}
+
+	// Record parents with an explicit DFS until both nodes are found.
+	parent := map[*TreeNode[T]]*TreeNode[T]{root: nil}
+	stack := []*TreeNode[T]{root}
+	for len(stack) > 0 {
+		_, haveA := parent[a]
+		_, haveB := parent[b]
+		if haveA && haveB {
+			break
+		}
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, child := range []*TreeNode[T]{node.Left, node.Right} {
+			if child != nil {
+				parent[child] = node
+				stack = append(stack, child)
+			}
+		}
+	}
+	if _, ok := parent[a]; !ok {
+		return nil
+	}
+	if _, ok := parent[b]; !ok {
+		return nil
+	}
+
+	ancestors := map[*TreeNode[T]]bool{}
+	for n := a; n != nil; n = parent[n] {
+		ancestors[n] = true
+	}
+	for n := b; n != nil; n = parent[n] {
+		if ancestors[n] {
+			return n
+		}
+	}
+	return nil // unreachable: root is an ancestor of both
+}
+
+// LCABST is LCA for binary search trees. It follows the BST ordering
+// from the root, running in O(height) without extra memory. Like LCA it
+// returns nil unless both a and b are nodes of the tree.
+func LCABST[T cmp.Ordered](root, a, b *TreeNode[T]) *TreeNode[T] {
+	if a == nil || b == nil || !bstContainsNode(root, a) || !bstContainsNode(root, b) {
+		return nil
+	}
+	lo, hi := min(a.Val, b.Val), max(a.Val, b.Val)
+	node := root
+	for node != nil {
+		switch {
+		case hi < node.Val:
+			node = node.Left
+		case lo > node.Val:
+			node = node.Right
+		default:
+			return node // the paths to a and b split here
+		}
+	}
+	return nil
+}
+
+// bstContainsNode reports whether target is reached by a BST search for
+// its own value.
+func bstContainsNode[T cmp.Ordered](root, target *TreeNode[T]) bool {
+	node := root
+	for node != nil && node != target {
+		if target.Val < node.Val {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return node == target
+}
+
+// BatchLCA answers many LCA queries on one tree at once with Tarjan's
+// offline algorithm: a single postorder pass over a DSU, O(n + q α(n))
+// in total instead of O(n) per query. The i-th result is the LCA of
+// queries[i]; it is nil if either node is not part of the tree. The
+// returned slice is never nil.
+func BatchLCA[T any](root *TreeNode[T], queries [][2]*TreeNode[T]) []*TreeNode[T] {
+	res := make([]*TreeNode[T], len(queries))
+	order := postorderNodes(root)
+	id := make(map[*TreeNode[T]]int, len(order))
+	for i, n := range order {
+		id[n] = i
+	}
+
+	// pending[u] lists the queries touching u as (other node, index).
+	type query struct{ other, index int }
+	pending := make([][]query, len(order))
+	for i, q := range queries {
+		a, okA := id[q[0]]
+		b, okB := id[q[1]]
+		if okA && okB {
+			pending[a] = append(pending[a], query{b, i})
+			pending[b] = append(pending[b], query{a, i})
+		}
+	}
+
+	// Once u is done, each finished node v lies in the set of the
+	// deepest ancestor of u that v also descends from, and ancestor[]
+	// maps that set to it.
+	sets := NewDSU(len(order))
+	ancestor := make([]int, len(order))
+	for i := range ancestor {
+		ancestor[i] = i
+	}
+	done := make([]bool, len(order))
+	parents := AttachParents(root)
+	for u, node := range order {
+		done[u] = true
+		for _, q := range pending[u] {
+			if done[q.other] {
+				res[q.index] = order[ancestor[sets.Find(q.other)]]
+			}
+		}
+		if p := parents.Parent(node); p != nil {
+			// Postorder finishes u just before its parent moves on, so
+			// this matches the union a recursive DFS makes on return.
+			sets.Union(id[p], u)
+			ancestor[sets.Find(u)] = id[p]
+		}
+	}
+	return res
+}
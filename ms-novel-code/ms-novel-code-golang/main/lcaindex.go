@@ -0,0 +1,132 @@
+package core
+
+import "math/bits"
+
+// LCAIndex answers lowest-common-ancestor and distance queries on a
+// static tree in O(1) after O(n log n) preprocessing. It records an
+// Euler tour of the tree (every node on entry and again after each
+// child) and a sparse table of range minima over the tour's depths: the
+// LCA of a and b is the shallowest node visited between their first
+// appearances. The index does not see later changes to the tree. It is
+// safe for concurrent use.
+type LCAIndex[T any] struct {
+	nodes []*TreeNode[T]       // tour position -> node
+	depth []int                // tour position -> depth of that node
+	first map[*TreeNode[T]]int // node -> first tour position
+	table [][]int              // table[k][i]: shallowest position in [i, i+2^k)
+}
+
+// PreprocessLCA builds an LCAIndex for the tree rooted at root.
+func PreprocessLCA[T any](root *TreeNode[T]) *LCAIndex[T] {
+	x := &LCAIndex[T]{first: map[*TreeNode[T]]int{}}
+	if root == nil {
+		return x
+	}
+
+	type frame struct {
+		node  *TreeNode[T]
+		stage int // 0: visit Left next, 1: Right next, 2: done
+	}
+	visit := func(n *TreeNode[T], depth int) {
+		if _, seen := x.first[n]; !seen {
+			x.first[n] = len(x.nodes)
+		}
+		x.nodes = append(x.nodes, n)
+		x.depth = append(x.depth, depth)
+	}
+	stack := []frame{{root, 0}}
+	visit(root, 0)
+	for len(stack) > 0 {
+		f := &stack[len(stack)-1]
+		var child *TreeNode[T]
+		switch f.stage {
+		case 0:
+			child = f.node.Left
+		case 1:
+			child = f.node.Right
+		default:
+			stack = stack[:len(stack)-1]
+			if len(stack) > 0 {
+				visit(stack[len(stack)-1].node, len(stack)-1)
+			}
+			continue
+		}
+		f.stage++
+		if child != nil {
+			stack = append(stack, frame{child, 0})
+			visit(child, len(stack)-1)
+		}
+	}
+
+	n := len(x.nodes)
+	base := make([]int, n)
+	for i := range base {
+		base[i] = i
+	}
+	x.table = [][]int{base}
+	for k := 1; 1<<k <= n; k++ {
+		prev, half := x.table[k-1], 1<<(k-1)
+		row := make([]int, n-1<<k+1)
+		for i := range row {
+			row[i] = x.shallower(prev[i], prev[i+half])
+		}
+		x.table = append(x.table, row)
+	}
+	return x
+}
+
+// shallower returns whichever tour position has the smaller depth.
+func (x *LCAIndex[T]) shallower(i, j int) int {
+	if x.depth[j] < x.depth[i] {
+		return j
+	}
+	return i
+}
+
+// Len returns the number of nodes indexed.
+func (x *LCAIndex[T]) Len() int {
+	return len(x.first)
+}
+
+// Depth returns the depth of n (the root has depth 0), or false if n is
+// not part of the tree.
+func (x *LCAIndex[T]) Depth(n *TreeNode[T]) (int, bool) {
+	i, ok := x.first[n]
+	if !ok {
+		return 0, false
+	}
+	return x.depth[i], true
+}
+
+// LCA returns the lowest common ancestor of a and b (a node counts as
+// its own ancestor), or nil if either is not part of the tree. Nodes are
+// matched by identity.
+func (x *LCAIndex[T]) LCA(a, b *TreeNode[T]) *TreeNode[T] {
+	i, okA := x.first[a]
+	j, okB := x.first[b]
+	if !okA || !okB {
+		return nil
+	}
+	return x.nodes[x.lcaPos(i, j)]
+}
+
+// Distance returns the number of edges on the path between a and b, or
+// false if either is not part of the tree.
+func (x *LCAIndex[T]) Distance(a, b *TreeNode[T]) (int, bool) {
+	i, okA := x.first[a]
+	j, okB := x.first[b]
+	if !okA || !okB {
+		return 0, false
+	}
+	return x.depth[i] + x.depth[j] - 2*x.depth[x.lcaPos(i, j)], true
+}
+
+// lcaPos returns the shallowest tour position between positions i and j
+// inclusive, covering the range with two overlapping power-of-two runs.
+func (x *LCAIndex[T]) lcaPos(i, j int) int {
+	if i > j {
+		i, j = j, i
+	}
+	k := bits.Len(uint(j-i+1)) - 1
+	return x.shallower(x.table[k][i], x.table[k][j-1<<k+1])
+}
@@ -0,0 +1,23 @@
+package core
+
+import "iter"
+
+// Levels returns an iterator over the tree's levels, top-to-bottom,
+// yielding each level's depth and its values left-to-right. Levels are
+// produced lazily, so breaking out of the range loop stops the traversal
+// without visiting the rest of the tree.
+//
+//	for depth, vals := range Levels(root) {
+//		...
+//	}
+func Levels[T any](root *TreeNode[T]) iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		walkLevels(root, func(depth int, level []*TreeNode[T]) bool {
+			vals := make([]T, len(level))
+			for i, node := range level {
+				vals[i] = node.Val
+			}
+			return yield(depth, vals)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package core
+
+import "math/bits"
+
+// AncestorTable answers k-th-ancestor and LCA queries on a static tree
+// in O(log n) by binary lifting: for every node it stores the ancestors
+// 2^0, 2^1, 2^2, ... levels up, so any jump is a sum of O(log n) stored
+// jumps. Building it takes O(n log n) time and space. The table does not
+// see later changes to the tree. It is safe for concurrent use.
+type AncestorTable[T any] struct {
+	nodes []*TreeNode[T]
+	id    map[*TreeNode[T]]int
+	depth []int
+	up    [][]int // up[j][i]: 2^j-th ancestor of node i, or -1
+}
+
+// PreprocessAncestors builds an AncestorTable for the tree rooted at
+// root.
+func PreprocessAncestors[T any](root *TreeNode[T]) *AncestorTable[T] {
+	a := &AncestorTable[T]{id: map[*TreeNode[T]]int{}}
+	var parent []int
+	// Preorder numbering puts every parent before its children.
+	eachNode(root, func(n *TreeNode[T]) {
+		a.id[n] = len(a.nodes)
+		a.nodes = append(a.nodes, n)
+		parent = append(parent, -1)
+		a.depth = append(a.depth, 0)
+	})
+	for i, n := range a.nodes {
+		for _, c := range []*TreeNode[T]{n.Left, n.Right} {
+			if c != nil {
+				parent[a.id[c]] = i
+				a.depth[a.id[c]] = a.depth[i] + 1
+			}
+		}
+	}
+
+	a.up = [][]int{parent}
+	maxDepth := 0
+	for _, d := range a.depth {
+		maxDepth = max(maxDepth, d)
+	}
+	for j := 1; j < bits.Len(uint(maxDepth)); j++ {
+		prev := a.up[j-1]
+		row := make([]int, len(a.nodes))
+		for i := range row {
+			if mid := prev[i]; mid >= 0 {
+				row[i] = prev[mid]
+			} else {
+				row[i] = -1
+			}
+		}
+		a.up = append(a.up, row)
+	}
+	return a
+}
+
+// Len returns the number of nodes indexed.
+func (a *AncestorTable[T]) Len() int {
+	return len(a.nodes)
+}
+
+// Depth returns the depth of n (the root has depth 0), or false if n is
+// not part of the tree.
+func (a *AncestorTable[T]) Depth(n *TreeNode[T]) (int, bool) {
+	i, ok := a.id[n]
+	if !ok {
+		return 0, false
+	}
+	return a.depth[i], true
+}
+
+// KthAncestor returns the node k levels above n (n itself for k == 0),
+// or nil if n is not part of the tree or k is negative or larger than
+// n's depth.
+func (a *AncestorTable[T]) KthAncestor(n *TreeNode[T], k int) *TreeNode[T] {
+	i, ok := a.id[n]
+	if !ok || k < 0 || k > a.depth[i] {
+		return nil
+	}
+	return a.nodes[a.jump(i, k)]
+}
+
+// LCA returns the lowest common ancestor of x and y (a node counts as
+// its own ancestor), or nil if either is not part of the tree. Nodes are
+// matched by identity.
+func (a *AncestorTable[T]) LCA(x, y *TreeNode[T]) *TreeNode[T] {
+	i, okX := a.id[x]
+	j, okY := a.id[y]
+	if !okX || !okY {
+		return nil
+	}
+	if a.depth[i] < a.depth[j] {
+		i, j = j, i
+	}
+	i = a.jump(i, a.depth[i]-a.depth[j])
+	if i == j {
+		return a.nodes[i]
+	}
+	// Lift both as far as they stay apart; their parent is the LCA.
+	for k := len(a.up) - 1; k >= 0; k-- {
+		if a.up[k][i] != a.up[k][j] {
+			i, j = a.up[k][i], a.up[k][j]
+		}
+	}
+	return a.nodes[a.up[0][i]]
+}
+
+// jump returns the ancestor k levels above node i; k must not exceed
+// i's depth.
+func (a *AncestorTable[T]) jump(i, k int) int {
+	for j := 0; k > 0; j, k = j+1, k>>1 {
+		if k&1 == 1 {
+			i = a.up[j][i]
+		}
+	}
+	return i
+}
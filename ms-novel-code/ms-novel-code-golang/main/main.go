@@ -2,51 +2,224 @@
 
 package core
 
-// import "fmt"
+import "cmp"
 
-type Node struct {
-	Val   int
-	Left  *Node
-	Right *Node
+// TreeNode is a binary tree node carrying a value of any type T.
+type TreeNode[T any] struct {
+	Val   T
+	Left  *TreeNode[T]
+	Right *TreeNode[T]
 }
 
+// Node is the int-valued tree node used by the rest of the package.
+type Node = TreeNode[int]
+
 // rowWiseMax returns a map whose single key "output" holds the
 // maximum node value found at each tree level, top-to-bottom.
-func rowWiseMax(root *Node) map[string][]int {
+func rowWiseMax(root *Node, opts ...TraversalOption) map[string][]int {
+	return map[string][]int{"output": RowWiseReduce(root, func(a, b int) int { return max(a, b) }, opts...)}
+}
+
+// rowWiseMin returns a map whose single key "output" holds the
+// minimum node value found at each tree level, top-to-bottom.
+func rowWiseMin(root *Node, opts ...TraversalOption) map[string][]int {
+	return map[string][]int{"output": RowWiseReduce(root, func(a, b int) int { return min(a, b) }, opts...)}
+}
+
+// RowWiseMaxResult is the structured form of rowWiseMax's output.
+// Values[i] is the maximum found at depth Depths[i]; NodeCount is the
+// total number of nodes visited.
+type RowWiseMaxResult struct {
+	Values    []int
+	Depths    []int
+	NodeCount int
+}
+
+// RowWiseMaxWithDepths returns rowWiseMax's per-level maxima together
+// with each level's depth and the tree's node count. Values and Depths
+// are never nil, even for an empty tree.
+func RowWiseMaxWithDepths(root *Node) RowWiseMaxResult {
+	res := RowWiseMaxResult{Values: []int{}, Depths: []int{}}
+
+	walkLevels(root, func(depth int, level []*Node) bool {
+		res.Values = append(res.Values, maxOfNodes(level))
+		res.Depths = append(res.Depths, depth)
+		res.NodeCount += len(level)
+		return true
+	})
+
+	return res
+}
+
+// RowWiseMaxNodes returns the node holding the maximum value at each
+// tree level, top-to-bottom, so callers can act on the node itself.
+// When several nodes tie, the left-most one is returned. The returned
+// slice is never nil, even for an empty tree.
+func RowWiseMaxNodes[T cmp.Ordered](root *TreeNode[T]) []*TreeNode[T] {
+	res := []*TreeNode[T]{}
+
+	walkLevels(root, func(_ int, level []*TreeNode[T]) bool {
+		best := level[0]
+		for _, node := range level[1:] {
+			if node.Val > best.Val {
+				best = node
+			}
+		}
+		res = append(res, best)
+		return true
+	})
+
+	return res
+}
+
+// RowWiseMaxOf returns the maximum value found at each level of a
+// tree over any ordered type, top-to-bottom.
+func RowWiseMaxOf[T cmp.Ordered](root *TreeNode[T], opts ...TraversalOption) []T {
+	return RowWiseReduce(root, func(a, b T) T { return max(a, b) }, opts...)
+}
+
+// RowWiseMinOf returns the minimum value found at each level of a
+// tree over any ordered type, top-to-bottom.
+func RowWiseMinOf[T cmp.Ordered](root *TreeNode[T], opts ...TraversalOption) []T {
+	return RowWiseReduce(root, func(a, b T) T { return min(a, b) }, opts...)
+}
+
+// RowWiseMaxFunc is like RowWiseMaxOf but orders values with less,
+// for types that are not cmp.Ordered. Ties keep the left-most value.
+func RowWiseMaxFunc[T any](root *TreeNode[T], less func(a, b T) bool, opts ...TraversalOption) []T {
+	return RowWiseReduce(root, func(a, b T) T {
+		if less(a, b) {
+			return b
+		}
+		return a
+	}, opts...)
+}
+
+// RowWiseMinFunc is like RowWiseMinOf but orders values with less,
+// for types that are not cmp.Ordered. Ties keep the left-most value.
+func RowWiseMinFunc[T any](root *TreeNode[T], less func(a, b T) bool, opts ...TraversalOption) []T {
+	return RowWiseReduce(root, func(a, b T) T {
+		if less(b, a) {
+			return b
+		}
+		return a
+	}, opts...)
+}
+
+// RowWiseReduce folds the values of each tree level, left-to-right,
+// with combine and returns one result per level, top-to-bottom.
+// combine should be associative (sum, product, min, bitwise-or, ...).
+// The returned slice is never nil, even for an empty tree.
+// By default the tree is walked breadth-first; see WithStrategy. If a
+// limit set by WithMaxDepth, WithMaxNodes or WithTimeout is reached, it
+// returns the levels completed so far, and with WithCycleCheck a
+// corrupted tree gives no levels; RowWiseReduceE reports why.
+func RowWiseReduce[T any](root *TreeNode[T], combine func(a, b T) T, opts ...TraversalOption) []T {
+	res, _ := RowWiseReduceE(root, combine, opts...)
+	return res
+}
+
+// RowWiseMaxE is RowWiseMaxOf with every reason for a short or empty
+// result reported as an error; see RowWiseReduceE.
+func RowWiseMaxE[T cmp.Ordered](root *TreeNode[T], opts ...TraversalOption) ([]T, error) {
+	return RowWiseReduceE(root, func(a, b T) T { return max(a, b) }, opts...)
+}
+
+// RowWiseMinE is RowWiseMinOf with every reason for a short or empty
+// result reported as an error; see RowWiseReduceE.
+func RowWiseMinE[T cmp.Ordered](root *TreeNode[T], opts ...TraversalOption) ([]T, error) {
+	return RowWiseReduceE(root, func(a, b T) T { return min(a, b) }, opts...)
+}
+
+// RowWiseReduceE is RowWiseReduce with errors instead of silent
+// results, so callers can tell the cases apart: ErrNilRoot for an empty
+// tree, ErrCycle or ErrSharedNode for a corrupted one (with
+// WithCycleCheck), and a *LimitError, along with the levels completed
+// so far, when a traversal limit is reached. The returned slice is
+// never nil.
+func RowWiseReduceE[T any](root *TreeNode[T], combine func(a, b T) T, opts ...TraversalOption) ([]T, error) {
 	// Always return a non-nil slice, even for an empty tree.
+	res := []T{}
 	if root == nil {
-		return map[string][]int{"output": []int{}}
+		return res, ErrNilRoot
+	}
+	cfg := newTraversalConfig(opts)
+	if cfg.validate {
+		if err := ValidateTree(root, WithDepthLimit(-1)); err != nil {
+			return res, err
+		}
+	}
+	if cfg.strategy == StrategyMorris && !cfg.limited() {
+		return morrisReduce(root, combine), nil
 	}
 
 	var (
-		res   []int   // result slice
-		queue []*Node // simple FIFO queue
+		lim *limiter
+		err error
 	)
-	queue = append(queue, root)
+	if cfg.limited() {
+		lim = newLimiter(cfg)
+	}
 
-	for len(queue) > 0 {
-		levelSize := len(queue)
-		maxVal := queue[0].Val // first node's value is current max
+	walkLevels(root, func(depth int, level []*TreeNode[T]) bool {
+		if lim != nil {
+			if err = lim.admit(depth, len(level)); err != nil {
+				return false
+			}
+		}
+		acc := level[0].Val // first node's value seeds the fold
+		for _, node := range level[1:] {
+			acc = combine(acc, node.Val)
+		}
+		res = append(res, acc)
+		return true
+	})
 
-		// Process one level
-		for i := 0; i < levelSize; i++ {
-			node := queue[0]
-			queue = queue[1:]
+	return res, err
+}
 
-			if node.Val > maxVal {
-				maxVal = node.Val
-			}
+// walkLevels is the level-order core shared by the row-wise functions.
+// It calls visit with the depth and the nodes of each level, left-to-right
+// and top-to-bottom, until visit returns false. The level slice is only
+// valid for the duration of the call.
+//
+// Two buffers are swapped between levels: the current level is read from
+// one while its children are collected into the other. Both are reused,
+// so memory stays bounded by twice the widest level instead of growing
+// with every node pushed through a FIFO.
+//
+// A level of n nodes has at most 2n children, so the buffer is grown to
+// that bound up front rather than by append's repeated doubling. This
+// keeps the walk to at most one allocation per level, and none once the
+// tree stops widening.
+func walkLevels[T any](root *TreeNode[T], visit func(depth int, level []*TreeNode[T]) bool) {
+	if root == nil {
+		return
+	}
+
+	level := []*TreeNode[T]{root}
+	var next []*TreeNode[T]
+
+	for depth := 0; len(level) > 0; depth++ {
+		if !visit(depth, level) {
+			return
+		}
+
+		// Collect the next level
+		if cap(next) < 2*len(level) {
+			next = make([]*TreeNode[T], 0, 2*len(level))
+		}
+		next = next[:0]
+		for _, node := range level {
 			if node.Left != nil {
-				queue = append(queue, node.Left)
+				next = append(next, node.Left)
 			}
 			if node.Right != nil {
-				queue = append(queue, node.Right)
+				next = append(next, node.Right)
 			}
 		}
-		res = append(res, maxVal)
+		level, next = next, level
 	}
-
-	return map[string][]int{"output": res}
 }
 
 // --- example usage ---
@@ -0,0 +1,187 @@
+package core
+
+import "math"
+
+// Height returns the number of levels in the tree: 0 for an empty tree,
+// 1 for a single node. It always equals len(rowWiseMax(root)["output"]).
+func Height[T any](root *TreeNode[T]) int {
+	height := 0
+	walkLevels(root, func(_ int, _ []*TreeNode[T]) bool {
+		height++
+		return true
+	})
+	return height
+}
+
+// Size returns the number of nodes in the tree.
+func Size[T any](root *TreeNode[T]) int {
+	size := 0
+	eachNode(root, func(*TreeNode[T]) { size++ })
+	return size
+}
+
+// CountLeaves returns the number of nodes without children.
+func CountLeaves[T any](root *TreeNode[T]) int {
+	leaves := 0
+	eachNode(root, func(n *TreeNode[T]) {
+		if n.Left == nil && n.Right == nil {
+			leaves++
+		}
+	})
+	return leaves
+}
+
+// CountInternal returns the number of nodes with at least one child.
+func CountInternal[T any](root *TreeNode[T]) int {
+	internal := 0
+	eachNode(root, func(n *TreeNode[T]) {
+		if n.Left != nil || n.Right != nil {
+			internal++
+		}
+	})
+	return internal
+}
+
+// eachNode calls fn once for every node, in preorder, using an explicit
+// stack so that deep trees cannot overflow the goroutine stack.
+func eachNode[T any](root *TreeNode[T], fn func(*TreeNode[T])) {
+	if root == nil {
+		return
+	}
+	stack := []*TreeNode[T]{root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		fn(node)
+		if node.Right != nil {
+			stack = append(stack, node.Right)
+		}
+		if node.Left != nil {
+			stack = append(stack, node.Left)
+		}
+	}
+}
+
+// Diameter returns the number of edges on the longest path between any
+// two nodes, which need not pass through the root. It is 0 for an empty
+// or single-node tree. Heights are computed bottom-up in one pass.
+func Diameter[T any](root *TreeNode[T]) int {
+	heights := make(map[*TreeNode[T]]int)
+	diameter := 0
+	for _, node := range postorderNodes(root) {
+		l, r := heights[node.Left], heights[node.Right] // nil maps to 0
+		diameter = max(diameter, l+r)
+		heights[node] = 1 + max(l, r)
+	}
+	return diameter
+}
+
+// widthConfig holds the settings applied by WidthOption values.
+type widthConfig struct {
+	nullGaps bool
+}
+
+// WidthOption customises MaxWidth.
+type WidthOption func(*widthConfig)
+
+// WithWidthNullGaps makes MaxWidth measure each level from its left-most
+// to its right-most node, counting the positions of missing nodes in
+// between as if the level were complete (the classic "maximum width"
+// definition).
+func WithWidthNullGaps() WidthOption {
+	return func(c *widthConfig) { c.nullGaps = true }
+}
+
+// MaxWidth returns the number of nodes on the widest level, or 0 for an
+// empty tree. With WithWidthNullGaps, missing nodes between a level's
+// ends are counted too; such widths grow exponentially with depth and
+// saturate at math.MaxInt instead of overflowing.
+func MaxWidth[T any](root *TreeNode[T], opts ...WidthOption) int {
+	var cfg widthConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if !cfg.nullGaps {
+		width := 0
+		walkLevels(root, func(_ int, level []*TreeNode[T]) bool {
+			width = max(width, len(level))
+			return true
+		})
+		return width
+	}
+	if root == nil {
+		return 0
+	}
+
+	// pos is a node's index within its level as if the level were
+	// complete, rebased each level so the left-most parent with children
+	// sits at 0 and positions stay small.
+	type slot struct {
+		node *TreeNode[T]
+		pos  int
+	}
+	level := []slot{{root, 0}}
+	var next []slot
+	width := 0
+	for len(level) > 0 {
+		width = max(width, level[len(level)-1].pos-level[0].pos+1)
+
+		next = next[:0]
+		base := -1
+		for _, s := range level {
+			if s.node.Left == nil && s.node.Right == nil {
+				continue
+			}
+			if base < 0 {
+				base = s.pos
+			}
+			rel := s.pos - base
+			if rel > (math.MaxInt-1)/2 {
+				return math.MaxInt
+			}
+			if s.node.Left != nil {
+				next = append(next, slot{s.node.Left, 2 * rel})
+			}
+			if s.node.Right != nil {
+				next = append(next, slot{s.node.Right, 2*rel + 1})
+			}
+		}
+		level, next = next, level
+	}
+	return width
+}
+
+// CountCompleteTreeNodes returns the number of nodes in a complete tree
+// (see IsComplete) in O(log² n) instead of Size's O(n). At each step it
+// compares the left-spine heights of the two subtrees: if they are
+// equal the left subtree is perfect and its size is known, otherwise the
+// right one is, and the search continues in the other subtree. The
+// result is unspecified for trees that are not complete, but it does
+// not panic.
+func CountCompleteTreeNodes[T any](root *TreeNode[T]) int {
+	spine := func(n *TreeNode[T]) int {
+		h := 0
+		for ; n != nil; n = n.Left {
+			h++
+		}
+		return h
+	}
+
+	count := 0
+	for node, h := root, spine(root); node != nil; h-- {
+		if spine(node.Right) == h-1 {
+			count += 1 << (h - 1) // node plus its perfect left subtree
+			node = node.Right
+		} else if h < 2 {
+			// node has a right child but no left one, so the tree is not
+			// complete; count the rest the slow way instead of shifting
+			// by a negative amount.
+			return count + Size(node)
+		} else {
+			count += 1 << (h - 2) // node plus its perfect right subtree
+			node = node.Left
+		}
+	}
+	return count
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+)
+
+// HashTree returns a Merkle hash of the tree rooted at root: each node's
+// hash covers its value and its children's hashes, so two trees hash
+// equal exactly when they have the same shape and values (barring
+// collisions in h). h is reset and reused; nil selects SHA-256.
+func HashTree(root *Node, h hash.Hash) []byte {
+	if root == nil {
+		return nilSubtreeHash(defaultHasher(h))
+	}
+	return SubtreeHashes(root, h)[root]
+}
+
+// SubtreeHashes returns the Merkle hash of every subtree of root, keyed
+// by its root node, as computed by HashTree.
+func SubtreeHashes(root *Node, h hash.Hash) map[*Node][]byte {
+	h = defaultHasher(h)
+	hashes := make(map[*Node][]byte)
+	nilHash := nilSubtreeHash(h)
+	child := func(n *Node) []byte {
+		if n == nil {
+			return nilHash
+		}
+		return hashes[n]
+	}
+
+	var buf [1 + binary.MaxVarintLen64]byte
+	for _, node := range postorderNodes(root) {
+		buf[0] = 1 // distinguishes a node from the empty nil-subtree input
+		n := binary.PutVarint(buf[1:], int64(node.Val))
+		h.Reset()
+		h.Write(buf[:1+n])
+		h.Write(child(node.Left))
+		h.Write(child(node.Right))
+		hashes[node] = h.Sum(nil)
+	}
+	return hashes
+}
+
+// HashDiff is a position where two trees differ. Path spells the route
+// from the root as a string of 'L' and 'R' steps ("" is the root); A and
+// B are the subtrees found there in each tree, either of which may be
+// nil.
+type HashDiff struct {
+	Path string
+	A, B *Node
+}
+
+// DiffByHash returns the smallest subtrees in which a and b differ, in
+// preorder of their positions. Subtrees whose hashes match are skipped
+// without being walked, so only the branches that changed need to be
+// transmitted or inspected. A position is reported as a whole when the
+// nodes there differ in value or one is missing; otherwise the search
+// continues into its children. h is as for HashTree.
+func DiffByHash(a, b *Node, h hash.Hash) []HashDiff {
+	h = defaultHasher(h)
+	ha, hb := SubtreeHashes(a, h), SubtreeHashes(b, h)
+	equal := func(x, y *Node) bool {
+		return x != nil && y != nil && string(ha[x]) == string(hb[y])
+	}
+
+	res := []HashDiff{}
+	type frame struct {
+		path string
+		x, y *Node
+	}
+	stack := []frame{{"", a, b}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		switch {
+		case f.x == nil && f.y == nil, equal(f.x, f.y):
+		case f.x == nil || f.y == nil || f.x.Val != f.y.Val:
+			res = append(res, HashDiff{Path: f.path, A: f.x, B: f.y})
+		default:
+			stack = append(stack,
+				frame{f.path + "R", f.x.Right, f.y.Right},
+				frame{f.path + "L", f.x.Left, f.y.Left})
+		}
+	}
+	return res
+}
+
+func defaultHasher(h hash.Hash) hash.Hash {
+	if h == nil {
+		return sha256.New()
+	}
+	return h
+}
+
+// nilSubtreeHash is the hash of an empty subtree: the digest of no input.
+func nilSubtreeHash(h hash.Hash) []byte {
+	h.Reset()
+	return h.Sum(nil)
+}
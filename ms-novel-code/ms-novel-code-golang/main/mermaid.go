@@ -0,0 +1,90 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// mermaidConfig holds the settings applied by MermaidOption values.
+type mermaidConfig struct {
+	label        func(*Node) string
+	highlightMax bool
+	direction    string
+}
+
+// MermaidOption customises the output of ExportMermaid.
+type MermaidOption func(*mermaidConfig)
+
+// WithMermaidLabel sets the function used to label each node.
+// By default a node is labelled with its value.
+func WithMermaidLabel(label func(*Node) string) MermaidOption {
+	return func(c *mermaidConfig) { c.label = label }
+}
+
+// WithMermaidHighlightMax styles the nodes holding their level's
+// maximum, the same values rowWiseMax reports.
+func WithMermaidHighlightMax() MermaidOption {
+	return func(c *mermaidConfig) { c.highlightMax = true }
+}
+
+// WithMermaidDirection sets the flowchart direction: "TD" (top-down,
+// the default), "BT", "LR" or "RL".
+func WithMermaidDirection(dir string) MermaidOption {
+	return func(c *mermaidConfig) { c.direction = dir }
+}
+
+// mermaidEscape makes s safe inside a quoted Mermaid label.
+var mermaidEscape = strings.NewReplacer(`"`, "#quot;", "\n", "<br>")
+
+// ExportMermaid writes the tree as a Mermaid flowchart to w, ready to
+// embed in a Markdown ```mermaid block. Like ExportDOT, nodes are named
+// n0, n1, ... in level order and edges carry an L or R label. A nil tree
+// produces a flowchart with no nodes.
+func ExportMermaid(root *Node, w io.Writer, opts ...MermaidOption) error {
+	cfg := mermaidConfig{
+		label:     func(n *Node) string { return strconv.Itoa(n.Val) },
+		direction: "TD",
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "flowchart %s\n", cfg.direction)
+
+	ids := map[*Node]int{}
+	var highlighted []string
+	walkLevels(root, func(_ int, level []*Node) bool {
+		levelMax := maxOfNodes(level)
+		for _, node := range level {
+			id := len(ids)
+			ids[node] = id
+			fmt.Fprintf(bw, "    n%d((\"%s\"))\n", id, mermaidEscape.Replace(cfg.label(node)))
+			if cfg.highlightMax && node.Val == levelMax {
+				highlighted = append(highlighted, "n"+strconv.Itoa(id))
+			}
+		}
+		return true
+	})
+
+	walkLevels(root, func(_ int, level []*Node) bool {
+		for _, node := range level {
+			if node.Left != nil {
+				fmt.Fprintf(bw, "    n%d -->|L| n%d\n", ids[node], ids[node.Left])
+			}
+			if node.Right != nil {
+				fmt.Fprintf(bw, "    n%d -->|R| n%d\n", ids[node], ids[node.Right])
+			}
+		}
+		return true
+	})
+
+	if len(highlighted) > 0 {
+		fmt.Fprintln(bw, "    classDef levelMax fill:#ffd700,stroke:#333")
+		fmt.Fprintf(bw, "    class %s levelMax\n", strings.Join(highlighted, ","))
+	}
+	return bw.Flush()
+}
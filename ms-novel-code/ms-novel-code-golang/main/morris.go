@@ -0,0 +1,62 @@
+package core
+
+// morrisReduce is the StrategyMorris implementation of RowWiseReduce.
+//
+// Morris in-order traversal threads each node's in-order predecessor
+// back to it instead of keeping a stack. The depth is tracked alongside:
+// descending to a child adds one, and returning along a thread from the
+// predecessor subtracts the length of the path that was walked to find
+// it. In-order visits the nodes of any single level left-to-right, so
+// folding into res[depth] applies combine in the same order as the BFS.
+func morrisReduce[T any](root *TreeNode[T], combine func(a, b T) T) []T {
+	res := []T{}
+	var seen []bool // whether res[depth] holds a value yet
+
+	visit := func(node *TreeNode[T], depth int) {
+		for len(res) <= depth {
+			var zero T
+			res = append(res, zero)
+			seen = append(seen, false)
+		}
+		if seen[depth] {
+			res[depth] = combine(res[depth], node.Val)
+		} else {
+			res[depth], seen[depth] = node.Val, true
+		}
+	}
+
+	cur, depth := root, 0
+	for cur != nil {
+		if cur.Left == nil {
+			visit(cur, depth)
+			cur = cur.Right
+			depth++
+			continue
+		}
+
+		// Find the in-order predecessor: right-most node of the left subtree.
+		pred, steps := cur.Left, 1
+		for pred.Right != nil && pred.Right != cur {
+			pred = pred.Right
+			steps++
+		}
+
+		if pred.Right == nil {
+			// First visit: thread the predecessor back to cur and descend.
+			pred.Right = cur
+			cur = cur.Left
+			depth++
+			continue
+		}
+
+		// Second visit, reached through the thread: the depth counter was
+		// bumped past pred on the way here, so unwind to cur's depth.
+		pred.Right = nil
+		depth -= steps + 1
+		visit(cur, depth)
+		cur = cur.Right
+		depth++
+	}
+
+	return res
+}
@@ -0,0 +1,154 @@
+package core
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// MarshalMsgpack encodes the tree as MessagePack: a flat array holding,
+// for each node in preorder, its child flags (bit 0: has left child,
+// bit 1: has right child) followed by its value, both as integers in
+// their smallest MessagePack form. Unlike JSON the size does not grow
+// with depth, and small values take a single byte. The method matches
+// the Marshaler interface of the common Go MessagePack libraries, so a
+// Tree can be embedded in their messages without this package depending
+// on them.
+func (t Tree) MarshalMsgpack() ([]byte, error) {
+	nodes := 0
+	eachNode(t.Root, func(*Node) { nodes++ })
+
+	buf := msgpackAppendArray(nil, 2*nodes)
+	eachNode(t.Root, func(n *Node) {
+		buf = msgpackAppendInt(buf, int64(childFlags(n)))
+		buf = msgpackAppendInt(buf, int64(n.Val))
+	})
+	return buf, nil
+}
+
+// UnmarshalMsgpack decodes the format written by MarshalMsgpack,
+// accepting any MessagePack integer encoding. It replaces t.Root with
+// the decoded tree and leaves t untouched on error, which wraps
+// ErrBinaryFormat.
+func (t *Tree) UnmarshalMsgpack(data []byte) error {
+	count, data, err := msgpackArray(data)
+	if err != nil {
+		return err
+	}
+	// Every integer takes at least one byte, which bounds a hostile count.
+	if count%2 != 0 || count > uint64(len(data)) {
+		return fmt.Errorf("%w: bad array length %d", ErrBinaryFormat, count)
+	}
+
+	var b preorderBuilder
+	for i := uint64(0); i < count/2; i++ {
+		if b.done() {
+			return fmt.Errorf("%w: node %d has no parent slot", ErrBinaryFormat, i)
+		}
+		var flags, v int64
+		if flags, data, err = msgpackInt(data); err != nil {
+			return fmt.Errorf("%w: node %d", err, i)
+		}
+		if flags&^int64(binaryHasLeft|binaryHasRight) != 0 {
+			return fmt.Errorf("%w: node %d has unknown flags %#x", ErrBinaryFormat, i, flags)
+		}
+		if v, data, err = msgpackInt(data); err != nil {
+			return fmt.Errorf("%w: node %d", err, i)
+		}
+		b.add(byte(flags), int(v))
+	}
+	if b.pending() > 0 {
+		return fmt.Errorf("%w: %d children announced but missing", ErrBinaryFormat, b.pending())
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrBinaryFormat, len(data))
+	}
+
+	t.Root = b.root
+	return nil
+}
+
+func msgpackAppendArray(buf []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(buf, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, 0xdc), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(buf, 0xdd), uint32(n))
+	}
+}
+
+func msgpackAppendInt(buf []byte, v int64) []byte {
+	switch {
+	case v >= 0 && v <= math.MaxInt8:
+		return append(buf, byte(v)) // positive fixint
+	case v >= -32 && v < 0:
+		return append(buf, byte(v)) // negative fixint
+	case v >= math.MinInt8 && v <= math.MaxInt8:
+		return append(buf, 0xd0, byte(v))
+	case v >= math.MinInt16 && v <= math.MaxInt16:
+		return binary.BigEndian.AppendUint16(append(buf, 0xd1), uint16(v))
+	case v >= math.MinInt32 && v <= math.MaxInt32:
+		return binary.BigEndian.AppendUint32(append(buf, 0xd2), uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, 0xd3), uint64(v))
+	}
+}
+
+// msgpackArray reads an array header, returning its length and the rest
+// of data.
+func msgpackArray(data []byte) (uint64, []byte, error) {
+	if len(data) == 0 {
+		return 0, nil, fmt.Errorf("%w: empty input", ErrBinaryFormat)
+	}
+	switch b := data[0]; {
+	case b&0xf0 == 0x90:
+		return uint64(b & 0x0f), data[1:], nil
+	case b == 0xdc && len(data) >= 3:
+		return uint64(binary.BigEndian.Uint16(data[1:])), data[3:], nil
+	case b == 0xdd && len(data) >= 5:
+		return uint64(binary.BigEndian.Uint32(data[1:])), data[5:], nil
+	}
+	return 0, nil, fmt.Errorf("%w: expected an array", ErrBinaryFormat)
+}
+
+// msgpackInt reads an integer in any of its encodings, returning it and
+// the rest of data.
+func msgpackInt(data []byte) (int64, []byte, error) {
+	if len(data) == 0 {
+		return 0, nil, fmt.Errorf("%w: truncated", ErrBinaryFormat)
+	}
+	b, rest := data[0], data[1:]
+	if b <= 0x7f || b >= 0xe0 {
+		return int64(int8(b)), rest, nil // positive and negative fixint
+	}
+	var size int
+	switch b {
+	case 0xcc, 0xd0: // uint8, int8
+		size = 1
+	case 0xcd, 0xd1:
+		size = 2
+	case 0xce, 0xd2:
+		size = 4
+	case 0xcf, 0xd3:
+		size = 8
+	default:
+		return 0, nil, fmt.Errorf("%w: expected an integer, got type %#x", ErrBinaryFormat, b)
+	}
+	if len(rest) < size {
+		return 0, nil, fmt.Errorf("%w: truncated", ErrBinaryFormat)
+	}
+	var u uint64
+	for _, c := range rest[:size] {
+		u = u<<8 | uint64(c)
+	}
+	if b >= 0xd0 { // signed: sign-extend from size bytes
+		shift := 64 - 8*size
+		return int64(u<<shift) >> shift, rest[size:], nil
+	}
+	if u > math.MaxInt64 {
+		return 0, nil, fmt.Errorf("%w: integer %d overflows int64", ErrBinaryFormat, u)
+	}
+	return int64(u), rest[size:], nil
+}
@@ -0,0 +1,137 @@
+package core
+
+import "cmp"
+
+// NaryTreeNode is a tree node with any number of ordered children.
+type NaryTreeNode[T any] struct {
+	Val      T
+	Children []*NaryTreeNode[T]
+}
+
+// NaryNode is the int-valued n-ary node, the counterpart of Node.
+type NaryNode = NaryTreeNode[int]
+
+// NaryLevelOrder returns the tree's values level by level, top-to-bottom,
+// each level listed left-to-right. Nil entries in Children are skipped.
+// The returned slice is never nil, even for an empty tree.
+func NaryLevelOrder[T any](root *NaryTreeNode[T]) [][]T {
+	res := [][]T{}
+	walkNaryLevels(root, func(_ int, level []*NaryTreeNode[T]) bool {
+		vals := make([]T, len(level))
+		for i, node := range level {
+			vals[i] = node.Val
+		}
+		res = append(res, vals)
+		return true
+	})
+	return res
+}
+
+// NaryRowWiseReduce is RowWiseReduce for n-ary trees: it folds each
+// level's values left-to-right with combine.
+// The returned slice is never nil, even for an empty tree.
+func NaryRowWiseReduce[T any](root *NaryTreeNode[T], combine func(a, b T) T) []T {
+	res := []T{}
+	walkNaryLevels(root, func(_ int, level []*NaryTreeNode[T]) bool {
+		acc := level[0].Val
+		for _, node := range level[1:] {
+			acc = combine(acc, node.Val)
+		}
+		res = append(res, acc)
+		return true
+	})
+	return res
+}
+
+// NaryRowWiseMax returns the maximum value at each level of an n-ary
+// tree, top-to-bottom, like rowWiseMax does for binary trees.
+// The returned slice is never nil, even for an empty tree.
+func NaryRowWiseMax[T cmp.Ordered](root *NaryTreeNode[T]) []T {
+	return NaryRowWiseReduce(root, func(a, b T) T { return max(a, b) })
+}
+
+// walkNaryLevels is walkLevels for n-ary trees.
+func walkNaryLevels[T any](root *NaryTreeNode[T], visit func(depth int, level []*NaryTreeNode[T]) bool) {
+	if root == nil {
+		return
+	}
+
+	level := []*NaryTreeNode[T]{root}
+	var next []*NaryTreeNode[T]
+
+	for depth := 0; len(level) > 0; depth++ {
+		if !visit(depth, level) {
+			return
+		}
+
+		next = next[:0]
+		for _, node := range level {
+			for _, child := range node.Children {
+				if child != nil {
+					next = append(next, child)
+				}
+			}
+		}
+		level, next = next, level
+	}
+}
+
+// ToLeftChildRightSibling converts an n-ary tree to its binary
+// left-child right-sibling form: each node's Left is its first child and
+// its Right is its next sibling, so binary algorithms can run on n-ary
+// data. Nil entries in Children are skipped. The root has no siblings,
+// so its Right is always nil.
+func ToLeftChildRightSibling[T any](root *NaryTreeNode[T]) *TreeNode[T] {
+	if root == nil {
+		return nil
+	}
+	type pair struct {
+		src *NaryTreeNode[T]
+		dst *TreeNode[T]
+	}
+
+	out := &TreeNode[T]{Val: root.Val}
+	stack := []pair{{root, out}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		link := &p.dst.Left
+		for _, child := range p.src.Children {
+			if child == nil {
+				continue
+			}
+			node := &TreeNode[T]{Val: child.Val}
+			*link = node
+			link = &node.Right
+			stack = append(stack, pair{child, node})
+		}
+	}
+	return out
+}
+
+// FromLeftChildRightSibling is the inverse of ToLeftChildRightSibling.
+// Any Right subtree of the root is ignored, since a root has no siblings.
+func FromLeftChildRightSibling[T any](root *TreeNode[T]) *NaryTreeNode[T] {
+	if root == nil {
+		return nil
+	}
+	type pair struct {
+		src *TreeNode[T]
+		dst *NaryTreeNode[T]
+	}
+
+	out := &NaryTreeNode[T]{Val: root.Val}
+	stack := []pair{{root, out}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for child := p.src.Left; child != nil; child = child.Right {
+			node := &NaryTreeNode[T]{Val: child.Val}
+			p.dst.Children = append(p.dst.Children, node)
+			stack = append(stack, pair{child, node})
+		}
+	}
+	return out
+}
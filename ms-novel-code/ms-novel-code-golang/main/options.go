@@ -0,0 +1,162 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Strategy selects how the row-wise functions walk the tree.
+type Strategy int
+
+const (
+	// StrategyBFS walks the tree level by level, breadth-first.
+	// Extra memory is proportional to the widest level.
+	StrategyBFS Strategy = iota
+	// StrategyMorris walks the tree with Morris threading, using O(1)
+	// extra memory beyond the per-level results. It temporarily rewires
+	// Right pointers while it runs, so the tree must not be read or
+	// modified concurrently; the tree is fully restored on return.
+	StrategyMorris
+)
+
+// traversalConfig holds the settings applied by TraversalOption values.
+type traversalConfig struct {
+	strategy Strategy
+	maxDepth int // -1 when unlimited
+	maxNodes int // -1 when unlimited
+	timeout  time.Duration
+	validate bool
+}
+
+// TraversalOption customises the row-wise traversal functions.
+type TraversalOption func(*traversalConfig)
+
+// WithStrategy selects the traversal strategy (StrategyBFS by default).
+func WithStrategy(s Strategy) TraversalOption {
+	return func(c *traversalConfig) { c.strategy = s }
+}
+
+// WithMaxDepth stops the traversal before it visits a level deeper
+// than depth (the root is at depth 0). Setting any limit makes the
+// traversal walk breadth-first, whatever WithStrategy says, since a
+// Morris walk cannot stop early without leaving the tree threaded.
+func WithMaxDepth(depth int) TraversalOption {
+	return func(c *traversalConfig) { c.maxDepth = max(depth, 0) }
+}
+
+// WithMaxNodes stops the traversal before it visits a level that would
+// take the total past n nodes. See WithMaxDepth.
+func WithMaxNodes(n int) TraversalOption {
+	return func(c *traversalConfig) { c.maxNodes = max(n, 0) }
+}
+
+// WithTimeout stops the traversal once d has elapsed since it started.
+// The clock is checked between levels. See WithMaxDepth.
+func WithTimeout(d time.Duration) TraversalOption {
+	return func(c *traversalConfig) { c.timeout = d }
+}
+
+// WithCycleCheck makes the traversal first check with ValidateTree that
+// the structure really is a tree, so a node linking back to an ancestor
+// or shared between parents fails with ErrCycle or ErrSharedNode instead
+// of being walked forever or twice. The check costs O(n) extra memory.
+func WithCycleCheck() TraversalOption {
+	return func(c *traversalConfig) { c.validate = true }
+}
+
+func newTraversalConfig(opts []TraversalOption) traversalConfig {
+	cfg := traversalConfig{maxDepth: -1, maxNodes: -1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// ErrNilRoot is returned by the error-returning traversals (RowWiseMaxE,
+// RowWiseReduceE, ...) for an empty tree, which the other variants
+// silently treat as having no levels.
+var ErrNilRoot = errors.New("core: nil root")
+
+// limited reports whether any traversal limit is set.
+func (c traversalConfig) limited() bool {
+	return c.maxDepth >= 0 || c.maxNodes >= 0 || c.timeout > 0
+}
+
+// ErrLimitExceeded matches every LimitError.
+var ErrLimitExceeded = errors.New("core: traversal limit exceeded")
+
+// LimitKind says which traversal limit was exceeded.
+type LimitKind int
+
+const (
+	// LimitDepth: a level was deeper than WithMaxDepth allows.
+	LimitDepth LimitKind = iota
+	// LimitNodes: the node count would have passed WithMaxNodes.
+	LimitNodes
+	// LimitTimeout: the WithTimeout duration elapsed.
+	LimitTimeout
+)
+
+// String returns "depth", "nodes" or "timeout".
+func (k LimitKind) String() string {
+	switch k {
+	case LimitDepth:
+		return "depth"
+	case LimitNodes:
+		return "nodes"
+	case LimitTimeout:
+		return "timeout"
+	}
+	return fmt.Sprintf("LimitKind(%d)", int(k))
+}
+
+// LimitError reports that a traversal stopped at a limit set by
+// WithMaxDepth, WithMaxNodes or WithTimeout, and how far it got.
+// errors.Is(err, ErrLimitExceeded) matches it.
+type LimitError struct {
+	Kind    LimitKind     // which limit was hit
+	Depth   int           // levels completed
+	Nodes   int           // nodes visited
+	Elapsed time.Duration // time spent
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("core: traversal %s limit exceeded after %d levels, %d nodes, %v",
+		e.Kind, e.Depth, e.Nodes, e.Elapsed)
+}
+
+// Is makes errors.Is(err, ErrLimitExceeded) true for a LimitError.
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// limiter enforces a traversalConfig's limits on a level-order walk.
+type limiter struct {
+	cfg   traversalConfig
+	start time.Time
+	nodes int
+}
+
+func newLimiter(cfg traversalConfig) *limiter {
+	return &limiter{cfg: cfg, start: time.Now()}
+}
+
+// admit checks the level about to be visited against the limits,
+// counting its nodes if it is allowed through.
+func (l *limiter) admit(depth, width int) error {
+	var kind LimitKind
+	elapsed := time.Since(l.start)
+	switch {
+	case l.cfg.maxDepth >= 0 && depth > l.cfg.maxDepth:
+		kind = LimitDepth
+	case l.cfg.maxNodes >= 0 && l.nodes+width > l.cfg.maxNodes:
+		kind = LimitNodes
+	case l.cfg.timeout > 0 && elapsed > l.cfg.timeout:
+		kind = LimitTimeout
+	default:
+		l.nodes += width
+		return nil
+	}
+	return &LimitError{Kind: kind, Depth: depth, Nodes: l.nodes, Elapsed: elapsed}
+}
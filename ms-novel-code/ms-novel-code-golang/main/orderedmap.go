@@ -0,0 +1,118 @@
+package core
+
+import (
+	"cmp"
+	"iter"
+)
+
+// OrderedMap is the interface shared by the package's key/value trees
+// (BTree, SkipList and SplayTree), so applications can swap backends
+// without code changes. Keys are unique and kept in ascending order.
+// The int-set trees (BST, AVL) implement SearchTree, whose Min and Max
+// clash with OrderedMap's; NewBSTMap and NewAVLMap adapt them.
+type OrderedMap[K cmp.Ordered, V any] interface {
+	// Get returns the value stored under key, or false if key is absent.
+	Get(key K) (V, bool)
+	// Put stores val under key, replacing any previous value, and
+	// reports whether key was not already present.
+	Put(key K, val V) bool
+	// Delete removes key and reports whether it was present.
+	Delete(key K) bool
+	// Min returns the smallest key and its value, or false if empty.
+	Min() (K, V, bool)
+	// Max returns the largest key and its value, or false if empty.
+	Max() (K, V, bool)
+	// Range iterates over the keys in [lo, hi] and their values in
+	// ascending key order. The map must not be modified meanwhile.
+	Range(lo, hi K) iter.Seq2[K, V]
+	// Len returns the number of keys stored.
+	Len() int
+}
+
+// SearchTreeMap adapts one of the int-set search trees to
+// OrderedMap[int, V]: the tree keeps the keys in order and a hash map
+// holds their values. Every operation, Get included, goes through the
+// tree, so it costs what it costs there and backends compare fairly.
+// Create one with NewAVLMap or NewBSTMap.
+//
+// A SearchTreeMap is not safe for concurrent use.
+type SearchTreeMap[V any] struct {
+	keys      SearchTree
+	vals      map[int]V
+	rangeKeys func(lo, hi int) iter.Seq[int]
+}
+
+var _ OrderedMap[int, int] = (*SearchTreeMap[int])(nil)
+
+// NewAVLMap returns an empty OrderedMap backed by an AVL tree.
+func NewAVLMap[V any]() *SearchTreeMap[V] {
+	t := NewAVL()
+	return &SearchTreeMap[V]{keys: t, vals: map[int]V{}, rangeKeys: t.Range}
+}
+
+// NewBSTMap returns an empty OrderedMap backed by an unbalanced BST, so
+// keys inserted in sorted order make every operation O(n).
+func NewBSTMap[V any]() *SearchTreeMap[V] {
+	t := NewBST()
+	return &SearchTreeMap[V]{keys: t, vals: map[int]V{}, rangeKeys: func(lo, hi int) iter.Seq[int] {
+		return func(yield func(int) bool) {
+			for _, k := range RangeValues(t.Root, lo, hi) {
+				if !yield(k) {
+					return
+				}
+			}
+		}
+	}}
+}
+
+// Get returns the value stored under key.
+func (m *SearchTreeMap[V]) Get(key int) (V, bool) {
+	if !m.keys.Search(key) {
+		var zero V
+		return zero, false
+	}
+	return m.vals[key], true
+}
+
+// Put stores val under key, replacing any previous value, and reports
+// whether key was not already present.
+func (m *SearchTreeMap[V]) Put(key int, val V) bool {
+	m.vals[key] = val
+	return m.keys.Insert(key)
+}
+
+// Delete removes key and reports whether it was present.
+func (m *SearchTreeMap[V]) Delete(key int) bool {
+	delete(m.vals, key)
+	return m.keys.Delete(key)
+}
+
+// Min returns the smallest key and its value, or false if the map is
+// empty.
+func (m *SearchTreeMap[V]) Min() (int, V, bool) {
+	k, ok := m.keys.Min()
+	return k, m.vals[k], ok
+}
+
+// Max returns the largest key and its value, or false if the map is
+// empty.
+func (m *SearchTreeMap[V]) Max() (int, V, bool) {
+	k, ok := m.keys.Max()
+	return k, m.vals[k], ok
+}
+
+// Range returns an iterator over the keys in [lo, hi] and their values,
+// in ascending key order. The map must not be modified during
+// iteration.
+func (m *SearchTreeMap[V]) Range(lo, hi int) iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		for k := range m.rangeKeys(lo, hi) {
+			if !yield(k, m.vals[k]) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of keys stored.
+func (m *SearchTreeMap[V]) Len() int { return m.keys.Len() }
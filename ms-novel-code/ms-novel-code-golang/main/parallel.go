@@ -0,0 +1,95 @@
+package core
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// parallelSerialCutoff is the number of nodes ParallelRowWiseMax visits
+// serially before fanning out. Trees smaller than this never start a
+// goroutine, since the coordination would cost more than it saves.
+const parallelSerialCutoff = 1 << 12
+
+// ParallelRowWiseMax returns the same per-level maxima as rowWiseMax,
+// spreading the work across up to workers goroutines (GOMAXPROCS when
+// workers <= 0).
+//
+// The top of the tree is walked serially until parallelSerialCutoff
+// nodes have been seen; if the tree ends first the serial result is
+// returned as is. Otherwise every node of the current frontier roots an
+// independent subtree: workers claim subtrees one at a time, compute
+// per-level maxima relative to the frontier depth, and the partial
+// results are merged level by level at the end.
+// The returned slice is never nil, even for an empty tree.
+func ParallelRowWiseMax(root *Node, workers int) []int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	res := []int{}
+	var frontier []*Node
+	visited := 0
+	walkLevels(root, func(_ int, level []*Node) bool {
+		if workers > 1 && visited >= parallelSerialCutoff {
+			frontier = append(frontier, level...)
+			return false
+		}
+		res = append(res, maxOfNodes(level))
+		visited += len(level)
+		return true
+	})
+	if len(frontier) == 0 {
+		return res
+	}
+
+	partials := make([][]int, workers)
+	var (
+		next atomic.Int64
+		wg   sync.WaitGroup
+	)
+	for w := range partials {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var local []int
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(frontier) {
+					break
+				}
+				local = mergeMaxLevels(local, RowWiseReduce(frontier[i], func(a, b int) int { return max(a, b) }))
+			}
+			partials[w] = local
+		}()
+	}
+	wg.Wait()
+
+	var deep []int
+	for _, p := range partials {
+		deep = mergeMaxLevels(deep, p)
+	}
+	return append(res, deep...)
+}
+
+// maxOfNodes returns the largest value among a non-empty set of nodes.
+func maxOfNodes(nodes []*Node) int {
+	best := nodes[0].Val
+	for _, node := range nodes[1:] {
+		best = max(best, node.Val)
+	}
+	return best
+}
+
+// mergeMaxLevels folds src into dst level by level, keeping the larger
+// value where both have a level and extending dst where src is deeper.
+func mergeMaxLevels(dst, src []int) []int {
+	for i, v := range src {
+		if i < len(dst) {
+			dst[i] = max(dst[i], v)
+		} else {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,136 @@
+package core
+
+// ParentMap maps every node of a tree to its parent; the root maps to
+// nil. It is built once by AttachParents and then answers upward
+// queries (ancestors, depth, LCA, distance) by walking parent links
+// alone, in O(depth), without searching from the root again.
+//
+// The map describes the tree as it was when it was built; it must be
+// rebuilt after the tree's structure changes.
+type ParentMap[T any] map[*TreeNode[T]]*TreeNode[T]
+
+// AttachParents records the parent of every node of the tree rooted at
+// root in a single pass.
+func AttachParents[T any](root *TreeNode[T]) ParentMap[T] {
+	parents := ParentMap[T]{}
+	if root == nil {
+		return parents
+	}
+	parents[root] = nil
+	eachNode(root, func(n *TreeNode[T]) {
+		if n.Left != nil {
+			parents[n.Left] = n
+		}
+		if n.Right != nil {
+			parents[n.Right] = n
+		}
+	})
+	return parents
+}
+
+// Contains reports whether n is a node of the tree.
+func (p ParentMap[T]) Contains(n *TreeNode[T]) bool {
+	_, ok := p[n]
+	return ok
+}
+
+// Parent returns n's parent, or nil if n is the root or not in the tree.
+func (p ParentMap[T]) Parent(n *TreeNode[T]) *TreeNode[T] {
+	return p[n]
+}
+
+// Ancestors returns n's proper ancestors, nearest first and ending with
+// the root. The returned slice is never nil; it is empty for the root
+// and for nodes not in the tree.
+func (p ParentMap[T]) Ancestors(n *TreeNode[T]) []*TreeNode[T] {
+	res := []*TreeNode[T]{}
+	for a := p[n]; a != nil; a = p[a] {
+		res = append(res, a)
+	}
+	return res
+}
+
+// Depth returns the number of edges between the root and n (0 for the
+// root), or false if n is not in the tree.
+func (p ParentMap[T]) Depth(n *TreeNode[T]) (int, bool) {
+	if !p.Contains(n) {
+		return 0, false
+	}
+	depth := 0
+	for a := p[n]; a != nil; a = p[a] {
+		depth++
+	}
+	return depth, true
+}
+
+// LCA returns the lowest common ancestor of a and b (a node counts as
+// its own ancestor), or nil if either is not in the tree.
+func (p ParentMap[T]) LCA(a, b *TreeNode[T]) *TreeNode[T] {
+	da, okA := p.Depth(a)
+	db, okB := p.Depth(b)
+	if !okA || !okB {
+		return nil
+	}
+	for ; da > db; da-- {
+		a = p[a]
+	}
+	for ; db > da; db-- {
+		b = p[b]
+	}
+	for a != b {
+		a, b = p[a], p[b]
+	}
+	return a
+}
+
+// Distance returns the number of edges on the path between a and b, or
+// false if either is not in the tree.
+func (p ParentMap[T]) Distance(a, b *TreeNode[T]) (int, bool) {
+	lca := p.LCA(a, b)
+	if lca == nil {
+		return 0, false
+	}
+	da, _ := p.Depth(a)
+	db, _ := p.Depth(b)
+	dl, _ := p.Depth(lca)
+	return da + db - 2*dl, true
+}
+
+// NodesAtDistance returns the nodes exactly k edges away from target,
+// moving both down to children and up to parents, in breadth-first
+// order outward from target. The returned slice is never nil; it is
+// empty if k is negative or target is not in the tree.
+func (p ParentMap[T]) NodesAtDistance(target *TreeNode[T], k int) []*TreeNode[T] {
+	if k < 0 || !p.Contains(target) {
+		return []*TreeNode[T]{}
+	}
+	seen := map[*TreeNode[T]]bool{target: true}
+	frontier := []*TreeNode[T]{target}
+	var next []*TreeNode[T]
+	for ; k > 0 && len(frontier) > 0; k-- {
+		next = next[:0]
+		for _, n := range frontier {
+			for _, nb := range [...]*TreeNode[T]{n.Left, n.Right, p[n]} {
+				if nb != nil && !seen[nb] {
+					seen[nb] = true
+					next = append(next, nb)
+				}
+			}
+		}
+		frontier, next = next, frontier
+	}
+	return append([]*TreeNode[T]{}, frontier...)
+}
+
+// NodesAtDistanceK returns the values of the nodes exactly k edges away
+// from target, as ParentMap.NodesAtDistance. It builds a ParentMap for
+// the call; for repeated queries on one tree, build it once with
+// AttachParents instead.
+func NodesAtDistanceK[T any](root, target *TreeNode[T], k int) []T {
+	nodes := AttachParents(root).NodesAtDistance(target, k)
+	res := make([]T, len(nodes))
+	for i, n := range nodes {
+		res[i] = n.Val
+	}
+	return res
+}
@@ -0,0 +1,111 @@
+package core
+
+import "slices"
+
+// AllRootToLeafPaths returns the values along every path from the root
+// to a leaf, one slice per leaf, left-most leaf first. The returned
+// slice is never nil, even for an empty tree.
+func AllRootToLeafPaths[T any](root *TreeNode[T]) [][]T {
+	res := [][]T{}
+	var path []T // values from the root down to the current node
+	walkOrder(root, OrderPre, func(n *TreeNode[T], depth int) bool {
+		path = append(path[:depth], n.Val)
+		if n.Left == nil && n.Right == nil {
+			res = append(res, append([]T(nil), path...))
+		}
+		return true
+	})
+	return res
+}
+
+// PathToNode returns the nodes from the root down to target, inclusive,
+// and true, or nil and false if target is not part of the tree. Nodes
+// are matched by identity. The walk stops once target is found.
+func PathToNode[T any](root, target *TreeNode[T]) ([]*TreeNode[T], bool) {
+	var path []*TreeNode[T]
+	found := false
+	walkOrder(root, OrderPre, func(n *TreeNode[T], depth int) bool {
+		path = append(path[:depth], n)
+		found = n == target
+		return !found
+	})
+	if !found {
+		return nil, false
+	}
+	return path, true
+}
+
+// PathSumExists reports whether some root-to-leaf path has values
+// summing to target. An empty tree has no paths.
+func PathSumExists(root *Node, target int) bool {
+	var sums []int // sums[d] is the path sum down to depth d
+	found := false
+	walkOrder(root, OrderPre, func(n *Node, depth int) bool {
+		sum := n.Val
+		if depth > 0 {
+			sum += sums[depth-1]
+		}
+		sums = append(sums[:depth], sum)
+		found = n.Left == nil && n.Right == nil && sum == target
+		return !found
+	})
+	return found
+}
+
+// CountPathsWithSum returns how many downward paths (starting at any
+// node and ending at the same node or any descendant) have values
+// summing to target. It runs in O(n) by counting, for each node, the
+// earlier prefix sums on its root path that differ from its own by
+// target.
+func CountPathsWithSum(root *Node, target int) int {
+	// prefix[d] is the sum from the root down to depth d on the current
+	// path; seen counts those sums, plus the empty prefix 0.
+	var prefix []int
+	seen := map[int]int{0: 1}
+	count := 0
+	walkOrder(root, OrderPre, func(n *Node, depth int) bool {
+		// Leaving a branch: forget the prefixes below this depth.
+		for len(prefix) > depth {
+			seen[prefix[len(prefix)-1]]--
+			prefix = prefix[:len(prefix)-1]
+		}
+		sum := n.Val
+		if depth > 0 {
+			sum += prefix[depth-1]
+		}
+		count += seen[sum-target]
+		prefix = append(prefix, sum)
+		seen[sum]++
+		return true
+	})
+	return count
+}
+
+// Ancestors returns target's proper ancestors, nearest first and ending
+// with the root, matching ParentMap.Ancestors. The returned slice is
+// never nil; it is empty for the root and for nodes not in the tree.
+func Ancestors[T any](root, target *TreeNode[T]) []*TreeNode[T] {
+	path, ok := PathToNode(root, target)
+	if !ok {
+		return []*TreeNode[T]{}
+	}
+	path = path[:len(path)-1]
+	slices.Reverse(path)
+	return path
+}
+
+// DepthOf returns target's depth (0 for the root) and true, or false if
+// target is not part of the tree. The depth is also target's index into
+// rowWiseMax's output. The walk is level by level, so it stops as soon
+// as target's level is reached.
+func DepthOf[T any](root, target *TreeNode[T]) (int, bool) {
+	depth, found := 0, false
+	walkLevels(root, func(d int, level []*TreeNode[T]) bool {
+		depth, found = d, slices.Contains(level, target)
+		return !found
+	})
+	if !found {
+		return 0, false
+	}
+	return depth, true
+}
@@ -0,0 +1,139 @@
+package core
+
+// MaxRootToLeafSum returns the largest sum of values along a path from
+// the root down to a leaf, or 0 for an empty tree.
+func MaxRootToLeafSum(root *Node) int {
+	return pathSum(MaxRootToLeafPath(root))
+}
+
+// MaxRootToLeafPath returns the nodes, root first, of the root-to-leaf
+// path with the largest sum. Ties keep the left-most path. The returned
+// slice is never nil, even for an empty tree.
+func MaxRootToLeafPath(root *Node) []*Node {
+	path := []*Node{}
+	if root == nil {
+		return path
+	}
+
+	// down[n] is the best sum of a path from n to a leaf below it, and
+	// next[n] is the child that path continues through.
+	down := map[*Node]int{}
+	next := map[*Node]*Node{}
+	for _, node := range postorderNodes(root) {
+		best, via := 0, (*Node)(nil)
+		for _, child := range []*Node{node.Left, node.Right} {
+			if child != nil && (via == nil || down[child] > best) {
+				best, via = down[child], child
+			}
+		}
+		down[node], next[node] = node.Val+best, via
+	}
+
+	for n := root; n != nil; n = next[n] {
+		path = append(path, n)
+	}
+	return path
+}
+
+// MaxPathSum returns the largest sum of values along any non-empty path
+// between two nodes (a path may bend at most once, through its highest
+// node, and may consist of a single node), or 0 for an empty tree.
+func MaxPathSum(root *Node) int {
+	return pathSum(MaxPathSumPath(root))
+}
+
+// MaxPathSumPath returns the nodes of the path MaxPathSum measures, in
+// order from one end to the other. The returned slice is never nil,
+// even for an empty tree.
+func MaxPathSumPath(root *Node) []*Node {
+	path := []*Node{}
+	if root == nil {
+		return path
+	}
+
+	// gain[n] is the best sum of a downward path starting at n (possibly
+	// just n itself), and next[n] is the child it continues through, or
+	// nil when extending would only lower the sum.
+	gain := map[*Node]int{}
+	next := map[*Node]*Node{}
+	var (
+		apex     *Node
+		bestSum  int
+		haveBest bool
+	)
+	for _, node := range postorderNodes(root) {
+		left, right := 0, 0
+		if node.Left != nil {
+			left = max(gain[node.Left], 0)
+		}
+		if node.Right != nil {
+			right = max(gain[node.Right], 0)
+		}
+
+		if sum := node.Val + left + right; !haveBest || sum > bestSum {
+			apex, bestSum, haveBest = node, sum, true
+		}
+
+		gain[node] = node.Val
+		switch {
+		case left > 0 && left >= right:
+			gain[node] += left
+			next[node] = node.Left
+		case right > 0:
+			gain[node] += right
+			next[node] = node.Right
+		}
+	}
+
+	// Left arm bottom-up, then the apex, then the right arm top-down.
+	if apex.Left != nil && gain[apex.Left] > 0 {
+		for n := apex.Left; n != nil; n = next[n] {
+			path = append(path, n)
+		}
+		for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+			path[i], path[j] = path[j], path[i]
+		}
+	}
+	path = append(path, apex)
+	if apex.Right != nil && gain[apex.Right] > 0 {
+		for n := apex.Right; n != nil; n = next[n] {
+			path = append(path, n)
+		}
+	}
+	return path
+}
+
+// pathSum adds up the values of the given nodes.
+func pathSum(path []*Node) int {
+	sum := 0
+	for _, n := range path {
+		sum += n.Val
+	}
+	return sum
+}
+
+// postorderNodes lists the tree's nodes so that every node comes after
+// its descendants. It uses an explicit stack, so deep trees are safe.
+func postorderNodes[T any](root *TreeNode[T]) []*TreeNode[T] {
+	var order []*TreeNode[T]
+	if root == nil {
+		return order
+	}
+	// Root-right-left preorder, reversed, is left-right-root postorder.
+	stack := []*TreeNode[T]{root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		order = append(order, node)
+		if node.Left != nil {
+			stack = append(stack, node.Left)
+		}
+		if node.Right != nil {
+			stack = append(stack, node.Right)
+		}
+	}
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
@@ -0,0 +1,186 @@
+package core
+
+// PersistentBST is an immutable binary search tree. Insert and Delete
+// return a new tree and leave the receiver untouched; the two share every
+// subtree off the modified path, so each update copies only O(height)
+// nodes. Old values therefore act as cheap snapshots.
+//
+// The zero value is an empty tree. PersistentBST values are safe to
+// share between goroutines because they are never modified.
+type PersistentBST struct {
+	root *Node
+	size int
+}
+
+// NewPersistentBST returns a PersistentBST holding vals.
+func NewPersistentBST(vals ...int) PersistentBST {
+	var t PersistentBST
+	for _, v := range vals {
+		t = t.Insert(v)
+	}
+	return t
+}
+
+// Insert returns a tree that also holds v. If v is already present, t
+// itself is returned.
+func (t PersistentBST) Insert(v int) PersistentBST {
+	path, node := t.path(v)
+	if node != nil {
+		return t
+	}
+	return PersistentBST{root: copyPath(path, v, &Node{Val: v}), size: t.size + 1}
+}
+
+// Delete returns a tree without v. If v is absent, t itself is returned.
+// A node with two children is replaced by a copy holding its in-order
+// successor.
+func (t PersistentBST) Delete(v int) PersistentBST {
+	path, node := t.path(v)
+	if node == nil {
+		return t
+	}
+
+	var sub *Node
+	switch {
+	case node.Left == nil:
+		sub = node.Right
+	case node.Right == nil:
+		sub = node.Left
+	default:
+		// Remove the successor (left-most node of the right subtree)
+		// from a copy of the right subtree, then hoist its value.
+		var right []*Node
+		succ := node.Right
+		for succ.Left != nil {
+			right = append(right, succ)
+			succ = succ.Left
+		}
+		sub = &Node{
+			Val:   succ.Val,
+			Left:  node.Left,
+			Right: copyPath(right, succ.Val, succ.Right),
+		}
+	}
+	return PersistentBST{root: copyPath(path, v, sub), size: t.size - 1}
+}
+
+// Search reports whether v is present.
+func (t PersistentBST) Search(v int) bool {
+	_, node := t.path(v)
+	return node != nil
+}
+
+// InOrder returns all values in ascending order.
+func (t PersistentBST) InOrder() []int {
+	bst := BST{Root: t.root, size: t.size}
+	return bst.InOrder()
+}
+
+// Len returns the number of values stored.
+func (t PersistentBST) Len() int {
+	return t.size
+}
+
+// Root returns the tree's root so the rest of the package (rowWiseMax,
+// LevelStats, ...) can run on it. The nodes may be shared with other
+// versions and must not be modified.
+func (t PersistentBST) Root() *Node {
+	return t.root
+}
+
+// path returns the nodes visited while searching for v, top-down and
+// excluding the match, together with the node holding v or nil.
+func (t PersistentBST) path(v int) ([]*Node, *Node) {
+	var path []*Node
+	node := t.root
+	for node != nil && node.Val != v {
+		path = append(path, node)
+		if v < node.Val {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return path, node
+}
+
+// copyPath copies the nodes of path, bottom-up, replacing the child
+// that leads towards v in the last node with sub, and returns the new
+// top of the path (sub itself if path is empty). Siblings off the path
+// are shared, not copied.
+func copyPath(path []*Node, v int, sub *Node) *Node {
+	for i := len(path) - 1; i >= 0; i-- {
+		cp := *path[i]
+		if v < cp.Val {
+			cp.Left = sub
+		} else {
+			cp.Right = sub
+		}
+		sub = &cp
+	}
+	return sub
+}
+
+// VersionedBST records every version of a PersistentBST so callers can
+// inspect or roll back to any earlier state. Version 0 is the empty tree
+// and each successful Insert or Delete adds one version.
+//
+// A VersionedBST is not safe for concurrent use, but the PersistentBST
+// values it hands out are.
+type VersionedBST struct {
+	versions []PersistentBST
+}
+
+// NewVersionedBST returns a VersionedBST at version 0, the empty tree.
+func NewVersionedBST() *VersionedBST {
+	return &VersionedBST{versions: []PersistentBST{{}}}
+}
+
+// Current returns the latest version of the tree.
+func (h *VersionedBST) Current() PersistentBST {
+	return h.versions[len(h.versions)-1]
+}
+
+// Version returns the number of the latest version.
+func (h *VersionedBST) Version() int {
+	return len(h.versions) - 1
+}
+
+// At returns the tree as of version, or false if no such version exists.
+func (h *VersionedBST) At(version int) (PersistentBST, bool) {
+	if version < 0 || version >= len(h.versions) {
+		return PersistentBST{}, false
+	}
+	return h.versions[version], true
+}
+
+// Insert adds v as a new version and reports whether it was not already
+// present. No version is recorded if it was.
+func (h *VersionedBST) Insert(v int) bool {
+	return h.commit(h.Current().Insert(v))
+}
+
+// Delete removes v as a new version and reports whether it was present.
+// No version is recorded if it was not.
+func (h *VersionedBST) Delete(v int) bool {
+	return h.commit(h.Current().Delete(v))
+}
+
+// Rollback makes version the latest one, discarding every later version,
+// and reports whether version exists.
+func (h *VersionedBST) Rollback(version int) bool {
+	if version < 0 || version >= len(h.versions) {
+		return false
+	}
+	clear(h.versions[version+1:])
+	h.versions = h.versions[:version+1]
+	return true
+}
+
+func (h *VersionedBST) commit(t PersistentBST) bool {
+	if t.root == h.Current().root {
+		return false
+	}
+	h.versions = append(h.versions, t)
+	return true
+}
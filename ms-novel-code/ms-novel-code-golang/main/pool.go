@@ -0,0 +1,68 @@
+package core
+
+import "sync"
+
+// NodePool recycles Nodes to cut allocation and GC pressure in code that
+// builds and discards many short-lived trees. The zero value is ready to
+// use and a NodePool is safe for concurrent use.
+//
+// A released node must not be used again: it may be handed out by a
+// later AcquireNode at any time. Releasing a node that is still
+// reachable from a live tree corrupts that tree.
+type NodePool struct {
+	pool sync.Pool
+}
+
+// AcquireNode returns a node holding v with no children, reusing a
+// released node when one is available.
+func (p *NodePool) AcquireNode(v int) *Node {
+	if n, ok := p.pool.Get().(*Node); ok {
+		n.Val = v
+		return n
+	}
+	return &Node{Val: v}
+}
+
+// ReleaseNode returns n to the pool. Only n itself is released; its
+// children are left alone. Releasing nil is a no-op.
+func (p *NodePool) ReleaseNode(n *Node) {
+	if n == nil {
+		return
+	}
+	*n = Node{} // drop child pointers so the pool does not pin subtrees
+	p.pool.Put(n)
+}
+
+// FreeTree releases every node of the tree rooted at root. The tree must
+// not be used afterwards, and no node in it may be shared with another
+// tree that is still in use.
+func (p *NodePool) FreeTree(root *Node) {
+	if root == nil {
+		return
+	}
+	stack := []*Node{root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if node.Left != nil {
+			stack = append(stack, node.Left)
+		}
+		if node.Right != nil {
+			stack = append(stack, node.Right)
+		}
+		p.ReleaseNode(node)
+	}
+}
+
+// defaultNodePool backs the package-level pool functions.
+var defaultNodePool NodePool
+
+// AcquireNode returns a node holding v from the package's default pool.
+func AcquireNode(v int) *Node { return defaultNodePool.AcquireNode(v) }
+
+// ReleaseNode returns n to the package's default pool.
+func ReleaseNode(n *Node) { defaultNodePool.ReleaseNode(n) }
+
+// FreeTree returns every node of root to the package's default pool.
+func FreeTree(root *Node) { defaultNodePool.FreeTree(root) }
@@ -0,0 +1,147 @@
+package core
+
+// PQHandle identifies an element pushed onto a PriorityQueue, so it can
+// later be re-prioritised or removed.
+type PQHandle[T any] struct {
+	val   T
+	index int // position in the queue's heap, or -1 once removed
+}
+
+// Value returns the element the handle refers to.
+func (h *PQHandle[T]) Value() T {
+	return h.val
+}
+
+// PriorityQueue is an array-backed binary heap that hands out a handle
+// for every element, supporting decrease-key and removal of arbitrary
+// elements in O(log n), without container/heap's interface. Like Heap,
+// the element for which less reports true against every other element
+// is at the top.
+type PriorityQueue[T any] struct {
+	items []*PQHandle[T]
+	less  func(a, b T) bool
+}
+
+// NewPriorityQueue returns an empty queue ordered by less.
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// Len returns the number of elements in the queue.
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.items)
+}
+
+// Push adds v in O(log n) and returns its handle.
+func (q *PriorityQueue[T]) Push(v T) *PQHandle[T] {
+	h := &PQHandle[T]{val: v, index: len(q.items)}
+	q.items = append(q.items, h)
+	q.up(h.index)
+	return h
+}
+
+// Peek returns the top element without removing it, or false if the
+// queue is empty.
+func (q *PriorityQueue[T]) Peek() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.items[0].val, true
+}
+
+// Pop removes and returns the top element in O(log n), or false if the
+// queue is empty.
+func (q *PriorityQueue[T]) Pop() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.removeAt(0), true
+}
+
+// Contains reports whether h refers to an element still in the queue.
+func (q *PriorityQueue[T]) Contains(h *PQHandle[T]) bool {
+	return h != nil && h.index >= 0 && h.index < len(q.items) && q.items[h.index] == h
+}
+
+// UpdatePriority replaces h's element with v and restores heap order in
+// O(log n). It handles moves in either direction, so it serves as
+// decrease-key and increase-key alike. It reports false, changing
+// nothing, if h is not in the queue.
+func (q *PriorityQueue[T]) UpdatePriority(h *PQHandle[T], v T) bool {
+	if !q.Contains(h) {
+		return false
+	}
+	h.val = v
+	q.fix(h.index)
+	return true
+}
+
+// Remove deletes h's element from the queue in O(log n) and reports
+// whether it was there.
+func (q *PriorityQueue[T]) Remove(h *PQHandle[T]) bool {
+	if !q.Contains(h) {
+		return false
+	}
+	q.removeAt(h.index)
+	return true
+}
+
+// removeAt moves the last element into position i, re-sifts it, and
+// returns the element that was at i.
+func (q *PriorityQueue[T]) removeAt(i int) T {
+	h := q.items[i]
+	last := len(q.items) - 1
+	q.swap(i, last)
+	q.items[last] = nil
+	q.items = q.items[:last]
+	if i < last {
+		q.fix(i)
+	}
+	h.index = -1
+	return h.val
+}
+
+func (q *PriorityQueue[T]) fix(i int) {
+	if !q.up(i) {
+		q.down(i)
+	}
+}
+
+func (q *PriorityQueue[T]) swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].index, q.items[j].index = i, j
+}
+
+// up sifts element i towards the root and reports whether it moved.
+func (q *PriorityQueue[T]) up(i int) bool {
+	start := i
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(q.items[i].val, q.items[parent].val) {
+			break
+		}
+		q.swap(i, parent)
+		i = parent
+	}
+	return i != start
+}
+
+func (q *PriorityQueue[T]) down(i int) {
+	n := len(q.items)
+	for {
+		best := i
+		if l := 2*i + 1; l < n && q.less(q.items[l].val, q.items[best].val) {
+			best = l
+		}
+		if r := 2*i + 2; r < n && q.less(q.items[r].val, q.items[best].val) {
+			best = r
+		}
+		if best == i {
+			return
+		}
+		q.swap(i, best)
+		i = best
+	}
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+)
+
+// printConfig holds the settings applied by PrintOption values.
+type printConfig struct {
+	maxDepth int // -1 for unlimited
+	format   func(int) string
+}
+
+// PrintOption customises the output of PrintTree.
+type PrintOption func(*printConfig)
+
+// WithPrintMaxDepth stops PrintTree below the given depth (the root is
+// depth 0); deeper subtrees are replaced by a single "..." line.
+func WithPrintMaxDepth(depth int) PrintOption {
+	return func(c *printConfig) { c.maxDepth = depth }
+}
+
+// WithPrintFormat sets how node values are rendered (strconv.Itoa by default).
+func WithPrintFormat(format func(int) string) PrintOption {
+	return func(c *printConfig) { c.format = format }
+}
+
+// PrintTree renders the tree to w as ASCII art in the style of the unix
+// tree command, one node per line, with each child tagged L or R:
+//
+//	10
+//	|-- L: 5
+//	|   |-- L: 8
+//	|   `-- R: 9
+//	`-- R: 4
+//	    `-- R: 15
+//
+// The walk uses an explicit stack, so deep trees are safe. A nil tree
+// prints nothing.
+func PrintTree(root *Node, w io.Writer, opts ...PrintOption) error {
+	cfg := printConfig{maxDepth: -1, format: strconv.Itoa}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if root == nil {
+		return nil
+	}
+
+	type frame struct {
+		node   *Node  // nil for a truncation marker
+		label  string // "L: ", "R: ", or "" for the root
+		prefix string // indentation inherited from the ancestors
+		last   bool   // whether this is its parent's last child
+		depth  int
+	}
+
+	bw := bufio.NewWriter(w)
+	stack := []frame{{node: root}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		childPrefix := ""
+		if f.depth > 0 {
+			branch := "|-- "
+			childPrefix = f.prefix + "|   "
+			if f.last {
+				branch = "`-- "
+				childPrefix = f.prefix + "    "
+			}
+			bw.WriteString(f.prefix + branch)
+		}
+		if f.node == nil {
+			bw.WriteString("...\n")
+			continue
+		}
+		bw.WriteString(f.label + cfg.format(f.node.Val) + "\n")
+
+		if f.node.Left == nil && f.node.Right == nil {
+			continue
+		}
+		if cfg.maxDepth >= 0 && f.depth >= cfg.maxDepth {
+			stack = append(stack, frame{prefix: childPrefix, last: true, depth: f.depth + 1})
+			continue
+		}
+		// Push right first so the left child is printed first.
+		if f.node.Right != nil {
+			stack = append(stack, frame{f.node.Right, "R: ", childPrefix, true, f.depth + 1})
+		}
+		if f.node.Left != nil {
+			stack = append(stack, frame{f.node.Left, "L: ", childPrefix, f.node.Right == nil, f.depth + 1})
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,274 @@
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// DefaultProtoMaxNodes is the node limit used by UnmarshalProto and
+// FromProto unless WithProtoMaxNodes says otherwise.
+const DefaultProtoMaxNodes = 1 << 20
+
+// ErrProtoFormat is returned (wrapped) when a protobuf tree is malformed,
+// does not describe a tree, or exceeds the node limit.
+var ErrProtoFormat = errors.New("core: invalid protobuf tree")
+
+// ProtoTree mirrors the Tree message of proto/tree.proto: a tree
+// flattened into a node list with the root first.
+type ProtoTree struct {
+	Nodes []ProtoNode
+}
+
+// ProtoNode mirrors the Node message of proto/tree.proto. Left and Right
+// are indices into ProtoTree.Nodes, or nil for a missing child.
+type ProtoNode struct {
+	Val         int64
+	Left, Right *uint32
+}
+
+// protoConfig holds the settings applied by ProtoOption values.
+type protoConfig struct {
+	maxNodes int
+}
+
+// ProtoOption customises UnmarshalProto and FromProto.
+type ProtoOption func(*protoConfig)
+
+// WithProtoMaxNodes caps the number of nodes accepted on decode
+// (default DefaultProtoMaxNodes), so a hostile message cannot make the
+// decoder allocate without bound.
+func WithProtoMaxNodes(n int) ProtoOption {
+	return func(c *protoConfig) { c.maxNodes = n }
+}
+
+func newProtoConfig(opts []ProtoOption) protoConfig {
+	cfg := protoConfig{maxNodes: DefaultProtoMaxNodes}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// ToProto flattens the tree into a ProtoTree, numbering the nodes in
+// preorder. A nil tree gives a ProtoTree with no nodes.
+func ToProto(root *Node) *ProtoTree {
+	index := map[*Node]uint32{}
+	pt := &ProtoTree{Nodes: []ProtoNode{}}
+	eachNode(root, func(n *Node) {
+		index[n] = uint32(len(pt.Nodes))
+		pt.Nodes = append(pt.Nodes, ProtoNode{Val: int64(n.Val)})
+	})
+	eachNode(root, func(n *Node) {
+		pn := &pt.Nodes[index[n]]
+		if n.Left != nil {
+			i := index[n.Left]
+			pn.Left = &i
+		}
+		if n.Right != nil {
+			i := index[n.Right]
+			pn.Right = &i
+		}
+	})
+	return pt
+}
+
+// FromProto rebuilds the tree described by pt. It returns an error
+// wrapping ErrProtoFormat if pt has more nodes than the limit, if a
+// child index is out of range or not greater than its parent's, or if a
+// node other than the root is not the child of exactly one node. An
+// empty pt gives a nil tree.
+func FromProto(pt *ProtoTree, opts ...ProtoOption) (*Node, error) {
+	cfg := newProtoConfig(opts)
+	if pt == nil || len(pt.Nodes) == 0 {
+		return nil, nil
+	}
+	if len(pt.Nodes) > cfg.maxNodes {
+		return nil, fmt.Errorf("%w: %d nodes exceed the limit of %d", ErrProtoFormat, len(pt.Nodes), cfg.maxNodes)
+	}
+
+	nodes := make([]Node, len(pt.Nodes))
+	hasParent := make([]bool, len(pt.Nodes))
+	link := func(parent int, child *uint32, slot **Node) error {
+		if child == nil {
+			return nil
+		}
+		c := int(*child)
+		switch {
+		case c <= parent || c >= len(nodes):
+			return fmt.Errorf("%w: node %d has child index %d", ErrProtoFormat, parent, c)
+		case hasParent[c]:
+			return fmt.Errorf("%w: node %d has more than one parent", ErrProtoFormat, c)
+		}
+		hasParent[c] = true
+		*slot = &nodes[c]
+		return nil
+	}
+	for i, pn := range pt.Nodes {
+		nodes[i].Val = int(pn.Val)
+		if err := link(i, pn.Left, &nodes[i].Left); err != nil {
+			return nil, err
+		}
+		if err := link(i, pn.Right, &nodes[i].Right); err != nil {
+			return nil, err
+		}
+	}
+	// Every parent precedes its children, so a node with a parent is
+	// reachable from the root as long as no node but the root is an orphan.
+	for i := 1; i < len(nodes); i++ {
+		if !hasParent[i] {
+			return nil, fmt.Errorf("%w: node %d is not reachable from the root", ErrProtoFormat, i)
+		}
+	}
+	return &nodes[0], nil
+}
+
+// Protobuf wire types and the field numbers of proto/tree.proto.
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+
+	protoTreeNodes = 1
+	protoNodeVal   = 1
+	protoNodeLeft  = 2
+	protoNodeRight = 3
+)
+
+// MarshalProto encodes pt in the protobuf wire format of the Tree
+// message, readable by any protobuf implementation given tree.proto.
+func (pt *ProtoTree) MarshalProto() ([]byte, error) {
+	var buf, node []byte
+	for _, pn := range pt.Nodes {
+		node = node[:0]
+		if pn.Val != 0 {
+			node = binary.AppendUvarint(node, protoNodeVal<<3|protoVarint)
+			node = binary.AppendUvarint(node, uint64(pn.Val<<1)^uint64(pn.Val>>63))
+		}
+		if pn.Left != nil {
+			node = binary.AppendUvarint(node, protoNodeLeft<<3|protoVarint)
+			node = binary.AppendUvarint(node, uint64(*pn.Left))
+		}
+		if pn.Right != nil {
+			node = binary.AppendUvarint(node, protoNodeRight<<3|protoVarint)
+			node = binary.AppendUvarint(node, uint64(*pn.Right))
+		}
+		buf = binary.AppendUvarint(buf, protoTreeNodes<<3|protoBytes)
+		buf = binary.AppendUvarint(buf, uint64(len(node)))
+		buf = append(buf, node...)
+	}
+	return buf, nil
+}
+
+// UnmarshalProto decodes a Tree message in the protobuf wire format.
+// Unknown fields are skipped, as protobuf requires. Decoding stops with
+// an error wrapping ErrProtoFormat on malformed input or once the node
+// limit is exceeded; the indices are checked by FromProto.
+func UnmarshalProto(data []byte, opts ...ProtoOption) (*ProtoTree, error) {
+	cfg := newProtoConfig(opts)
+	pt := &ProtoTree{Nodes: []ProtoNode{}}
+	for len(data) > 0 {
+		field, wire, n := protoTag(data)
+		if n <= 0 {
+			return nil, fmt.Errorf("%w: bad field tag", ErrProtoFormat)
+		}
+		data = data[n:]
+		if field != protoTreeNodes || wire != protoBytes {
+			if n = protoSkip(data, wire); n < 0 {
+				return nil, fmt.Errorf("%w: bad value for field %d", ErrProtoFormat, field)
+			}
+			data = data[n:]
+			continue
+		}
+
+		size, n := binary.Uvarint(data)
+		if n <= 0 || size > uint64(len(data)-n) {
+			return nil, fmt.Errorf("%w: truncated node %d", ErrProtoFormat, len(pt.Nodes))
+		}
+		if len(pt.Nodes) == cfg.maxNodes {
+			return nil, fmt.Errorf("%w: more than %d nodes", ErrProtoFormat, cfg.maxNodes)
+		}
+		pn, err := unmarshalProtoNode(data[n : n+int(size)])
+		if err != nil {
+			return nil, fmt.Errorf("%w: node %d: %s", ErrProtoFormat, len(pt.Nodes), err)
+		}
+		pt.Nodes = append(pt.Nodes, pn)
+		data = data[n+int(size):]
+	}
+	return pt, nil
+}
+
+// unmarshalProtoNode decodes the body of a Node message.
+func unmarshalProtoNode(data []byte) (ProtoNode, error) {
+	var pn ProtoNode
+	for len(data) > 0 {
+		field, wire, n := protoTag(data)
+		if n <= 0 {
+			return pn, errors.New("bad field tag")
+		}
+		data = data[n:]
+		if wire != protoVarint || field < protoNodeVal || field > protoNodeRight {
+			if n = protoSkip(data, wire); n < 0 {
+				return pn, fmt.Errorf("bad value for field %d", field)
+			}
+			data = data[n:]
+			continue
+		}
+
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return pn, fmt.Errorf("bad value for field %d", field)
+		}
+		data = data[n:]
+		if field == protoNodeVal {
+			pn.Val = int64(v>>1) ^ -int64(v&1)
+			continue
+		}
+		if v > math.MaxUint32 {
+			return pn, fmt.Errorf("child index %d out of range", v)
+		}
+		i := uint32(v)
+		if field == protoNodeLeft {
+			pn.Left = &i
+		} else {
+			pn.Right = &i
+		}
+	}
+	return pn, nil
+}
+
+// protoTag reads a field tag, returning the field number, the wire type
+// and the number of bytes read (<= 0 on error).
+func protoTag(data []byte) (field uint64, wire int, n int) {
+	tag, n := binary.Uvarint(data)
+	if n > 0 && tag>>3 == 0 {
+		n = -1 // field number 0 is reserved
+	}
+	return tag >> 3, int(tag & 7), n
+}
+
+// protoSkip returns the length of the value of an unknown field with the
+// given wire type at the start of data, or -1 if it is malformed.
+func protoSkip(data []byte, wire int) int {
+	switch wire {
+	case protoVarint:
+		if _, n := binary.Uvarint(data); n > 0 {
+			return n
+		}
+	case protoFixed64:
+		if len(data) >= 8 {
+			return 8
+		}
+	case protoBytes:
+		if size, n := binary.Uvarint(data); n > 0 && size <= uint64(len(data)-n) {
+			return n + int(size)
+		}
+	case protoFixed32:
+		if len(data) >= 4 {
+			return 4
+		}
+	}
+	return -1
+}
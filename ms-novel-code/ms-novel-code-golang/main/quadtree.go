@@ -0,0 +1,220 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// quadtreeMaxDepth bounds subdivision so that many copies of one point
+// cannot split a region forever; leaves at this depth may exceed the
+// bucket capacity.
+const quadtreeMaxDepth = 32
+
+// Point is a location in the plane.
+type Point struct {
+	X, Y float64
+}
+
+// Rect is an axis-aligned rectangle; its edges are included.
+type Rect struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Contains reports whether p lies inside r or on its edge.
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.MinX && p.X <= r.MaxX && p.Y >= r.MinY && p.Y <= r.MaxY
+}
+
+// Intersects reports whether r and s share at least one point.
+func (r Rect) Intersects(s Rect) bool {
+	return r.MinX <= s.MaxX && s.MinX <= r.MaxX && r.MinY <= s.MaxY && s.MinY <= r.MaxY
+}
+
+// quadNode is a region of a Quadtree: a leaf holding points, or an
+// internal node with four children, indexed by quadrant
+// (bit 0 set: east half; bit 1 set: north half).
+type quadNode struct {
+	bounds   Rect
+	points   []Point
+	children *[4]quadNode
+}
+
+// quadrantNames labels the children of a quadNode by index.
+var quadrantNames = [4]string{"SW", "SE", "NW", "NE"}
+
+// Quadtree is a region quadtree over points in a fixed rectangle. Each
+// leaf holds up to a bucket capacity of points; inserting one more
+// splits the leaf into four equal quadrants. Region queries only visit
+// quadrants that overlap the query, which makes them fast for sparse
+// hits such as collision checks.
+//
+// A Quadtree is not safe for concurrent modification.
+type Quadtree struct {
+	root     quadNode
+	capacity int
+	size     int
+}
+
+// NewQuadtree returns an empty Quadtree covering bounds whose leaves
+// hold up to capacity points. It panics if capacity is less than 1 or
+// bounds is empty (MinX > MaxX or MinY > MaxY).
+func NewQuadtree(bounds Rect, capacity int) *Quadtree {
+	if capacity < 1 {
+		panic(fmt.Sprintf("core: quadtree capacity %d is less than 1", capacity))
+	}
+	if bounds.MinX > bounds.MaxX || bounds.MinY > bounds.MaxY {
+		panic(fmt.Sprintf("core: quadtree bounds %+v are empty", bounds))
+	}
+	return &Quadtree{root: quadNode{bounds: bounds}, capacity: capacity}
+}
+
+// Len returns the number of points stored.
+func (q *Quadtree) Len() int { return q.size }
+
+// Bounds returns the region the tree covers.
+func (q *Quadtree) Bounds() Rect { return q.root.bounds }
+
+// Insert adds p and reports whether it lies within the tree's bounds;
+// points outside are not stored. Duplicate points are stored again.
+func (q *Quadtree) Insert(p Point) bool {
+	if !q.root.bounds.Contains(p) {
+		return false
+	}
+	n := &q.root
+	for depth := 0; ; depth++ {
+		if n.children == nil {
+			if len(n.points) < q.capacity || depth == quadtreeMaxDepth {
+				n.points = append(n.points, p)
+				q.size++
+				return true
+			}
+			n.split()
+		}
+		n = &n.children[n.quadrant(p)]
+	}
+}
+
+// split turns the leaf n into an internal node, moving its points into
+// four new child quadrants.
+func (n *quadNode) split() {
+	b := n.bounds
+	midX, midY := b.MinX+(b.MaxX-b.MinX)/2, b.MinY+(b.MaxY-b.MinY)/2
+	n.children = &[4]quadNode{
+		{bounds: Rect{b.MinX, b.MinY, midX, midY}},
+		{bounds: Rect{midX, b.MinY, b.MaxX, midY}},
+		{bounds: Rect{b.MinX, midY, midX, b.MaxY}},
+		{bounds: Rect{midX, midY, b.MaxX, b.MaxY}},
+	}
+	for _, p := range n.points {
+		c := &n.children[n.quadrant(p)]
+		c.points = append(c.points, p)
+	}
+	n.points = nil
+}
+
+// quadrant returns the index of the child of n whose region holds p.
+// Points on a dividing line belong to the east or north side.
+func (n *quadNode) quadrant(p Point) int {
+	c := n.children[0].bounds // the south-west quadrant ends at the midlines
+	i := 0
+	if p.X >= c.MaxX {
+		i |= 1
+	}
+	if p.Y >= c.MaxY {
+		i |= 2
+	}
+	return i
+}
+
+// Contains reports whether p has been inserted.
+func (q *Quadtree) Contains(p Point) bool {
+	if !q.root.bounds.Contains(p) {
+		return false
+	}
+	n := &q.root
+	for n.children != nil {
+		n = &n.children[n.quadrant(p)]
+	}
+	for _, x := range n.points {
+		if x == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Query returns every stored point inside r, edges included, in no
+// particular order. The returned slice is never nil.
+func (q *Quadtree) Query(r Rect) []Point {
+	res := []Point{}
+	stack := []*quadNode{&q.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !n.bounds.Intersects(r) {
+			continue
+		}
+		for _, p := range n.points {
+			if r.Contains(p) {
+				res = append(res, p)
+			}
+		}
+		if n.children != nil {
+			for i := range n.children {
+				stack = append(stack, &n.children[i])
+			}
+		}
+	}
+	return res
+}
+
+// ExportDOT writes the tree's regions as a Graphviz digraph to w, in the
+// style of the package-level ExportDOT. Regions are named n0, n1, ... in
+// level order and drawn as boxes labelled with their bounds, plus their
+// points for leaves; edges carry the quadrant name (SW, SE, NW, NE).
+func (q *Quadtree) ExportDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph quadtree {")
+	fmt.Fprintln(bw, "\tnode [shape=box];")
+
+	// Number the regions in level order; children are queued in order,
+	// so a node's children get consecutive ids.
+	nodes := []*quadNode{&q.root}
+	for i := 0; i < len(nodes); i++ {
+		if c := nodes[i].children; c != nil {
+			nodes = append(nodes, &c[0], &c[1], &c[2], &c[3])
+		}
+	}
+	for id, n := range nodes {
+		fmt.Fprintf(bw, "\tn%d [label=%s];\n", id, strconv.Quote(n.label()))
+	}
+	next := 1
+	for id, n := range nodes {
+		if n.children == nil {
+			continue
+		}
+		for i := range n.children {
+			fmt.Fprintf(bw, "\tn%d -> n%d [label=%q];\n", id, next, quadrantNames[i])
+			next++
+		}
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// label describes n for ExportDOT.
+func (n *quadNode) label() string {
+	var sb strings.Builder
+	b := n.bounds
+	fmt.Fprintf(&sb, "[%g,%g]x[%g,%g]", b.MinX, b.MaxX, b.MinY, b.MaxY)
+	if n.children == nil {
+		for _, p := range n.points {
+			fmt.Fprintf(&sb, "\n(%g,%g)", p.X, p.Y)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,47 @@
+package core
+
+// ringQueue is a FIFO queue backed by a circular buffer that doubles
+// when full. Unlike popping with queue = queue[1:], freed slots are
+// reused, so memory stays proportional to the queue's peak length
+// rather than to the total number of elements ever pushed.
+type ringQueue[T any] struct {
+	buf  []T
+	head int // index of the oldest element
+	n    int // number of queued elements
+}
+
+// Len returns the number of queued elements.
+func (q *ringQueue[T]) Len() int {
+	return q.n
+}
+
+// Push appends v to the back of the queue.
+func (q *ringQueue[T]) Push(v T) {
+	if q.n == len(q.buf) {
+		q.grow()
+	}
+	q.buf[(q.head+q.n)%len(q.buf)] = v
+	q.n++
+}
+
+// Pop removes and returns the front element. It panics on an empty queue.
+func (q *ringQueue[T]) Pop() T {
+	if q.n == 0 {
+		panic("core: Pop from empty ringQueue")
+	}
+	var zero T
+	v := q.buf[q.head]
+	q.buf[q.head] = zero // drop the reference so the GC can reclaim it
+	q.head = (q.head + 1) % len(q.buf)
+	q.n--
+	return v
+}
+
+// grow doubles the buffer, unwrapping the elements to start at index 0.
+func (q *ringQueue[T]) grow() {
+	buf := make([]T, max(2*len(q.buf), 8))
+	for i := 0; i < q.n; i++ {
+		buf[i] = q.buf[(q.head+i)%len(q.buf)]
+	}
+	q.buf, q.head = buf, 0
+}
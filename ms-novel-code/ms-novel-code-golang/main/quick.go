@@ -0,0 +1,75 @@
+package core
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"reflect"
+)
+
+// Generate implements testing/quick.Generator, so property-based tests
+// can take Tree arguments:
+//
+//	quick.Check(func(t Tree) bool {
+//		return len(rowWiseMax(t.Root)["output"]) == Height(t.Root)
+//	}, nil)
+//
+// Trees have up to size nodes and a randomly chosen Shape, mostly
+// ShapeRandom, with values in [-size, size] so duplicates occur.
+func (Tree) Generate(r *rand.Rand, size int) reflect.Value {
+	shapes := []Shape{ShapeRandom, ShapeRandom, ShapeRandom, ShapeBalanced, ShapeLeftSkewed, ShapeRightSkewed}
+	root := GenerateRandomTree(r.Intn(size+1),
+		WithShape(shapes[r.Intn(len(shapes))]),
+		WithValueRange(-size, size),
+		WithSeed(r.Uint64()),
+	)
+	return reflect.ValueOf(Tree{Root: root})
+}
+
+// EncodeFuzzCorpus encodes t as a native fuzzing input that
+// DecodeFuzzCorpus turns back into an equal tree, for seeding corpora
+// with f.Add.
+//
+// The encoding is the node section of the binary format (see
+// MarshalBinary) without the version byte and node count: per node, in
+// preorder, a flags byte for the children followed by a varint value.
+func EncodeFuzzCorpus(t Tree) []byte {
+	var buf []byte
+	eachNode(t.Root, func(n *Node) {
+		buf = append(buf, childFlags(n))
+		buf = binary.AppendVarint(buf, int64(n.Val))
+	})
+	return buf
+}
+
+// DecodeFuzzCorpus maps any byte string to a tree, so every input the
+// fuzzer mutates into is a usable test case. It reads EncodeFuzzCorpus's
+// format leniently: unknown flag bits are ignored, a truncated value
+// reads as 0, and children still missing when the input runs out are
+// left nil. Each node consumes at least one byte, so the tree has at
+// most len(data) nodes.
+func DecodeFuzzCorpus(data []byte) Tree {
+	var root *Node
+	slots := []**Node{&root}
+	for len(slots) > 0 && len(data) > 0 {
+		flags := data[0]
+		data = data[1:]
+		v, n := binary.Varint(data)
+		if n <= 0 {
+			v, data = 0, nil
+		} else {
+			data = data[n:]
+		}
+
+		slot := slots[len(slots)-1]
+		slots = slots[:len(slots)-1]
+		node := &Node{Val: int(v)}
+		*slot = node
+		if flags&binaryHasRight != 0 {
+			slots = append(slots, &node.Right)
+		}
+		if flags&binaryHasLeft != 0 {
+			slots = append(slots, &node.Left)
+		}
+	}
+	return Tree{Root: root}
+}
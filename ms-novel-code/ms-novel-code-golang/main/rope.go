@@ -0,0 +1,241 @@
+package core
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// ropeMaxChunk is the largest chunk a Rope stores in one node.
+const ropeMaxChunk = 512
+
+// ropeNode holds one chunk of a Rope's text. Nodes form a treap keyed
+// implicitly by position: a node's text comes after its left subtree's
+// and before its right subtree's.
+type ropeNode struct {
+	chunk       string
+	priority    uint64
+	left, right *ropeNode
+	size        int // bytes in this subtree
+}
+
+// Rope is a string built for editing large texts: a balanced tree of
+// string chunks, so inserting, deleting, splitting and joining cost
+// O(log n) expected time instead of copying the whole text. Positions
+// are byte offsets. Like Treap it stays balanced through random
+// priorities. Wherever an edit joins two pieces, the chunks meeting
+// there are fused if they fit in one, so many small inserts, as when
+// typing, do not leave a node per keystroke. A Rope is not safe for
+// concurrent use.
+type Rope struct {
+	root *ropeNode
+}
+
+// NewRope returns a Rope holding s.
+func NewRope(s string) *Rope {
+	return &Rope{root: ropeFromString(s)}
+}
+
+// Len returns the length of the text in bytes.
+func (r *Rope) Len() int {
+	return ropeSize(r.root)
+}
+
+// String returns the whole text.
+func (r *Rope) String() string {
+	var sb strings.Builder
+	sb.Grow(r.Len())
+	r.each(func(chunk string) { sb.WriteString(chunk) })
+	return sb.String()
+}
+
+// Index returns the byte at position i. It panics if i is out of range.
+func (r *Rope) Index(i int) byte {
+	if i < 0 || i >= r.Len() {
+		panic(fmt.Sprintf("core: Rope index %d out of range [0, %d)", i, r.Len()))
+	}
+	n := r.root
+	for {
+		left := ropeSize(n.left)
+		switch {
+		case i < left:
+			n = n.left
+		case i < left+len(n.chunk):
+			return n.chunk[i-left]
+		default:
+			i -= left + len(n.chunk)
+			n = n.right
+		}
+	}
+}
+
+// Slice returns the text in [lo, hi). It panics if the range is out of
+// bounds. Although the text is unchanged, Slice restructures the tree:
+// it splits at lo and hi and joins the pieces back, so it counts as a
+// modification, for instance when guarding a Rope with a lock.
+func (r *Rope) Slice(lo, hi int) string {
+	r.checkRange(lo, hi)
+	left, rest := ropeSplit(r.root, lo)
+	mid, right := ropeSplit(rest, hi-lo)
+	s := (&Rope{root: mid}).String()
+	r.root = ropeJoin(ropeJoin(left, mid), right)
+	return s
+}
+
+// Insert inserts s before position i (i == Len appends). It panics if i
+// is out of range.
+func (r *Rope) Insert(i int, s string) {
+	r.checkRange(i, i)
+	left, right := ropeSplit(r.root, i)
+	r.root = ropeJoin(ropeJoin(left, ropeFromString(s)), right)
+}
+
+// Delete removes the text in [lo, hi). It panics if the range is out of
+// bounds.
+func (r *Rope) Delete(lo, hi int) {
+	r.checkRange(lo, hi)
+	left, rest := ropeSplit(r.root, lo)
+	_, right := ropeSplit(rest, hi-lo)
+	r.root = ropeJoin(left, right)
+}
+
+// Concat appends other's text to r, leaving other empty. If other is r
+// itself, a copy of the text is appended, doubling it.
+func (r *Rope) Concat(other *Rope) {
+	if other == r {
+		// Merging a treap into itself would corrupt it, and emptying
+		// other would empty r.
+		r.root = ropeJoin(r.root, ropeFromString(r.String()))
+		return
+	}
+	r.root = ropeJoin(r.root, other.root)
+	other.root = nil
+}
+
+// Split moves the text from position i on into a new Rope, which it
+// returns; r keeps the text before i. It panics if i is out of range.
+func (r *Rope) Split(i int) *Rope {
+	r.checkRange(i, i)
+	left, right := ropeSplit(r.root, i)
+	r.root = left
+	return &Rope{root: right}
+}
+
+// each calls fn with the chunks in order, walking with an explicit
+// stack.
+func (r *Rope) each(fn func(chunk string)) {
+	var stack []*ropeNode
+	n := r.root
+	for n != nil || len(stack) > 0 {
+		for n != nil {
+			stack = append(stack, n)
+			n = n.left
+		}
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		fn(n.chunk)
+		n = n.right
+	}
+}
+
+func (r *Rope) checkRange(lo, hi int) {
+	if lo < 0 || hi > r.Len() || lo > hi {
+		panic(fmt.Sprintf("core: Rope range [%d, %d) invalid for length %d", lo, hi, r.Len()))
+	}
+}
+
+// ropeFromString builds a treap of s split into chunks of at most
+// ropeMaxChunk bytes.
+func ropeFromString(s string) *ropeNode {
+	var root *ropeNode
+	for len(s) > 0 {
+		n := min(len(s), ropeMaxChunk)
+		root = ropeMerge(root, &ropeNode{chunk: s[:n], priority: rand.Uint64(), size: n})
+		s = s[n:]
+	}
+	return root
+}
+
+func ropeSize(n *ropeNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func ropeUpdate(n *ropeNode) {
+	n.size = ropeSize(n.left) + len(n.chunk) + ropeSize(n.right)
+}
+
+// ropeSplit divides n into its first k bytes and the rest, cutting a
+// chunk in two if k falls inside it.
+func ropeSplit(n *ropeNode, k int) (left, right *ropeNode) {
+	if n == nil {
+		return nil, nil
+	}
+	ls := ropeSize(n.left)
+	switch {
+	case k <= ls:
+		left, n.left = ropeSplit(n.left, k)
+		ropeUpdate(n)
+		return left, n
+	case k >= ls+len(n.chunk):
+		n.right, right = ropeSplit(n.right, k-ls-len(n.chunk))
+		ropeUpdate(n)
+		return n, right
+	}
+	// The tail of the chunk moves to a new node with n's priority, which
+	// is at least that of everything below it, keeping heap order.
+	off := k - ls
+	tail := &ropeNode{chunk: n.chunk[off:], priority: n.priority, right: n.right}
+	n.chunk, n.right = n.chunk[:off], nil
+	ropeUpdate(n)
+	ropeUpdate(tail)
+	return n, tail
+}
+
+// ropeMerge joins two treaps, a's text before b's.
+func ropeMerge(a, b *ropeNode) *ropeNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		a.right = ropeMerge(a.right, b)
+		ropeUpdate(a)
+		return a
+	}
+	b.left = ropeMerge(a, b.left)
+	ropeUpdate(b)
+	return b
+}
+
+// ropeJoin is ropeMerge for edits: if a's last chunk and b's first
+// together fit in ropeMaxChunk, they become one chunk, so the cuts
+// ropeSplit makes heal when the pieces are put back together.
+func ropeJoin(a, b *ropeNode) *ropeNode {
+	if a == nil || b == nil {
+		return ropeMerge(a, b)
+	}
+	first := b
+	for first.left != nil {
+		first = first.left
+	}
+	var spine []*ropeNode // a's right spine, ending at its last chunk
+	for n := a; n != nil; n = n.right {
+		spine = append(spine, n)
+	}
+	last := spine[len(spine)-1]
+	if len(last.chunk)+len(first.chunk) > ropeMaxChunk {
+		return ropeMerge(a, b)
+	}
+
+	_, b = ropeSplit(b, len(first.chunk)) // detaches exactly first
+	last.chunk += first.chunk
+	for i := len(spine) - 1; i >= 0; i-- {
+		ropeUpdate(spine[i])
+	}
+	return ropeMerge(a, b)
+}
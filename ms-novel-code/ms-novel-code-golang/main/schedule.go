@@ -0,0 +1,79 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// MaxNonOverlapping returns a largest subset of intervals in which no
+// two share a point, ordered by Lo. It is the classic greedy schedule:
+// repeatedly take the interval that ends first among those starting
+// after the last one taken, which is optimal. Intervals are closed, so
+// [1, 2] and [2, 3] conflict. The input is not modified and the
+// returned slice is never nil. It panics if an interval has Lo > Hi.
+//
+// Unlike FindConflicts it does not use an IntervalTree: once sorted by
+// end, each candidate only needs comparing with the last interval
+// taken, so a tree would add O(n log n) work without saving any.
+func MaxNonOverlapping(intervals []Interval) []Interval {
+	checkIntervals(intervals)
+	byEnd := slices.Clone(intervals)
+	slices.SortFunc(byEnd, func(a, b Interval) int {
+		if c := cmp.Compare(a.Hi, b.Hi); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Lo, a.Lo) // prefer the shorter of two that end together
+	})
+
+	res := []Interval{}
+	for _, iv := range byEnd {
+		if len(res) == 0 || iv.Lo > res[len(res)-1].Hi {
+			res = append(res, iv)
+		}
+	}
+	return res
+}
+
+// FindConflicts returns every pair of indices (i, j), i < j, whose
+// intervals share a point, ordered by i and then j. It loads the
+// intervals into an IntervalTree and runs one overlap query per
+// interval, so it costs O(n log n + k) for k conflicts rather than
+// comparing all pairs. The returned slice is never nil. It panics if an
+// interval has Lo > Hi.
+func FindConflicts(intervals []Interval) [][2]int {
+	checkIntervals(intervals)
+	// The tree holds distinct intervals; at remembers where each occurs.
+	tree := NewIntervalTree()
+	at := map[Interval][]int{}
+	for i, iv := range intervals {
+		tree.Insert(iv)
+		at[iv] = append(at[iv], i)
+	}
+
+	res := [][2]int{}
+	var partners []int
+	for i, iv := range intervals {
+		partners = partners[:0]
+		for _, o := range tree.QueryOverlapping(iv) {
+			for _, j := range at[o] {
+				if j > i {
+					partners = append(partners, j)
+				}
+			}
+		}
+		slices.Sort(partners)
+		for _, j := range partners {
+			res = append(res, [2]int{i, j})
+		}
+	}
+	return res
+}
+
+func checkIntervals(intervals []Interval) {
+	for _, iv := range intervals {
+		if iv.Lo > iv.Hi {
+			panic(fmt.Sprintf("core: invalid interval [%d, %d]", iv.Lo, iv.Hi))
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package core
+
+import "fmt"
+
+// SegmentTree answers range minimum, maximum, and sum queries over a
+// fixed-length sequence of ints in O(log n), with O(log n) point
+// updates. Ranges are half-open, [lo, hi), like Go slices.
+//
+// It is the iterative, array-backed form: leaves live at [n, 2n) and
+// node i aggregates its children 2i and 2i+1.
+type SegmentTree struct {
+	n   int
+	min []int
+	max []int
+	sum []int
+}
+
+// NewSegmentTree builds a SegmentTree over a copy of vals in O(n).
+func NewSegmentTree(vals []int) *SegmentTree {
+	n := len(vals)
+	st := &SegmentTree{
+		n:   n,
+		min: make([]int, 2*n),
+		max: make([]int, 2*n),
+		sum: make([]int, 2*n),
+	}
+	copy(st.min[n:], vals)
+	copy(st.max[n:], vals)
+	copy(st.sum[n:], vals)
+	for i := n - 1; i > 0; i-- {
+		st.pull(i)
+	}
+	return st
+}
+
+// Len returns the length of the underlying sequence.
+func (st *SegmentTree) Len() int {
+	return st.n
+}
+
+// Get returns the value at index i.
+func (st *SegmentTree) Get(i int) int {
+	st.checkIndex(i)
+	return st.sum[st.n+i]
+}
+
+// Update sets the value at index i to v.
+func (st *SegmentTree) Update(i, v int) {
+	st.checkIndex(i)
+	i += st.n
+	st.min[i], st.max[i], st.sum[i] = v, v, v
+	for i /= 2; i > 0; i /= 2 {
+		st.pull(i)
+	}
+}
+
+// RangeMin returns the smallest value in [lo, hi). It panics if the
+// range is empty or out of bounds.
+func (st *SegmentTree) RangeMin(lo, hi int) int {
+	st.checkRange(lo, hi, false)
+	res := st.min[lo+st.n]
+	st.query(lo, hi, func(i int) { res = min(res, st.min[i]) })
+	return res
+}
+
+// RangeMax returns the largest value in [lo, hi). It panics if the
+// range is empty or out of bounds.
+func (st *SegmentTree) RangeMax(lo, hi int) int {
+	st.checkRange(lo, hi, false)
+	res := st.max[lo+st.n]
+	st.query(lo, hi, func(i int) { res = max(res, st.max[i]) })
+	return res
+}
+
+// RangeSum returns the sum of the values in [lo, hi); an empty range
+// sums to 0. It panics if the range is out of bounds.
+func (st *SegmentTree) RangeSum(lo, hi int) int {
+	st.checkRange(lo, hi, true)
+	res := 0
+	st.query(lo, hi, func(i int) { res += st.sum[i] })
+	return res
+}
+
+// query calls visit with the internal nodes that exactly cover [lo, hi).
+func (st *SegmentTree) query(lo, hi int, visit func(i int)) {
+	for lo, hi = lo+st.n, hi+st.n; lo < hi; lo, hi = lo/2, hi/2 {
+		if lo&1 == 1 {
+			visit(lo)
+			lo++
+		}
+		if hi&1 == 1 {
+			hi--
+			visit(hi)
+		}
+	}
+}
+
+// pull recomputes internal node i from its children.
+func (st *SegmentTree) pull(i int) {
+	st.min[i] = min(st.min[2*i], st.min[2*i+1])
+	st.max[i] = max(st.max[2*i], st.max[2*i+1])
+	st.sum[i] = st.sum[2*i] + st.sum[2*i+1]
+}
+
+func (st *SegmentTree) checkIndex(i int) {
+	if i < 0 || i >= st.n {
+		panic(fmt.Sprintf("core: SegmentTree index %d out of range [0, %d)", i, st.n))
+	}
+}
+
+func (st *SegmentTree) checkRange(lo, hi int, allowEmpty bool) {
+	if lo < 0 || hi > st.n || lo > hi || (!allowEmpty && lo == hi) {
+		panic(fmt.Sprintf("core: SegmentTree range [%d, %d) invalid for length %d", lo, hi, st.n))
+	}
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// nullMarker stands for a missing child in the Serialize format.
+const nullMarker = "#"
+
+// Serialize encodes the tree as comma-separated values in preorder, with
+// "#" for every missing child: the tree 1(2, 3) becomes "1,2,#,#,3,#,#"
+// and the empty tree "#". Deserialize reverses it exactly.
+func Serialize(root *Node) string {
+	var sb strings.Builder
+	stack := []*Node{root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if sb.Len() > 0 {
+			sb.WriteByte(',')
+		}
+		if node == nil {
+			sb.WriteString(nullMarker)
+			continue
+		}
+		sb.WriteString(strconv.Itoa(node.Val))
+		stack = append(stack, node.Right, node.Left)
+	}
+	return sb.String()
+}
+
+// Deserialize decodes a string produced by Serialize. It returns an
+// error if a token is not an integer or "#", if the input ends before
+// the tree is complete, or if tokens are left over after it.
+func Deserialize(s string) (*Node, error) {
+	tokens := strings.Split(s, ",")
+
+	var root *Node
+	slots := []**Node{&root} // links still waiting for a token
+	for i, tok := range tokens {
+		if len(slots) == 0 {
+			return nil, fmt.Errorf("deserialize: unexpected trailing data at token %d", i)
+		}
+		slot := slots[len(slots)-1]
+		slots = slots[:len(slots)-1]
+
+		if tok == nullMarker {
+			continue
+		}
+		v, err := strconv.Atoi(tok)
+		if err != nil {
+			return nil, fmt.Errorf("deserialize: token %d: invalid value %q", i, tok)
+		}
+		node := &Node{Val: v}
+		*slot = node
+		slots = append(slots, &node.Right, &node.Left)
+	}
+	if len(slots) > 0 {
+		return nil, fmt.Errorf("deserialize: input ends with %d missing children", len(slots))
+	}
+	return root, nil
+}
+
+// ParseLevelOrder reads the LeetCode-style level-order text that
+// BuildFromLevelOrder takes as a slice, e.g. "[1,2,null,3]". Brackets
+// are optional, values may be separated by commas or whitespace, and
+// "null", "nil" and "#" all mark a missing child. It returns an error
+// if a token is not an integer or a null marker.
+func ParseLevelOrder(s string) (*Node, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	tokens := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	vals := make([]*int, len(tokens))
+	for i, tok := range tokens {
+		switch tok {
+		case "null", "nil", nullMarker:
+			continue
+		}
+		v, err := strconv.Atoi(tok)
+		if err != nil {
+			return nil, fmt.Errorf("parse level order: token %d: invalid value %q", i, tok)
+		}
+		vals[i] = &v
+	}
+	return BuildFromLevelOrder(vals), nil
+}
@@ -0,0 +1,138 @@
+package core
+
+import (
+	"cmp"
+	"iter"
+)
+
+// setDegree is the B-tree minimum degree backing a Set.
+const setDegree = 16
+
+// Set is an ordered set of values backed by a BTree, for callers who
+// want set algebra without handling trees directly. Iteration is in
+// ascending order. The zero value is not usable; create sets with
+// NewSet. A Set is not safe for concurrent use.
+type Set[T cmp.Ordered] struct {
+	m OrderedMap[T, struct{}]
+}
+
+// NewSet returns a Set holding vals.
+func NewSet[T cmp.Ordered](vals ...T) *Set[T] {
+	s := &Set[T]{m: NewBTree[T, struct{}](setDegree)}
+	for _, v := range vals {
+		s.Add(v)
+	}
+	return s
+}
+
+// Add inserts v and reports whether it was not already present.
+func (s *Set[T]) Add(v T) bool {
+	return s.m.Put(v, struct{}{})
+}
+
+// Remove deletes v and reports whether it was present.
+func (s *Set[T]) Remove(v T) bool {
+	return s.m.Delete(v)
+}
+
+// Contains reports whether v is in the set.
+func (s *Set[T]) Contains(v T) bool {
+	_, ok := s.m.Get(v)
+	return ok
+}
+
+// Len returns the number of values in the set.
+func (s *Set[T]) Len() int {
+	return s.m.Len()
+}
+
+// Min returns the smallest value, or false if the set is empty.
+func (s *Set[T]) Min() (T, bool) {
+	v, _, ok := s.m.Min()
+	return v, ok
+}
+
+// Max returns the largest value, or false if the set is empty.
+func (s *Set[T]) Max() (T, bool) {
+	v, _, ok := s.m.Max()
+	return v, ok
+}
+
+// All returns an iterator over the values in ascending order. The set
+// must not be modified during iteration.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		lo, _, ok := s.m.Min()
+		if !ok {
+			return
+		}
+		hi, _, _ := s.m.Max()
+		for v := range s.m.Range(lo, hi) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the values in [lo, hi] in ascending
+// order. The set must not be modified during iteration.
+func (s *Set[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.m.Range(lo, hi) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns the values in ascending order. The returned slice is
+// never nil.
+func (s *Set[T]) Values() []T {
+	vals := make([]T, 0, s.Len())
+	for v := range s.All() {
+		vals = append(vals, v)
+	}
+	return vals
+}
+
+// Union returns a new set holding the values in s or other.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	res := NewSet[T]()
+	for v := range s.All() {
+		res.Add(v)
+	}
+	for v := range other.All() {
+		res.Add(v)
+	}
+	return res
+}
+
+// Intersection returns a new set holding the values in both s and
+// other. It iterates the smaller set and probes the larger.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	small, large := s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	res := NewSet[T]()
+	for v := range small.All() {
+		if large.Contains(v) {
+			res.Add(v)
+		}
+	}
+	return res
+}
+
+// Difference returns a new set holding the values in s but not in
+// other.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	res := NewSet[T]()
+	for v := range s.All() {
+		if !other.Contains(v) {
+			res.Add(v)
+		}
+	}
+	return res
+}
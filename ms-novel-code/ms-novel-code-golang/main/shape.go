@@ -0,0 +1,64 @@
+package core
+
+// IsBalanced reports whether, at every node, the heights of the left and
+// right subtrees differ by at most one. An empty tree is balanced.
+func IsBalanced[T any](root *TreeNode[T]) bool {
+	height := map[*TreeNode[T]]int{nil: 0}
+	for _, node := range postorderNodes(root) {
+		l, r := height[node.Left], height[node.Right]
+		if l-r > 1 || r-l > 1 {
+			return false
+		}
+		height[node] = 1 + max(l, r)
+	}
+	return true
+}
+
+// IsComplete reports whether every level except possibly the last is
+// full and the last level's nodes are packed to the left, i.e. the tree
+// could be stored in an array without gaps. An empty tree is complete.
+func IsComplete[T any](root *TreeNode[T]) bool {
+	if root == nil {
+		return true
+	}
+	var queue ringQueue[*TreeNode[T]]
+	queue.Push(root)
+	seenGap := false
+	for queue.Len() > 0 {
+		node := queue.Pop()
+		if node == nil {
+			seenGap = true
+			continue
+		}
+		if seenGap {
+			return false
+		}
+		queue.Push(node.Left)
+		queue.Push(node.Right)
+	}
+	return true
+}
+
+// IsPerfect reports whether every level is full, so all leaves share the
+// same depth and the tree has exactly 2^Height - 1 nodes. An empty tree
+// is perfect.
+func IsPerfect[T any](root *TreeNode[T]) bool {
+	perfect := true
+	walkLevels(root, func(depth int, level []*TreeNode[T]) bool {
+		perfect = len(level) == 1<<depth
+		return perfect
+	})
+	return perfect
+}
+
+// IsFull reports whether every node has either zero or two children.
+// An empty tree is full.
+func IsFull[T any](root *TreeNode[T]) bool {
+	full := true
+	eachNode(root, func(n *TreeNode[T]) {
+		if (n.Left == nil) != (n.Right == nil) {
+			full = false
+		}
+	})
+	return full
+}
@@ -0,0 +1,166 @@
+package core
+
+import (
+	"cmp"
+	"iter"
+	"math/bits"
+	"math/rand/v2"
+)
+
+// skipListMaxLevel bounds node heights; with p = 1/4 it comfortably
+// covers 4^32 entries.
+const skipListMaxLevel = 32
+
+// skipNode is an entry of a SkipList; next[i] is its successor on
+// level i.
+type skipNode[K cmp.Ordered, V any] struct {
+	key  K
+	val  V
+	next []*skipNode[K, V]
+}
+
+// SkipList is a probabilistic ordered map: a sorted linked list with
+// extra express lanes, where each entry appears on level i+1 with
+// probability 1/4 given that it is on level i. Searches, inserts and
+// deletes take O(log n) expected time. It offers the same operations as
+// BTree and SplayTree, so the three can be benchmarked against each
+// other.
+//
+// A SkipList is not safe for concurrent use.
+type SkipList[K cmp.Ordered, V any] struct {
+	head  skipNode[K, V] // sentinel; only head.next is used
+	level int            // number of levels in use
+	size  int
+}
+
+var _ OrderedMap[int, int] = (*SkipList[int, int])(nil)
+
+// NewSkipList returns an empty SkipList.
+func NewSkipList[K cmp.Ordered, V any]() *SkipList[K, V] {
+	return &SkipList[K, V]{head: skipNode[K, V]{next: make([]*skipNode[K, V], skipListMaxLevel)}}
+}
+
+// Len returns the number of keys stored.
+func (s *SkipList[K, V]) Len() int { return s.size }
+
+// search returns, for every level, the last node whose key is below key
+// (the head if none), filling update when it is non-nil.
+func (s *SkipList[K, V]) search(key K, update *[skipListMaxLevel]*skipNode[K, V]) *skipNode[K, V] {
+	x := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < key {
+			x = x.next[i]
+		}
+		if update != nil {
+			update[i] = x
+		}
+	}
+	return x
+}
+
+// Get returns the value stored under key.
+func (s *SkipList[K, V]) Get(key K) (V, bool) {
+	if x := s.search(key, nil).next[0]; x != nil && x.key == key {
+		return x.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Insert stores val under key, replacing any previous value, and
+// reports whether key was not already present.
+func (s *SkipList[K, V]) Insert(key K, val V) bool {
+	var update [skipListMaxLevel]*skipNode[K, V]
+	if x := s.search(key, &update).next[0]; x != nil && x.key == key {
+		x.val = val
+		return false
+	}
+
+	// Each level is kept with probability 1/4: two zero bits per level.
+	lvl := min(1+bits.TrailingZeros64(rand.Uint64())/2, skipListMaxLevel)
+	for ; s.level < lvl; s.level++ {
+		update[s.level] = &s.head
+	}
+	node := &skipNode[K, V]{key: key, val: val, next: make([]*skipNode[K, V], lvl)}
+	for i := range lvl {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+	}
+	s.size++
+	return true
+}
+
+// Put is Insert under the name OrderedMap uses.
+func (s *SkipList[K, V]) Put(key K, val V) bool {
+	return s.Insert(key, val)
+}
+
+// Delete removes key and reports whether it was present.
+func (s *SkipList[K, V]) Delete(key K) bool {
+	var update [skipListMaxLevel]*skipNode[K, V]
+	x := s.search(key, &update).next[0]
+	if x == nil || x.key != key {
+		return false
+	}
+	for i := range x.next {
+		update[i].next[i] = x.next[i]
+	}
+	for s.level > 0 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.size--
+	return true
+}
+
+// Min returns the smallest key and its value, or false if the list is
+// empty.
+func (s *SkipList[K, V]) Min() (K, V, bool) {
+	if x := s.head.next[0]; x != nil {
+		return x.key, x.val, true
+	}
+	var k K
+	var v V
+	return k, v, false
+}
+
+// Max returns the largest key and its value, or false if the list is
+// empty.
+func (s *SkipList[K, V]) Max() (K, V, bool) {
+	x := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil {
+			x = x.next[i]
+		}
+	}
+	if x == &s.head {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return x.key, x.val, true
+}
+
+// All returns an iterator over the keys and values in ascending key
+// order. The list must not be modified during iteration.
+func (s *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.head.next[0]; x != nil; x = x.next[0] {
+			if !yield(x.key, x.val) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the keys in [lo, hi] and their values,
+// in ascending key order, costing O(log n + m) for m results. The list
+// must not be modified during iteration.
+func (s *SkipList[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.search(lo, nil).next[0]; x != nil && x.key <= hi; x = x.next[0] {
+			if !yield(x.key, x.val) {
+				return
+			}
+		}
+	}
+}
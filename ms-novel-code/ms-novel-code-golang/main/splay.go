@@ -0,0 +1,257 @@
+package core
+
+import (
+	"cmp"
+	"iter"
+)
+
+// splayNode is a key/value node of a SplayTree.
+type splayNode[K cmp.Ordered, V any] struct {
+	key         K
+	val         V
+	left, right *splayNode[K, V]
+}
+
+// SplayTree is a self-adjusting binary search tree mapping keys to
+// values. Every access splays the accessed key to the root, so recently
+// and frequently used keys stay near the top: operations are O(log n)
+// amortised, and workloads with strong temporal locality run faster
+// than on a strictly balanced tree. Since even reads restructure the
+// tree, a SplayTree is not safe for concurrent use, including
+// concurrent Gets.
+//
+// The tree can be linear in height between accesses, so every walk is
+// iterative.
+type SplayTree[K cmp.Ordered, V any] struct {
+	root *splayNode[K, V]
+	size int
+}
+
+var _ OrderedMap[int, int] = (*SplayTree[int, int])(nil)
+
+// NewSplayTree returns an empty SplayTree.
+func NewSplayTree[K cmp.Ordered, V any]() *SplayTree[K, V] {
+	return &SplayTree[K, V]{}
+}
+
+// Get returns the value stored under key, splaying it to the root.
+func (t *SplayTree[K, V]) Get(key K) (V, bool) {
+	if !t.Splay(key) {
+		var zero V
+		return zero, false
+	}
+	return t.root.val, true
+}
+
+// Insert stores val under key, replacing any previous value, and
+// reports whether key was not already present. key ends at the root.
+func (t *SplayTree[K, V]) Insert(key K, val V) bool {
+	if t.Splay(key) {
+		t.root.val = val
+		return false
+	}
+	node := &splayNode[K, V]{key: key, val: val}
+	if r := t.root; r != nil {
+		// r is key's neighbour in order; split around it.
+		if key < r.key {
+			node.left, node.right, r.left = r.left, r, nil
+		} else {
+			node.right, node.left, r.right = r.right, r, nil
+		}
+	}
+	t.root = node
+	t.size++
+	return true
+}
+
+// Put is Insert under the name OrderedMap uses.
+func (t *SplayTree[K, V]) Put(key K, val V) bool {
+	return t.Insert(key, val)
+}
+
+// Delete removes key and reports whether it was present.
+func (t *SplayTree[K, V]) Delete(key K) bool {
+	if !t.Splay(key) {
+		return false
+	}
+	left, right := t.root.left, t.root.right
+	if left == nil {
+		t.root = right
+	} else {
+		// Every key in left is smaller than key, so splaying key there
+		// brings left's maximum, which has no right child, to the top.
+		t.root = left
+		t.Splay(key)
+		t.root.right = right
+	}
+	t.size--
+	return true
+}
+
+// Splay restructures the tree so that key is at the root, or, if key is
+// absent, the last node on its search path (its neighbour in order). It
+// reports whether key is present.
+//
+// This is Sleator and Tarjan's top-down splay: nodes passed on the way
+// down are hung off a left tree (keys below key) and a right tree (keys
+// above), with a rotation whenever two steps go the same way, and the
+// two trees become the new root's subtrees at the end.
+func (t *SplayTree[K, V]) Splay(key K) bool {
+	n := t.root
+	if n == nil {
+		return false
+	}
+	var header splayNode[K, V] // header.right / header.left collect the left / right trees
+	l, r := &header, &header
+	for {
+		if key < n.key {
+			if n.left == nil {
+				break
+			}
+			if key < n.left.key { // zig-zig: rotate right
+				y := n.left
+				n.left, y.right = y.right, n
+				n = y
+				if n.left == nil {
+					break
+				}
+			}
+			r.left, r, n = n, n, n.left // link into the right tree
+		} else if key > n.key {
+			if n.right == nil {
+				break
+			}
+			if key > n.right.key { // zig-zig: rotate left
+				y := n.right
+				n.right, y.left = y.left, n
+				n = y
+				if n.right == nil {
+					break
+				}
+			}
+			l.right, l, n = n, n, n.right // link into the left tree
+		} else {
+			break
+		}
+	}
+	l.right, r.left = n.left, n.right
+	n.left, n.right = header.right, header.left
+	t.root = n
+	return n.key == key
+}
+
+// Min returns the smallest key and its value, splaying it to the root,
+// or false if the tree is empty.
+func (t *SplayTree[K, V]) Min() (K, V, bool) {
+	if t.root == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	n := t.root
+	for n.left != nil {
+		n = n.left
+	}
+	t.Splay(n.key)
+	return n.key, n.val, true
+}
+
+// Max returns the largest key and its value, splaying it to the root,
+// or false if the tree is empty.
+func (t *SplayTree[K, V]) Max() (K, V, bool) {
+	if t.root == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	n := t.root
+	for n.right != nil {
+		n = n.right
+	}
+	t.Splay(n.key)
+	return n.key, n.val, true
+}
+
+// Len returns the number of keys stored.
+func (t *SplayTree[K, V]) Len() int {
+	return t.size
+}
+
+// All returns an iterator over the keys and values in ascending key
+// order. Iterating does not splay; the tree must not be modified
+// during iteration.
+func (t *SplayTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*splayNode[K, V]
+		node := t.root
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.left
+			}
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(node.key, node.val) {
+				return
+			}
+			node = node.right
+		}
+	}
+}
+
+// Range returns an iterator over the keys in [lo, hi] and their values,
+// in ascending key order. Subtrees entirely outside the range are
+// skipped. Iterating does not splay; the tree must not be modified
+// during iteration.
+func (t *SplayTree[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*splayNode[K, V]
+		node := t.root
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				if node.key < lo {
+					node = node.right // node and its left subtree are below lo
+					continue
+				}
+				stack = append(stack, node)
+				node = node.left
+			}
+			if len(stack) == 0 {
+				return
+			}
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if node.key > hi || !yield(node.key, node.val) {
+				return
+			}
+			node = node.right
+		}
+	}
+}
+
+// Tree returns a copy of the tree's current shape holding its keys, so
+// the rest of the package (rowWiseMax, PrintTree, ...) can inspect it.
+func (t *SplayTree[K, V]) Tree() *TreeNode[K] {
+	if t.root == nil {
+		return nil
+	}
+	type pair struct {
+		src *splayNode[K, V]
+		dst *TreeNode[K]
+	}
+	out := &TreeNode[K]{Val: t.root.key}
+	stack := []pair{{t.root, out}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if p.src.left != nil {
+			p.dst.Left = &TreeNode[K]{Val: p.src.left.key}
+			stack = append(stack, pair{p.src.left, p.dst.Left})
+		}
+		if p.src.right != nil {
+			p.dst.Right = &TreeNode[K]{Val: p.src.right.key}
+			stack = append(stack, pair{p.src.right, p.dst.Right})
+		}
+	}
+	return out
+}
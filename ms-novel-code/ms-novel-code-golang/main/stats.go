@@ -0,0 +1,71 @@
+package core
+
+// LevelStat summarises the values found on a single tree level.
+type LevelStat struct {
+	Depth int     // 0 for the root level
+	Count int     // number of nodes on the level
+	Min   int     // smallest value on the level
+	Max   int     // largest value on the level
+	Sum   int     // sum of all values on the level
+	Mean  float64 // Sum / Count
+}
+
+// LevelStats returns min, max, sum, mean, and count for every tree
+// level, top-to-bottom, computed in a single traversal.
+// The returned slice is never nil, even for an empty tree.
+func LevelStats(root *Node) []LevelStat {
+	stats := []LevelStat{}
+
+	walkLevels(root, func(depth int, level []*Node) bool {
+		st := LevelStat{
+			Depth: depth,
+			Count: len(level),
+			Min:   level[0].Val,
+			Max:   level[0].Val,
+		}
+		for _, node := range level {
+			st.Min = min(st.Min, node.Val)
+			st.Max = max(st.Max, node.Val)
+			st.Sum += node.Val
+		}
+		st.Mean = float64(st.Sum) / float64(st.Count)
+		stats = append(stats, st)
+		return true
+	})
+
+	return stats
+}
+
+// SubtreeStats summarises the values in the subtree rooted at a node,
+// the node itself included.
+type SubtreeStats struct {
+	Size   int // number of nodes
+	Height int // number of levels; 1 for a leaf
+	Min    int // smallest value
+	Max    int // largest value
+	Sum    int // sum of all values
+}
+
+// Annotate returns the SubtreeStats of every node's subtree, computed
+// bottom-up in a single pass, so that later queries on any subtree are
+// O(1) map lookups. The map describes the tree as it was when it was
+// built. An empty tree yields an empty map.
+func Annotate(root *Node) map[*Node]SubtreeStats {
+	stats := make(map[*Node]SubtreeStats)
+	for _, node := range postorderNodes(root) {
+		st := SubtreeStats{Size: 1, Height: 1, Min: node.Val, Max: node.Val, Sum: node.Val}
+		for _, child := range [...]*Node{node.Left, node.Right} {
+			if child == nil {
+				continue
+			}
+			c := stats[child]
+			st.Size += c.Size
+			st.Height = max(st.Height, c.Height+1)
+			st.Min = min(st.Min, c.Min)
+			st.Max = max(st.Max, c.Max)
+			st.Sum += c.Sum
+		}
+		stats[node] = st
+	}
+	return stats
+}
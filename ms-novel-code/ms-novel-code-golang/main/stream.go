@@ -0,0 +1,32 @@
+package core
+
+import (
+	"context"
+	"fmt"
+)
+
+// TraverseChan streams the tree's values in the given order over the
+// returned channel, without materialising them in a slice. The channel
+// is unbuffered and is closed once every value has been sent or ctx is
+// done, whichever comes first; cancelling ctx is therefore enough to
+// release the producing goroutine even if the consumer stops reading.
+// The tree must not be modified until the channel is closed.
+// It panics if order is not a defined TraversalOrder.
+func TraverseChan[T any](ctx context.Context, root *TreeNode[T], order TraversalOrder) <-chan T {
+	if !order.valid() {
+		panic(fmt.Sprintf("core: unknown %v", order))
+	}
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		walkOrder(root, order, func(n *TreeNode[T], _ int) bool {
+			select {
+			case out <- n.Val:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return out
+}
@@ -0,0 +1,109 @@
+package core
+
+import "cmp"
+
+// InorderSuccessor returns the node visited right after n in an inorder
+// walk of the tree rooted at root, or nil if n is the last node or is
+// not part of the tree. Nodes are matched by identity. Any binary tree
+// is accepted, so the walk may take O(n); for binary search trees,
+// InorderSuccessorBST takes O(height).
+func InorderSuccessor[T any](root, n *TreeNode[T]) *TreeNode[T] {
+	return inorderNeighbour(root, n, false)
+}
+
+// InorderPredecessor returns the node visited right before n in an
+// inorder walk, or nil if n is the first node or is not part of the
+// tree. See InorderSuccessor.
+func InorderPredecessor[T any](root, n *TreeNode[T]) *TreeNode[T] {
+	return inorderNeighbour(root, n, true)
+}
+
+// inorderNeighbour returns the node after n in inorder, or in reverse
+// inorder when reverse is set.
+func inorderNeighbour[T any](root, n *TreeNode[T], reverse bool) *TreeNode[T] {
+	if n == nil {
+		return nil
+	}
+	near := func(x *TreeNode[T]) *TreeNode[T] { return x.Left }
+	far := func(x *TreeNode[T]) *TreeNode[T] { return x.Right }
+	if reverse {
+		near, far = far, near
+	}
+
+	var stack []*TreeNode[T]
+	found := false
+	node := root
+	for node != nil || len(stack) > 0 {
+		for node != nil {
+			stack = append(stack, node)
+			node = near(node)
+		}
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if found {
+			return node
+		}
+		found = node == n
+		node = far(node)
+	}
+	return nil
+}
+
+// InorderSuccessorBST is InorderSuccessor for binary search trees. It
+// needs no parent pointers: the search for n from the root remembers the
+// last node where it turned left, which is the successor unless n has a
+// right subtree. It runs in O(height) and, like InorderSuccessor,
+// returns nil if n is not part of the tree.
+func InorderSuccessorBST[T cmp.Ordered](root, n *TreeNode[T]) *TreeNode[T] {
+	if n == nil {
+		return nil
+	}
+	var succ *TreeNode[T]
+	node := root
+	for node != nil && node != n {
+		if n.Val < node.Val {
+			succ = node
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	if node == nil {
+		return nil
+	}
+	if n.Right != nil {
+		succ = n.Right
+		for succ.Left != nil {
+			succ = succ.Left
+		}
+	}
+	return succ
+}
+
+// InorderPredecessorBST is InorderPredecessor for binary search trees,
+// mirroring InorderSuccessorBST. It runs in O(height).
+func InorderPredecessorBST[T cmp.Ordered](root, n *TreeNode[T]) *TreeNode[T] {
+	if n == nil {
+		return nil
+	}
+	var pred *TreeNode[T]
+	node := root
+	for node != nil && node != n {
+		if n.Val > node.Val {
+			pred = node
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+	if node == nil {
+		return nil
+	}
+	if n.Left != nil {
+		pred = n.Left
+		for pred.Right != nil {
+			pred = pred.Right
+		}
+	}
+	return pred
+}
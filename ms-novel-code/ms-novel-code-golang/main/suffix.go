@@ -0,0 +1,217 @@
+package core
+
+// suffixTerminal marks the end of the text. It is not a byte value, so
+// it cannot clash with any character of the text.
+const suffixTerminal = -1
+
+// suffixNode is a node of a SuffixTree. Its incoming edge is labelled
+// text[start:end]; leaves use end == -1 for "up to the end of the
+// text", which is what lets Ukkonen's algorithm extend every leaf at
+// once.
+type suffixNode struct {
+	start, end int
+	link       int         // suffix link; the root for leaves and the root itself
+	next       map[int]int // first symbol of an outgoing edge -> child index
+	leaves     int         // leaves below this node: suffixes that pass through it
+	depth      int         // length of the string spelled from the root to here
+	first      int         // smallest start position of those suffixes
+}
+
+// SuffixTree indexes every suffix of a text for substring queries: a
+// pattern occurs in the text exactly when it spells a path from the
+// root, so Contains and CountOccurrences take O(m) for a pattern of
+// length m regardless of the text's length. It is built in O(n) with
+// Ukkonen's algorithm and works on bytes. A SuffixTree is immutable and
+// safe for concurrent use.
+type SuffixTree struct {
+	text  []int // the text's bytes followed by suffixTerminal
+	nodes []suffixNode
+}
+
+// NewSuffixTree builds the suffix tree of text.
+func NewSuffixTree(text string) *SuffixTree {
+	t := &SuffixTree{text: make([]int, 0, len(text)+1)}
+	for i := 0; i < len(text); i++ {
+		t.text = append(t.text, int(text[i]))
+	}
+	t.text = append(t.text, suffixTerminal)
+	t.newNode(0, 0) // root
+	t.build()
+	t.annotate()
+	return t
+}
+
+func (t *SuffixTree) newNode(start, end int) int {
+	t.nodes = append(t.nodes, suffixNode{start: start, end: end, next: map[int]int{}})
+	return len(t.nodes) - 1
+}
+
+// edgeEnd returns the exclusive end of node n's incoming edge, given
+// that the text has been processed up to (not including) pos.
+func (t *SuffixTree) edgeEnd(n, pos int) int {
+	if t.nodes[n].end == -1 {
+		return pos
+	}
+	return t.nodes[n].end
+}
+
+// build runs Ukkonen's algorithm. The active point (node, edge, length)
+// marks where the next suffix to be made explicit ends, and remainder
+// counts the suffixes still pending; suffix links move the active point
+// from one suffix to the next in amortised O(1).
+func (t *SuffixTree) build() {
+	const root = 0
+	activeNode, activeEdge, activeLength, remainder := root, 0, 0, 0
+
+	for i, c := range t.text {
+		remainder++
+		lastInternal := -1
+		for remainder > 0 {
+			if activeLength == 0 {
+				activeEdge = i
+			}
+			child, ok := t.nodes[activeNode].next[t.text[activeEdge]]
+			if !ok {
+				leaf := t.newNode(i, -1)
+				t.nodes[activeNode].next[t.text[activeEdge]] = leaf
+				if lastInternal != -1 {
+					t.nodes[lastInternal].link = activeNode
+					lastInternal = -1
+				}
+			} else {
+				edgeLen := t.edgeEnd(child, i+1) - t.nodes[child].start
+				if activeLength >= edgeLen {
+					// Walk down: the active point lies beyond this edge.
+					activeEdge += edgeLen
+					activeLength -= edgeLen
+					activeNode = child
+					continue
+				}
+				if t.text[t.nodes[child].start+activeLength] == c {
+					// c is already there implicitly; finish this phase.
+					if lastInternal != -1 && activeNode != root {
+						t.nodes[lastInternal].link = activeNode
+					}
+					activeLength++
+					break
+				}
+				// Split the edge and hang a new leaf for c off the split.
+				start := t.nodes[child].start
+				split := t.newNode(start, start+activeLength)
+				t.nodes[activeNode].next[t.text[activeEdge]] = split
+				leaf := t.newNode(i, -1)
+				t.nodes[split].next[c] = leaf
+				t.nodes[child].start += activeLength
+				t.nodes[split].next[t.text[t.nodes[child].start]] = child
+				if lastInternal != -1 {
+					t.nodes[lastInternal].link = split
+				}
+				lastInternal = split
+			}
+
+			remainder--
+			if activeNode == root && activeLength > 0 {
+				activeLength--
+				activeEdge = i - remainder + 1
+			} else if activeNode != root {
+				activeNode = t.nodes[activeNode].link
+			}
+		}
+	}
+}
+
+// annotate fills in every node's depth, leaf count and first suffix
+// position with an explicit-stack postorder walk.
+func (t *SuffixTree) annotate() {
+	n := len(t.text)
+	type frame struct {
+		node  int
+		ready bool
+	}
+	stack := []frame{{0, false}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		node := &t.nodes[f.node]
+
+		if !f.ready {
+			stack = append(stack, frame{f.node, true})
+			for _, child := range node.next {
+				c := &t.nodes[child]
+				c.depth = node.depth + t.edgeEnd(child, n) - c.start
+				stack = append(stack, frame{child, false})
+			}
+			continue
+		}
+		if len(node.next) == 0 {
+			node.leaves, node.first = 1, n-node.depth
+			continue
+		}
+		node.first = n
+		for _, child := range node.next {
+			node.leaves += t.nodes[child].leaves
+			node.first = min(node.first, t.nodes[child].first)
+		}
+	}
+}
+
+// locate returns the node at or just below the end of pattern's path
+// from the root, or false if pattern does not occur.
+func (t *SuffixTree) locate(pattern string) (int, bool) {
+	node, i := 0, 0
+	for i < len(pattern) {
+		child, ok := t.nodes[node].next[int(pattern[i])]
+		if !ok {
+			return 0, false
+		}
+		end := t.edgeEnd(child, len(t.text))
+		for j := t.nodes[child].start; j < end && i < len(pattern); j, i = j+1, i+1 {
+			if t.text[j] != int(pattern[i]) {
+				return 0, false
+			}
+		}
+		node = child
+	}
+	return node, true
+}
+
+// Contains reports whether pattern occurs in the text. The empty
+// pattern occurs in every text.
+func (t *SuffixTree) Contains(pattern string) bool {
+	_, ok := t.locate(pattern)
+	return ok
+}
+
+// CountOccurrences returns the number of positions at which pattern
+// occurs in the text, overlapping occurrences included. The empty
+// pattern occurs at every position, including the end: len(text)+1.
+func (t *SuffixTree) CountOccurrences(pattern string) int {
+	node, ok := t.locate(pattern)
+	if !ok {
+		return 0
+	}
+	return t.nodes[node].leaves
+}
+
+// LongestRepeatedSubstring returns the longest substring that occurs at
+// least twice in the text (occurrences may overlap), or "" if no
+// character repeats. Among several of the same length it returns the
+// one that occurs first. The answer is the deepest internal node.
+func (t *SuffixTree) LongestRepeatedSubstring() string {
+	best := 0
+	for i := 1; i < len(t.nodes); i++ {
+		n, b := &t.nodes[i], &t.nodes[best]
+		if len(n.next) == 0 {
+			continue
+		}
+		if n.depth > b.depth || (n.depth == b.depth && n.first < b.first) {
+			best = i
+		}
+	}
+	b := t.nodes[best]
+	out := make([]byte, b.depth)
+	for i := range out {
+		out[i] = byte(t.text[b.first+i])
+	}
+	return string(out)
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+)
+
+// svgConfig holds the settings applied by SVGOption values.
+type svgConfig struct {
+	label              func(*Node) string
+	radius             float64
+	dx, dy             float64
+	fill, stroke, text string
+}
+
+// SVGOption customises the output of RenderSVG.
+type SVGOption func(*svgConfig)
+
+// WithSVGLabel sets the function used to label each node.
+// By default a node is labelled with its value.
+func WithSVGLabel(label func(*Node) string) SVGOption {
+	return func(c *svgConfig) { c.label = label }
+}
+
+// WithSVGNodeRadius sets the radius of the node circles (default 16).
+func WithSVGNodeRadius(r float64) SVGOption {
+	return func(c *svgConfig) { c.radius = r }
+}
+
+// WithSVGSpacing sets the minimum horizontal distance between nodes on
+// the same level and the vertical distance between levels (defaults 40
+// and 60).
+func WithSVGSpacing(dx, dy float64) SVGOption {
+	return func(c *svgConfig) { c.dx, c.dy = dx, dy }
+}
+
+// WithSVGColors sets the node fill, the node outline and edge colour,
+// and the label colour, as SVG colour strings (defaults "white",
+// "#333333" and "black").
+func WithSVGColors(fill, stroke, text string) SVGOption {
+	return func(c *svgConfig) { c.fill, c.stroke, c.text = fill, stroke, text }
+}
+
+// RenderSVG draws the tree as a standalone SVG document on w, with no
+// external tools needed. Nodes are placed with the Reingold–Tilford
+// algorithm: subtrees are drawn independently, then pushed together as
+// closely as their outlines allow, and each parent is centred over its
+// children. The drawing is tidy: a subtree looks the same wherever it
+// appears, mirrored subtrees are drawn as mirror images, and a lone
+// child leans to its side. A nil tree produces an empty drawing.
+func RenderSVG(root *Node, w io.Writer, opts ...SVGOption) error {
+	cfg := svgConfig{
+		label:  func(n *Node) string { return strconv.Itoa(n.Val) },
+		radius: 16,
+		dx:     40,
+		dy:     60,
+		fill:   "white",
+		stroke: "#333333",
+		text:   "black",
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	xs := layoutTree(root)
+	width, height := 0.0, 0.0
+	pos := func(n *Node, depth int) (float64, float64) {
+		return cfg.radius + xs[n]*cfg.dx, cfg.radius + float64(depth)*cfg.dy
+	}
+	walkLevels(root, func(depth int, level []*Node) bool {
+		for _, n := range level {
+			x, y := pos(n, depth)
+			width, height = max(width, x+cfg.radius), max(height, y+cfg.radius)
+		}
+		return true
+	})
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %[1]s %[2]s">`+"\n",
+		svgNum(width), svgNum(height))
+	fmt.Fprintf(bw, `<g stroke="%s" stroke-width="1.5">`+"\n", html.EscapeString(cfg.stroke))
+	walkLevels(root, func(depth int, level []*Node) bool {
+		for _, n := range level {
+			x1, y1 := pos(n, depth)
+			for _, c := range []*Node{n.Left, n.Right} {
+				if c != nil {
+					x2, y2 := pos(c, depth+1)
+					fmt.Fprintf(bw, `<line x1="%s" y1="%s" x2="%s" y2="%s"/>`+"\n",
+						svgNum(x1), svgNum(y1), svgNum(x2), svgNum(y2))
+				}
+			}
+		}
+		return true
+	})
+	fmt.Fprintln(bw, "</g>")
+
+	fmt.Fprintf(bw, `<g font-family="sans-serif" font-size="%s" text-anchor="middle" dominant-baseline="central">`+"\n",
+		svgNum(cfg.radius))
+	walkLevels(root, func(depth int, level []*Node) bool {
+		for _, n := range level {
+			x, y := pos(n, depth)
+			fmt.Fprintf(bw, `<circle cx="%s" cy="%s" r="%s" fill="%s" stroke="%s"/>`+"\n",
+				svgNum(x), svgNum(y), svgNum(cfg.radius), html.EscapeString(cfg.fill), html.EscapeString(cfg.stroke))
+			fmt.Fprintf(bw, `<text x="%s" y="%s" fill="%s">%s</text>`+"\n",
+				svgNum(x), svgNum(y), html.EscapeString(cfg.text), html.EscapeString(cfg.label(n)))
+		}
+		return true
+	})
+	fmt.Fprintln(bw, "</g>")
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+func svgNum(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// rtContour is the outline of a laid-out subtree: the leftmost and
+// rightmost x at each depth, relative to the subtree's root. It is
+// stored deepest level first, so a parent adds its own level by
+// appending, and every stored value is offset by base, so moving a
+// whole subtree is a single addition. With the parent reusing its
+// taller child's contour, each merge costs only the shorter child's
+// height.
+type rtContour struct {
+	lo, hi []float64 // deepest first; true x is value + base
+	base   float64
+}
+
+func (c *rtContour) height() int            { return len(c.lo) }
+func (c *rtContour) loAt(depth int) float64 { return c.lo[len(c.lo)-1-depth] + c.base }
+func (c *rtContour) hiAt(depth int) float64 { return c.hi[len(c.hi)-1-depth] + c.base }
+
+// layoutTree assigns every node an x coordinate, in units of the minimum
+// node spacing, using the Reingold–Tilford algorithm; a node's depth is
+// its y. The leftmost node is at x 0. The returned map is never nil.
+func layoutTree(root *Node) map[*Node]float64 {
+	xs := map[*Node]float64{}
+	if root == nil {
+		return xs
+	}
+
+	// Postorder: fix each child's offset from its parent.
+	offset := map[*Node]float64{}
+	contours := map[*Node]*rtContour{}
+	for _, n := range postorderNodes(root) {
+		l, r := contours[n.Left], contours[n.Right]
+		delete(contours, n.Left)
+		delete(contours, n.Right)
+
+		var c *rtContour
+		switch {
+		case l == nil && r == nil:
+			c = &rtContour{}
+		case r == nil:
+			offset[n.Left] = -0.5
+			c = l
+			c.base -= 0.5
+		case l == nil:
+			offset[n.Right] = 0.5
+			c = r
+			c.base += 0.5
+		default:
+			// Push the subtrees apart until no level overlaps.
+			gap := 0.0
+			for d := range min(l.height(), r.height()) {
+				gap = max(gap, l.hiAt(d)-r.loAt(d))
+			}
+			half := (gap + 1) / 2
+			offset[n.Left], offset[n.Right] = -half, half
+			l.base -= half
+			r.base += half
+			other := r
+			c = l
+			if r.height() > l.height() {
+				c, other = r, l
+			}
+			for d := range other.height() {
+				i := len(c.lo) - 1 - d
+				c.lo[i] = min(c.loAt(d), other.loAt(d)) - c.base
+				c.hi[i] = max(c.hiAt(d), other.hiAt(d)) - c.base
+			}
+		}
+		c.lo = append(c.lo, -c.base)
+		c.hi = append(c.hi, -c.base)
+		contours[n] = c
+	}
+
+	// Preorder: turn offsets into positions, then shift so the leftmost
+	// node is at 0.
+	left := 0.0
+	for d := range contours[root].height() {
+		left = min(left, contours[root].loAt(d))
+	}
+	xs[root] = -left
+	eachNode(root, func(n *Node) {
+		for _, c := range []*Node{n.Left, n.Right} {
+			if c != nil {
+				xs[c] = xs[n] + offset[c]
+			}
+		}
+	})
+	return xs
+}
@@ -0,0 +1,86 @@
+package core
+
+import "sync"
+
+// SyncTree is a BST that is safe for concurrent use.
+//
+// Reads (Search, Len, RowWiseMax, LevelStats, View) take a shared lock
+// and may run in parallel with each other; writes (Insert, Delete,
+// Update) take an exclusive lock. Every call is therefore atomic: a
+// read observes the tree either entirely before or entirely after any
+// given write, never a partially applied one. Results returned to the
+// caller are copies and stay valid after the lock is released; nodes
+// handed to View are not and must not be retained.
+type SyncTree struct {
+	mu   sync.RWMutex
+	tree BST
+}
+
+// NewSyncTree returns a SyncTree holding vals.
+func NewSyncTree(vals ...int) *SyncTree {
+	t := &SyncTree{}
+	for _, v := range vals {
+		t.tree.Insert(v)
+	}
+	return t
+}
+
+// Insert adds v and reports whether it was not already present.
+func (t *SyncTree) Insert(v int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.Insert(v)
+}
+
+// Delete removes v and reports whether it was present.
+func (t *SyncTree) Delete(v int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.Delete(v)
+}
+
+// Search reports whether v is present.
+func (t *SyncTree) Search(v int) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Search(v)
+}
+
+// Len returns the number of values stored.
+func (t *SyncTree) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Len()
+}
+
+// RowWiseMax returns the maximum value on each level of the tree.
+func (t *SyncTree) RowWiseMax() []int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return rowWiseMax(t.tree.Root)["output"]
+}
+
+// LevelStats returns the per-level statistics of the tree.
+func (t *SyncTree) LevelStats() []LevelStat {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return LevelStats(t.tree.Root)
+}
+
+// View runs fn with the current root under the shared lock, for queries
+// not covered by the methods above. fn must not modify the tree (this
+// includes StrategyMorris, which rewires pointers while it runs) and
+// must not retain root or any node after it returns.
+func (t *SyncTree) View(fn func(root *Node)) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	fn(t.tree.Root)
+}
+
+// Update runs fn with the underlying BST under the exclusive lock, so a
+// batch of changes is applied atomically. fn must not retain the tree.
+func (t *SyncTree) Update(fn func(tree *BST)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fn(&t.tree)
+}
@@ -0,0 +1,164 @@
+package core
+
+import "iter"
+
+// ThreadedNode is a node of a fully threaded binary tree. A nil child
+// pointer is replaced by a thread: an empty Left points to the node's
+// inorder predecessor and an empty Right to its inorder successor, with
+// LeftThread and RightThread telling threads apart from real children.
+// Only the first node's Left and the last node's Right stay nil.
+type ThreadedNode[T any] struct {
+	Val                     T
+	Left, Right             *ThreadedNode[T]
+	LeftThread, RightThread bool
+}
+
+// ThreadedTree wraps the root of a threaded tree. Its traversals follow
+// threads instead of keeping a stack or queue, so they run in O(1)
+// extra memory without allocating, which suits targets where
+// heap-allocated stacks must be avoided.
+type ThreadedTree[T any] struct {
+	Root *ThreadedNode[T]
+}
+
+// NewThreadedTree returns a threaded copy of the tree rooted at root;
+// root itself is not modified. Building the copy uses a stack; only the
+// traversals of the result are stackless.
+func NewThreadedTree[T any](root *TreeNode[T]) *ThreadedTree[T] {
+	if root == nil {
+		return &ThreadedTree[T]{}
+	}
+
+	// Copy the shape first, then thread the copy in one inorder pass.
+	type pair struct {
+		src *TreeNode[T]
+		dst *ThreadedNode[T]
+	}
+	out := &ThreadedNode[T]{Val: root.Val}
+	stack := []pair{{root, out}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if p.src.Left != nil {
+			p.dst.Left = &ThreadedNode[T]{Val: p.src.Left.Val}
+			stack = append(stack, pair{p.src.Left, p.dst.Left})
+		}
+		if p.src.Right != nil {
+			p.dst.Right = &ThreadedNode[T]{Val: p.src.Right.Val}
+			stack = append(stack, pair{p.src.Right, p.dst.Right})
+		}
+	}
+
+	var prev *ThreadedNode[T]
+	var nodes []*ThreadedNode[T]
+	node := out
+	for node != nil || len(nodes) > 0 {
+		for node != nil {
+			nodes = append(nodes, node)
+			node = node.Left
+		}
+		node = nodes[len(nodes)-1]
+		nodes = nodes[:len(nodes)-1]
+		right := node.Right // read before threading can set it
+
+		if prev != nil && prev.Right == nil {
+			prev.Right, prev.RightThread = node, true
+		}
+		if node.Left == nil && prev != nil {
+			node.Left, node.LeftThread = prev, true
+		}
+		prev = node
+		node = right
+	}
+	return &ThreadedTree[T]{Root: out}
+}
+
+// Tree returns an ordinary (unthreaded) copy of the tree.
+func (t *ThreadedTree[T]) Tree() *TreeNode[T] {
+	if t.Root == nil {
+		return nil
+	}
+	type pair struct {
+		src *ThreadedNode[T]
+		dst *TreeNode[T]
+	}
+	out := &TreeNode[T]{Val: t.Root.Val}
+	stack := []pair{{t.Root, out}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if p.src.Left != nil && !p.src.LeftThread {
+			p.dst.Left = &TreeNode[T]{Val: p.src.Left.Val}
+			stack = append(stack, pair{p.src.Left, p.dst.Left})
+		}
+		if p.src.Right != nil && !p.src.RightThread {
+			p.dst.Right = &TreeNode[T]{Val: p.src.Right.Val}
+			stack = append(stack, pair{p.src.Right, p.dst.Right})
+		}
+	}
+	return out
+}
+
+// First returns the first node in inorder, or nil for an empty tree.
+func (t *ThreadedTree[T]) First() *ThreadedNode[T] {
+	n := t.Root
+	for n != nil && n.Left != nil && !n.LeftThread {
+		n = n.Left
+	}
+	return n
+}
+
+// Last returns the last node in inorder, or nil for an empty tree.
+func (t *ThreadedTree[T]) Last() *ThreadedNode[T] {
+	n := t.Root
+	for n != nil && n.Right != nil && !n.RightThread {
+		n = n.Right
+	}
+	return n
+}
+
+// Next returns n's inorder successor, or nil if n is the last node.
+func (n *ThreadedNode[T]) Next() *ThreadedNode[T] {
+	if n.RightThread || n.Right == nil {
+		return n.Right
+	}
+	next := n.Right
+	for next.Left != nil && !next.LeftThread {
+		next = next.Left
+	}
+	return next
+}
+
+// Prev returns n's inorder predecessor, or nil if n is the first node.
+func (n *ThreadedNode[T]) Prev() *ThreadedNode[T] {
+	if n.LeftThread || n.Left == nil {
+		return n.Left
+	}
+	prev := n.Left
+	for prev.Right != nil && !prev.RightThread {
+		prev = prev.Right
+	}
+	return prev
+}
+
+// All returns an iterator over the values in inorder.
+func (t *ThreadedTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := t.First(); n != nil; n = n.Next() {
+			if !yield(n.Val) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values in reverse inorder.
+func (t *ThreadedTree[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := t.Last(); n != nil; n = n.Prev() {
+			if !yield(n.Val) {
+				return
+			}
+		}
+	}
+}
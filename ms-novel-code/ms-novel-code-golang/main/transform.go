@@ -0,0 +1,71 @@
+package core
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Invert mirrors the tree in place by swapping every node's children,
+// and returns root for convenience. It uses an explicit stack, so deep
+// trees are safe.
+func Invert[T any](root *TreeNode[T]) *TreeNode[T] {
+	if root == nil {
+		return nil
+	}
+	stack := []*TreeNode[T]{root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		node.Left, node.Right = node.Right, node.Left
+		if node.Left != nil {
+			stack = append(stack, node.Left)
+		}
+		if node.Right != nil {
+			stack = append(stack, node.Right)
+		}
+	}
+	return root
+}
+
+// CloneInverted returns a mirrored copy of the tree, leaving the
+// original untouched.
+func CloneInverted[T any](root *TreeNode[T]) *TreeNode[T] {
+	if root == nil {
+		return nil
+	}
+	type pair struct{ src, dst *TreeNode[T] }
+
+	out := &TreeNode[T]{Val: root.Val}
+	stack := []pair{{root, out}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if p.src.Left != nil {
+			p.dst.Right = &TreeNode[T]{Val: p.src.Left.Val}
+			stack = append(stack, pair{p.src.Left, p.dst.Right})
+		}
+		if p.src.Right != nil {
+			p.dst.Left = &TreeNode[T]{Val: p.src.Right.Val}
+			stack = append(stack, pair{p.src.Right, p.dst.Left})
+		}
+	}
+	return out
+}
+
+// ConvertToBST reassigns the tree's values in place so that it becomes
+// a binary search tree without changing its shape: the values are
+// sorted and written back in inorder. It returns root for convenience.
+// Duplicate values stay duplicated, so the result satisfies ValidateBST
+// only when the values are distinct.
+func ConvertToBST[T cmp.Ordered](root *TreeNode[T]) *TreeNode[T] {
+	vals := Inorder(root)
+	slices.Sort(vals)
+	i := 0
+	VisitInorder(root, func(n *TreeNode[T]) {
+		n.Val = vals[i]
+		i++
+	})
+	return root
+}
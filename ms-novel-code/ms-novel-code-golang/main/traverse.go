@@ -0,0 +1,177 @@
+package core
+
+import "fmt"
+
+// TraversalOrder selects the order in which a traversal visits nodes.
+type TraversalOrder int
+
+const (
+	OrderPre   TraversalOrder = iota // node, left, right
+	OrderIn                          // left, node, right
+	OrderPost                        // left, right, node
+	OrderLevel                       // level by level, left-to-right
+)
+
+// String returns the order's name, e.g. "preorder".
+func (o TraversalOrder) String() string {
+	switch o {
+	case OrderPre:
+		return "preorder"
+	case OrderIn:
+		return "inorder"
+	case OrderPost:
+		return "postorder"
+	case OrderLevel:
+		return "level-order"
+	}
+	return fmt.Sprintf("TraversalOrder(%d)", int(o))
+}
+
+// valid reports whether o is one of the defined orders.
+func (o TraversalOrder) valid() bool {
+	return o >= OrderPre && o <= OrderLevel
+}
+
+// Preorder returns the tree's values in node-left-right order.
+// The returned slice is never nil, even for an empty tree.
+func Preorder[T any](root *TreeNode[T]) []T {
+	return collectDepthFirst(root, OrderPre)
+}
+
+// Inorder returns the tree's values in left-node-right order, which is
+// ascending order for a binary search tree.
+// The returned slice is never nil, even for an empty tree.
+func Inorder[T any](root *TreeNode[T]) []T {
+	return collectDepthFirst(root, OrderIn)
+}
+
+// Postorder returns the tree's values in left-right-node order.
+// The returned slice is never nil, even for an empty tree.
+func Postorder[T any](root *TreeNode[T]) []T {
+	return collectDepthFirst(root, OrderPost)
+}
+
+// VisitPreorder calls visit for every node in node-left-right order.
+func VisitPreorder[T any](root *TreeNode[T], visit func(*TreeNode[T])) {
+	walkDepthFirst(root, OrderPre, func(n *TreeNode[T], _ int) bool { visit(n); return true })
+}
+
+// VisitInorder calls visit for every node in left-node-right order.
+func VisitInorder[T any](root *TreeNode[T], visit func(*TreeNode[T])) {
+	walkDepthFirst(root, OrderIn, func(n *TreeNode[T], _ int) bool { visit(n); return true })
+}
+
+// VisitPostorder calls visit for every node in left-right-node order.
+func VisitPostorder[T any](root *TreeNode[T], visit func(*TreeNode[T])) {
+	walkDepthFirst(root, OrderPost, func(n *TreeNode[T], _ int) bool { visit(n); return true })
+}
+
+// Walk calls fn with each node and its depth (0 for the root) in the
+// given order, stopping as soon as fn returns false; the rest of the
+// tree is not visited. It panics if order is not a defined
+// TraversalOrder.
+func Walk[T any](root *TreeNode[T], order TraversalOrder, fn func(n *TreeNode[T], depth int) bool) {
+	walkOrder(root, order, fn)
+}
+
+// Find returns the first node, in the given order, for which match
+// reports true, or nil if there is none. The walk stops at the match.
+func Find[T any](root *TreeNode[T], order TraversalOrder, match func(*TreeNode[T]) bool) *TreeNode[T] {
+	var found *TreeNode[T]
+	walkOrder(root, order, func(n *TreeNode[T], _ int) bool {
+		if match(n) {
+			found = n
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// walkOrder calls visit with each node and its depth in the given order
+// until visit returns false, and reports whether the walk ran to
+// completion. It panics if order is not a defined TraversalOrder.
+func walkOrder[T any](root *TreeNode[T], order TraversalOrder, visit func(n *TreeNode[T], depth int) bool) bool {
+	switch order {
+	case OrderPre, OrderIn, OrderPost:
+		return walkDepthFirst(root, order, visit)
+	case OrderLevel:
+		completed := true
+		walkLevels(root, func(depth int, level []*TreeNode[T]) bool {
+			for _, n := range level {
+				if !visit(n, depth) {
+					completed = false
+					return false
+				}
+			}
+			return true
+		})
+		return completed
+	}
+	panic(fmt.Sprintf("core: unknown %v", order))
+}
+
+func collectDepthFirst[T any](root *TreeNode[T], order TraversalOrder) []T {
+	res := []T{}
+	walkDepthFirst(root, order, func(n *TreeNode[T], _ int) bool {
+		res = append(res, n.Val)
+		return true
+	})
+	return res
+}
+
+// walkDepthFirst is the depth-first core shared by the traversal
+// functions. It calls visit with each node and its depth in the given
+// order (OrderPre, OrderIn, or OrderPost) until visit returns false,
+// and reports whether the walk ran to completion. It keeps an explicit
+// stack instead of recursing, so skewed trees with millions of nodes
+// cannot overflow the goroutine stack.
+func walkDepthFirst[T any](root *TreeNode[T], order TraversalOrder, visit func(n *TreeNode[T], depth int) bool) bool {
+	if root == nil {
+		return true
+	}
+
+	// A frame is first expanded into its children and the node itself;
+	// the node's own frame is marked ready and visited when popped.
+	type frame struct {
+		node  *TreeNode[T]
+		depth int
+		ready bool
+	}
+	stack := []frame{{node: root}}
+	push := func(n *TreeNode[T], depth int) {
+		if n != nil {
+			stack = append(stack, frame{node: n, depth: depth})
+		}
+	}
+
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if f.ready || order == OrderPre {
+			if !visit(f.node, f.depth) {
+				return false
+			}
+			if f.ready {
+				continue
+			}
+		}
+
+		// Frames are pushed in reverse of the order they are popped.
+		switch order {
+		case OrderPre:
+			push(f.node.Right, f.depth+1)
+			push(f.node.Left, f.depth+1)
+		case OrderIn:
+			push(f.node.Right, f.depth+1)
+			stack = append(stack, frame{f.node, f.depth, true})
+			push(f.node.Left, f.depth+1)
+		case OrderPost:
+			stack = append(stack, frame{f.node, f.depth, true})
+			push(f.node.Right, f.depth+1)
+			push(f.node.Left, f.depth+1)
+		}
+	}
+	return true
+}
@@ -0,0 +1,242 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+)
+
+// treapNode is a BST node that is also heap-ordered by a random priority.
+type treapNode struct {
+	val         int
+	priority    uint64
+	left, right *treapNode
+	size        int // nodes in this subtree
+}
+
+// Treap is a randomized balanced binary search tree: keys are in BST
+// order and random priorities are in max-heap order, which keeps the
+// expected height O(log n). Besides the SearchTree operations it
+// supports Split and Merge, which cut and join whole key ranges in
+// O(log n).
+type Treap struct {
+	root *treapNode
+}
+
+var _ SearchTree = (*Treap)(nil)
+
+// ErrTreapOverlap is returned by Merge when the key ranges of the two
+// treaps overlap.
+var ErrTreapOverlap = errors.New("treap merge: key ranges overlap")
+
+// NewTreap returns a Treap holding vals.
+func NewTreap(vals ...int) *Treap {
+	t := &Treap{}
+	for _, v := range vals {
+		t.Insert(v)
+	}
+	return t
+}
+
+// Insert adds v and reports whether it was not already present.
+func (t *Treap) Insert(v int) bool {
+	if t.Search(v) {
+		return false
+	}
+	left, right := treapSplit(t.root, v)
+	node := &treapNode{val: v, priority: rand.Uint64(), size: 1}
+	t.root = treapMerge(treapMerge(left, node), right)
+	return true
+}
+
+// Delete removes v and reports whether it was present.
+func (t *Treap) Delete(v int) bool {
+	if !t.Search(v) {
+		return false
+	}
+	left, right := treapSplit(t.root, v)
+	t.root = treapMerge(left, treapDeleteMin(right)) // v is right's minimum
+	return true
+}
+
+// Search reports whether v is present.
+func (t *Treap) Search(v int) bool {
+	node := t.root
+	for node != nil {
+		switch {
+		case v < node.val:
+			node = node.left
+		case v > node.val:
+			node = node.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Min returns the smallest value, or false if the treap is empty.
+func (t *Treap) Min() (int, bool) {
+	if t.root == nil {
+		return 0, false
+	}
+	node := t.root
+	for node.left != nil {
+		node = node.left
+	}
+	return node.val, true
+}
+
+// Max returns the largest value, or false if the treap is empty.
+func (t *Treap) Max() (int, bool) {
+	if t.root == nil {
+		return 0, false
+	}
+	node := t.root
+	for node.right != nil {
+		node = node.right
+	}
+	return node.val, true
+}
+
+// InOrder returns all values in ascending order.
+func (t *Treap) InOrder() []int {
+	res := make([]int, 0, t.Len())
+	for v := range t.All() {
+		res = append(res, v)
+	}
+	return res
+}
+
+// All returns an iterator over the values in ascending order.
+func (t *Treap) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		var stack []*treapNode
+		node := t.root
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.left
+			}
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(node.val) {
+				return
+			}
+			node = node.right
+		}
+	}
+}
+
+// Len returns the number of values stored.
+func (t *Treap) Len() int {
+	return treapSize(t.root)
+}
+
+// KthSmallest returns the k-th smallest value (1-based) in O(log n)
+// expected time, using the subtree sizes the treap maintains.
+func (t *Treap) KthSmallest(k int) (int, error) {
+	if k < 1 || k > t.Len() {
+		return 0, fmt.Errorf("%w: k=%d, size %d", ErrKOutOfRange, k, t.Len())
+	}
+	node := t.root
+	for {
+		left := treapSize(node.left)
+		switch {
+		case k <= left:
+			node = node.left
+		case k == left+1:
+			return node.val, nil
+		default:
+			k -= left + 1
+			node = node.right
+		}
+	}
+}
+
+// KthLargest returns the k-th largest value (1-based) in O(log n)
+// expected time.
+func (t *Treap) KthLargest(k int) (int, error) {
+	if k < 1 || k > t.Len() {
+		return 0, fmt.Errorf("%w: k=%d, size %d", ErrKOutOfRange, k, t.Len())
+	}
+	return t.KthSmallest(t.Len() - k + 1)
+}
+
+// Split moves every value >= key into a new treap, which it returns;
+// values < key stay in t.
+func (t *Treap) Split(key int) *Treap {
+	left, right := treapSplit(t.root, key)
+	t.root = left
+	return &Treap{root: right}
+}
+
+// Merge moves every value of other into t, leaving other empty. All of
+// other's values must be greater than all of t's; otherwise Merge
+// returns ErrTreapOverlap and changes nothing.
+func (t *Treap) Merge(other *Treap) error {
+	if hi, ok := t.Max(); ok {
+		if lo, ok := other.Min(); ok && lo <= hi {
+			return ErrTreapOverlap
+		}
+	}
+	t.root = treapMerge(t.root, other.root)
+	other.root = nil
+	return nil
+}
+
+func treapSize(n *treapNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func treapUpdate(n *treapNode) {
+	n.size = 1 + treapSize(n.left) + treapSize(n.right)
+}
+
+// treapSplit divides n into the nodes with values < key and >= key.
+func treapSplit(n *treapNode, key int) (left, right *treapNode) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.val < key {
+		n.right, right = treapSplit(n.right, key)
+		treapUpdate(n)
+		return n, right
+	}
+	left, n.left = treapSplit(n.left, key)
+	treapUpdate(n)
+	return left, n
+}
+
+// treapDeleteMin removes the smallest value from a non-empty treap.
+func treapDeleteMin(n *treapNode) *treapNode {
+	if n.left == nil {
+		return n.right
+	}
+	n.left = treapDeleteMin(n.left)
+	treapUpdate(n)
+	return n
+}
+
+// treapMerge joins two treaps where every value in a is less than every
+// value in b.
+func treapMerge(a, b *treapNode) *treapNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		a.right = treapMerge(a.right, b)
+		treapUpdate(a)
+		return a
+	}
+	b.left = treapMerge(a, b.left)
+	treapUpdate(b)
+	return b
+}
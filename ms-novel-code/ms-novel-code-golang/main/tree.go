@@ -0,0 +1,8 @@
+package core
+
+// Tree wraps the root of an int tree so that whole trees can carry
+// methods of their own, such as encodings. A Tree with a nil Root is the
+// empty tree.
+type Tree struct {
+	Root *Node
+}
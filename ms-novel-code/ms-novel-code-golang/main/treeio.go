@@ -0,0 +1,299 @@
+package core
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format selects the text encoding used by ReadTree and WriteTree.
+type Format int
+
+const (
+	// FormatLevelOrder has one level-order entry per line: an integer,
+	// or "null" ("nil" and "#" are also read) for a missing child, as in
+	// ParseLevelOrder. Trailing nulls are omitted and blank lines are
+	// ignored.
+	FormatLevelOrder Format = iota
+	// FormatParen writes a node as its value followed, if it has
+	// children, by "(left,right)" with an empty string for a missing
+	// child: 1(2(,4),3). Whitespace between tokens is ignored, and the
+	// empty tree is the empty string.
+	FormatParen
+)
+
+// String returns the format's name.
+func (f Format) String() string {
+	switch f {
+	case FormatLevelOrder:
+		return "FormatLevelOrder"
+	case FormatParen:
+		return "FormatParen"
+	}
+	return "Format(" + strconv.Itoa(int(f)) + ")"
+}
+
+// ReadTree decodes one tree in the given format from r. Unlike
+// ParseLevelOrder and Deserialize it never holds the whole input in
+// memory: it reads through a small buffer and builds the tree as it
+// goes, so multi-gigabyte dumps cost only the tree itself. Errors from r
+// are returned wrapped; malformed input gives an error naming the line
+// or byte offset.
+func ReadTree(r io.Reader, format Format) (*Node, error) {
+	switch format {
+	case FormatLevelOrder:
+		return readLevelOrder(r)
+	case FormatParen:
+		return readParen(bufio.NewReader(r))
+	}
+	return nil, fmt.Errorf("read tree: unknown %v", format)
+}
+
+// WriteTree encodes the tree in the given format to w, streaming the
+// output through a small buffer rather than building it in memory. The
+// output ends with a newline unless the tree is empty.
+func WriteTree(w io.Writer, root *Node, format Format) error {
+	bw := bufio.NewWriter(w)
+	switch format {
+	case FormatLevelOrder:
+		writeLevelOrder(bw, root)
+	case FormatParen:
+		writeParen(bw, root)
+	default:
+		return fmt.Errorf("write tree: unknown %v", format)
+	}
+	return bw.Flush()
+}
+
+func readLevelOrder(r io.Reader) (*Node, error) {
+	var root *Node
+	var slots ringQueue[**Node] // children still to be read, in level order
+	slots.Push(&root)
+
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		tok := strings.TrimSpace(sc.Text())
+		isNull := false
+		switch tok {
+		case "":
+			continue
+		case "null", "nil", nullMarker:
+			isNull = true
+		}
+		if slots.Len() == 0 {
+			if isNull {
+				continue
+			}
+			return nil, fmt.Errorf("read tree: line %d: value %q after the tree is complete", line, tok)
+		}
+		slot := slots.Pop()
+		if isNull {
+			continue
+		}
+		v, err := strconv.Atoi(tok)
+		if err != nil {
+			return nil, fmt.Errorf("read tree: line %d: invalid value %q", line, tok)
+		}
+		node := &Node{Val: v}
+		*slot = node
+		slots.Push(&node.Left)
+		slots.Push(&node.Right)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read tree: %w", err)
+	}
+	return root, nil
+}
+
+func writeLevelOrder(w *bufio.Writer, root *Node) {
+	if root == nil {
+		return
+	}
+	w.WriteString(strconv.Itoa(root.Val) + "\n")
+	nulls := 0 // held back until a value follows, so trailing nulls are dropped
+	walkLevels(root, func(_ int, level []*Node) bool {
+		for _, n := range level {
+			for _, c := range []*Node{n.Left, n.Right} {
+				if c == nil {
+					nulls++
+					continue
+				}
+				for ; nulls > 0; nulls-- {
+					w.WriteString("null\n")
+				}
+				w.WriteString(strconv.Itoa(c.Val) + "\n")
+			}
+		}
+		return true
+	})
+}
+
+// parenReader tracks the byte offset for error messages.
+type parenReader struct {
+	r   *bufio.Reader
+	off int64
+}
+
+// peek skips whitespace and returns the next byte without consuming
+// it, or -1 at the end of the input.
+func (p *parenReader) peek() (int, error) {
+	for {
+		b, err := p.r.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return -1, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("read tree: %w", err)
+		}
+		if b != ' ' && b != '\t' && b != '\n' && b != '\r' {
+			p.r.UnreadByte()
+			return int(b), nil
+		}
+		p.off++
+	}
+}
+
+func (p *parenReader) expect(want byte) error {
+	b, err := p.peek()
+	if err != nil {
+		return err
+	}
+	if b != int(want) {
+		return p.errorf("expected %q, found %s", want, describeByte(b))
+	}
+	p.r.ReadByte()
+	p.off++
+	return nil
+}
+
+func (p *parenReader) errorf(format string, args ...any) error {
+	return fmt.Errorf("read tree: offset %d: "+format, append([]any{p.off}, args...)...)
+}
+
+func describeByte(b int) string {
+	if b < 0 {
+		return "end of input"
+	}
+	return strconv.QuoteRune(rune(b))
+}
+
+// value reads the integer starting at the next byte.
+func (p *parenReader) value() (int, error) {
+	start := p.off
+	var sb strings.Builder
+	for {
+		b, err := p.r.ReadByte()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("read tree: %w", err)
+		}
+		if b != '-' && b != '+' && (b < '0' || b > '9') {
+			p.r.UnreadByte()
+			break
+		}
+		sb.WriteByte(b)
+		p.off++
+	}
+	v, err := strconv.Atoi(sb.String())
+	if err != nil {
+		return 0, fmt.Errorf("read tree: offset %d: invalid value %q", start, sb.String())
+	}
+	return v, nil
+}
+
+func readParen(r *bufio.Reader) (*Node, error) {
+	p := &parenReader{r: r}
+	var root *Node
+	// open holds the nodes whose "(" has been read; onRight says whether
+	// their "," has been read too.
+	type frame struct {
+		node    *Node
+		onRight bool
+	}
+	var open []frame
+	slot := &root
+	for {
+		// Read the subtree for slot: a value, or nothing if it is empty.
+		b, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+		if b == '-' || b == '+' || (b >= '0' && b <= '9') {
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			node := &Node{Val: v}
+			*slot = node
+			if b, err = p.peek(); err != nil {
+				return nil, err
+			}
+			if b == '(' {
+				p.r.ReadByte()
+				p.off++
+				open = append(open, frame{node: node})
+				slot = &node.Left
+				continue
+			}
+		}
+
+		// The subtree is complete: close parents until one still needs
+		// its right child.
+		for {
+			if len(open) == 0 {
+				b, err := p.peek()
+				if err != nil {
+					return nil, err
+				}
+				if b >= 0 {
+					return nil, p.errorf("unexpected %s after the tree", describeByte(b))
+				}
+				return root, nil
+			}
+			top := &open[len(open)-1]
+			if !top.onRight {
+				if err := p.expect(','); err != nil {
+					return nil, err
+				}
+				top.onRight = true
+				slot = &top.node.Right
+				break
+			}
+			if err := p.expect(')'); err != nil {
+				return nil, err
+			}
+			open = open[:len(open)-1]
+		}
+	}
+}
+
+func writeParen(w *bufio.Writer, root *Node) {
+	if root == nil {
+		return
+	}
+	// Each item is either a node to write or punctuation.
+	type item struct {
+		node *Node
+		text string
+	}
+	stack := []item{{node: root}}
+	for len(stack) > 0 {
+		it := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if it.node == nil {
+			w.WriteString(it.text)
+			continue
+		}
+		w.WriteString(strconv.Itoa(it.node.Val))
+		if it.node.Left != nil || it.node.Right != nil {
+			w.WriteByte('(')
+			stack = append(stack, item{text: ")"}, item{node: it.node.Right}, item{text: ","}, item{node: it.node.Left})
+		}
+	}
+	w.WriteByte('\n')
+}
@@ -0,0 +1,141 @@
+package core
+
+import "sort"
+
+// trieNode is one rune-labelled position in a Trie.
+type trieNode struct {
+	children map[rune]*trieNode
+	terminal bool // a word ends here
+}
+
+// Trie is a prefix tree over strings, keyed by rune. The zero value is
+// an empty trie ready to use.
+type Trie struct {
+	root trieNode
+	size int
+}
+
+// NewTrie returns a Trie holding words.
+func NewTrie(words ...string) *Trie {
+	t := &Trie{}
+	for _, w := range words {
+		t.Insert(w)
+	}
+	return t
+}
+
+// Insert adds word and reports whether it was not already present.
+// The empty string is a valid word.
+func (t *Trie) Insert(word string) bool {
+	node := &t.root
+	for _, r := range word {
+		child := node.children[r]
+		if child == nil {
+			if node.children == nil {
+				node.children = map[rune]*trieNode{}
+			}
+			child = &trieNode{}
+			node.children[r] = child
+		}
+		node = child
+	}
+	if node.terminal {
+		return false
+	}
+	node.terminal = true
+	t.size++
+	return true
+}
+
+// Contains reports whether word was inserted.
+func (t *Trie) Contains(word string) bool {
+	node := t.find(word)
+	return node != nil && node.terminal
+}
+
+// HasPrefix reports whether any inserted word starts with prefix.
+func (t *Trie) HasPrefix(prefix string) bool {
+	node := t.find(prefix)
+	return node != nil && (node.terminal || len(node.children) > 0)
+}
+
+// Delete removes word and reports whether it was present. Branches left
+// without any words are pruned.
+func (t *Trie) Delete(word string) bool {
+	type step struct {
+		parent *trieNode
+		r      rune
+	}
+	var path []step
+	node := &t.root
+	for _, r := range word {
+		child := node.children[r]
+		if child == nil {
+			return false
+		}
+		path = append(path, step{node, r})
+		node = child
+	}
+	if !node.terminal {
+		return false
+	}
+	node.terminal = false
+	t.size--
+
+	// Walk back up, unlinking nodes that no longer lead to a word.
+	for i := len(path) - 1; i >= 0; i-- {
+		if node.terminal || len(node.children) > 0 {
+			break
+		}
+		delete(path[i].parent.children, path[i].r)
+		node = path[i].parent
+	}
+	return true
+}
+
+// WordsWithPrefix returns every inserted word starting with prefix, in
+// lexicographic order. The returned slice is never nil.
+func (t *Trie) WordsWithPrefix(prefix string) []string {
+	words := []string{}
+	start := t.find(prefix)
+	if start == nil {
+		return words
+	}
+
+	type frame struct {
+		node *trieNode
+		word []rune
+	}
+	stack := []frame{{start, []rune(prefix)}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if f.node.terminal {
+			words = append(words, string(f.word))
+		}
+		for r, child := range f.node.children {
+			word := append(f.word[:len(f.word):len(f.word)], r)
+			stack = append(stack, frame{child, word})
+		}
+	}
+	sort.Strings(words)
+	return words
+}
+
+// Len returns the number of words stored.
+func (t *Trie) Len() int {
+	return t.size
+}
+
+// find returns the node reached by following s, or nil.
+func (t *Trie) find(s string) *trieNode {
+	node := &t.root
+	for _, r := range s {
+		node = node.children[r]
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
@@ -0,0 +1,72 @@
+package core
+
+import "fmt"
+
+// ViolationKind says which side of its allowed range a node fell on.
+type ViolationKind int
+
+const (
+	// BelowMin: the node is not greater than an ancestor it sits to the
+	// right of.
+	BelowMin ViolationKind = iota
+	// AboveMax: the node is not less than an ancestor it sits to the
+	// left of.
+	AboveMax
+)
+
+// Violation describes one node that breaks the BST invariant.
+type Violation struct {
+	Node     *Node         // the offending node
+	Kind     ViolationKind // which bound was exceeded
+	Bound    int           // the exclusive bound that was exceeded
+	Ancestor *Node         // the ancestor that imposes Bound
+}
+
+// String explains the violation, e.g.
+// "node 7 is not less than 5 (it is in the left subtree of 5)".
+func (v Violation) String() string {
+	if v.Kind == BelowMin {
+		return fmt.Sprintf("node %d is not greater than %d (it is in the right subtree of %d)",
+			v.Node.Val, v.Bound, v.Ancestor.Val)
+	}
+	return fmt.Sprintf("node %d is not less than %d (it is in the left subtree of %d)",
+		v.Node.Val, v.Bound, v.Ancestor.Val)
+}
+
+// ValidateBST checks the BST invariant used by BST and AVL: every value
+// in a node's left subtree is strictly less than the node's value and
+// every value in its right subtree strictly greater. Instead of stopping
+// at the first problem it reports every offending node, in preorder,
+// together with the ancestor whose bound it exceeds. Subtrees below an
+// offending node are still checked against the bounds their position
+// implies. The returned slice is never nil.
+func ValidateBST(root *Node) (bool, []Violation) {
+	violations := []Violation{}
+	if root == nil {
+		return true, violations
+	}
+
+	type frame struct {
+		node   *Node
+		lo, hi *Node // ancestors bounding the node, nil when unbounded
+	}
+	stack := []frame{{node: root}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if f.lo != nil && f.node.Val <= f.lo.Val {
+			violations = append(violations, Violation{f.node, BelowMin, f.lo.Val, f.lo})
+		}
+		if f.hi != nil && f.node.Val >= f.hi.Val {
+			violations = append(violations, Violation{f.node, AboveMax, f.hi.Val, f.hi})
+		}
+		if f.node.Right != nil {
+			stack = append(stack, frame{f.node.Right, f.node, f.hi})
+		}
+		if f.node.Left != nil {
+			stack = append(stack, frame{f.node.Left, f.lo, f.node})
+		}
+	}
+	return len(violations) == 0, violations
+}
@@ -0,0 +1,108 @@
+package core
+
+import "cmp"
+
+// VerticalOrder groups node values by horizontal distance from the root
+// (left child = column-1, right child = column+1), returning the columns
+// left-to-right. Within a column, values appear in level order, so upper
+// nodes come first and nodes sharing a level keep their left-to-right
+// order. The returned slice is never nil, even for an empty tree.
+func VerticalOrder[T any](root *TreeNode[T]) [][]T {
+	res := [][]T{}
+	if root == nil {
+		return res
+	}
+
+	type item struct {
+		node *TreeNode[T]
+		col  int
+	}
+
+	var (
+		columns = map[int][]T{}
+		minCol  int
+		maxCol  int
+		queue   ringQueue[item]
+	)
+	queue.Push(item{root, 0})
+
+	for queue.Len() > 0 {
+		it := queue.Pop()
+
+		columns[it.col] = append(columns[it.col], it.node.Val)
+		minCol, maxCol = min(minCol, it.col), max(maxCol, it.col)
+
+		if it.node.Left != nil {
+			queue.Push(item{it.node.Left, it.col - 1})
+		}
+		if it.node.Right != nil {
+			queue.Push(item{it.node.Right, it.col + 1})
+		}
+	}
+
+	for col := minCol; col <= maxCol; col++ {
+		res = append(res, columns[col])
+	}
+	return res
+}
+
+// ColumnWiseMax returns the maximum value in each vertical column,
+// left-to-right; it is the column counterpart of rowWiseMax.
+// The returned slice is never nil, even for an empty tree.
+func ColumnWiseMax[T cmp.Ordered](root *TreeNode[T]) []T {
+	res := []T{}
+	for _, col := range VerticalOrder(root) {
+		res = append(res, maxOf(col))
+	}
+	return res
+}
+
+// maxOf returns the largest element of a non-empty slice.
+func maxOf[T cmp.Ordered](vals []T) T {
+	best := vals[0]
+	for _, v := range vals[1:] {
+		best = max(best, v)
+	}
+	return best
+}
+
+// DiagonalOrder groups node values by diagonal: moving to a right child
+// stays on the same diagonal and moving to a left child moves to the
+// next one, so diagonal 0 is the root's right spine. Within a diagonal,
+// values are listed chain by chain in the order the chains start, each
+// chain top-down. The returned slice is never nil, even for an empty tree.
+func DiagonalOrder[T any](root *TreeNode[T]) [][]T {
+	res := [][]T{}
+	if root == nil {
+		return res
+	}
+
+	heads := []*TreeNode[T]{root} // first node of each chain on this diagonal
+	var next []*TreeNode[T]
+	for len(heads) > 0 {
+		var diag []T
+		next = next[:0]
+		for _, head := range heads {
+			for n := head; n != nil; n = n.Right {
+				diag = append(diag, n.Val)
+				if n.Left != nil {
+					next = append(next, n.Left)
+				}
+			}
+		}
+		res = append(res, diag)
+		heads, next = next, heads
+	}
+	return res
+}
+
+// DiagonalMax returns the maximum value on each diagonal, in the order
+// of DiagonalOrder; it is the diagonal counterpart of rowWiseMax.
+// The returned slice is never nil, even for an empty tree.
+func DiagonalMax[T cmp.Ordered](root *TreeNode[T]) []T {
+	res := []T{}
+	for _, diag := range DiagonalOrder(root) {
+		res = append(res, maxOf(diag))
+	}
+	return res
+}
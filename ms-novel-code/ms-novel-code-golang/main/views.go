@@ -0,0 +1,75 @@
+package core
+
+// RightView returns the value of the right-most node on each level,
+// i.e. what is visible when the tree is viewed from the right.
+// The returned slice is never nil, even for an empty tree.
+func RightView[T any](root *TreeNode[T]) []T {
+	res := []T{}
+	walkLevels(root, func(_ int, level []*TreeNode[T]) bool {
+		res = append(res, level[len(level)-1].Val)
+		return true
+	})
+	return res
+}
+
+// LeftView returns the value of the left-most node on each level,
+// i.e. what is visible when the tree is viewed from the left.
+// The returned slice is never nil, even for an empty tree.
+func LeftView[T any](root *TreeNode[T]) []T {
+	res := []T{}
+	walkLevels(root, func(_ int, level []*TreeNode[T]) bool {
+		res = append(res, level[0].Val)
+		return true
+	})
+	return res
+}
+
+// BoundaryTraversal returns the tree's outline anti-clockwise from the
+// root: the root, the left boundary top-down (the path that keeps to the
+// left-most child, excluding its leaf), every leaf left-to-right, then
+// the right boundary bottom-up (likewise excluding its leaf). Each node
+// appears once. The returned slice is never nil, even for an empty tree.
+func BoundaryTraversal[T any](root *TreeNode[T]) []T {
+	res := []T{}
+	if root == nil {
+		return res
+	}
+	isLeaf := func(n *TreeNode[T]) bool { return n.Left == nil && n.Right == nil }
+
+	res = append(res, root.Val)
+	if isLeaf(root) {
+		return res
+	}
+
+	// Left boundary, top-down.
+	for n := root.Left; n != nil && !isLeaf(n); {
+		res = append(res, n.Val)
+		if n.Left != nil {
+			n = n.Left
+		} else {
+			n = n.Right
+		}
+	}
+
+	// Leaves, left-to-right.
+	eachNode(root, func(n *TreeNode[T]) {
+		if n != root && isLeaf(n) {
+			res = append(res, n.Val)
+		}
+	})
+
+	// Right boundary, bottom-up.
+	var right []T
+	for n := root.Right; n != nil && !isLeaf(n); {
+		right = append(right, n.Val)
+		if n.Right != nil {
+			n = n.Right
+		} else {
+			n = n.Left
+		}
+	}
+	for i := len(right) - 1; i >= 0; i-- {
+		res = append(res, right[i])
+	}
+	return res
+}
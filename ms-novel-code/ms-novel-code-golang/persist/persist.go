@@ -0,0 +1,373 @@
+// Package persist stores binary trees in a SQL database through
+// database/sql, so any driver can be used. Each tree is a set of rows
+// keyed by a caller-chosen tree ID, in one of two encodings:
+//
+//   - AdjacencyList: each row names its parent, which makes single-node
+//     edits cheap.
+//   - NestedSets: each row holds the [lft, rgt] interval of a preorder
+//     walk, so a whole subtree can be selected with one range query.
+//
+// Both keep which side a child hangs on, so lone children round-trip.
+package persist
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	core "core/main"
+)
+
+// Encoding selects how a Store lays trees out in its table.
+type Encoding int
+
+const (
+	// AdjacencyList stores (tree_id, id, parent_id, side, val) rows.
+	AdjacencyList Encoding = iota
+	// NestedSets stores (tree_id, lft, rgt, side, val) rows.
+	NestedSets
+)
+
+// String returns the encoding's name.
+func (e Encoding) String() string {
+	switch e {
+	case AdjacencyList:
+		return "AdjacencyList"
+	case NestedSets:
+		return "NestedSets"
+	}
+	return "Encoding(" + strconv.Itoa(int(e)) + ")"
+}
+
+// ErrNotFound is returned by LoadTree when no rows exist for the tree ID.
+var ErrNotFound = errors.New("persist: tree not found")
+
+// ErrCorrupt is returned (wrapped) by LoadTree when the stored rows do
+// not describe a single binary tree.
+var ErrCorrupt = errors.New("persist: stored rows do not form a tree")
+
+// Placeholder renders the n-th (1-based) query parameter marker.
+type Placeholder func(n int) string
+
+// DollarPlaceholder writes $1, $2, ..., as PostgreSQL expects. It is
+// the default.
+func DollarPlaceholder(n int) string { return "$" + strconv.Itoa(n) }
+
+// QuestionPlaceholder writes ?, as MySQL and SQLite expect.
+func QuestionPlaceholder(int) string { return "?" }
+
+// Option customises a Store.
+type Option func(*Store)
+
+// WithPlaceholder sets the parameter marker style of the driver.
+func WithPlaceholder(p Placeholder) Option {
+	return func(s *Store) { s.placeholder = p }
+}
+
+// Store saves and loads trees in one table. It is safe for concurrent
+// use as long as the database handle is.
+type Store struct {
+	db          *sql.DB
+	table       string
+	enc         Encoding
+	placeholder Placeholder
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// NewStore returns a Store keeping trees in table with the given
+// encoding. table is spliced into SQL, so it must be a plain identifier.
+func NewStore(db *sql.DB, table string, enc Encoding, opts ...Option) (*Store, error) {
+	if !identifier.MatchString(table) {
+		return nil, fmt.Errorf("persist: invalid table name %q", table)
+	}
+	if enc != AdjacencyList && enc != NestedSets {
+		return nil, fmt.Errorf("persist: unknown %v", enc)
+	}
+	s := &Store{db: db, table: table, enc: enc, placeholder: DollarPlaceholder}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// query substitutes the table name for {t} and parameter markers for
+// each ?, in order.
+func (s *Store) query(q string) string {
+	q = strings.ReplaceAll(q, "{t}", s.table)
+	var b strings.Builder
+	n := 0
+	for _, r := range q {
+		if r == '?' {
+			n++
+			b.WriteString(s.placeholder(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// CreateTable creates the Store's table if it does not exist yet. The
+// DDL sticks to types every mainstream database accepts.
+func (s *Store) CreateTable(ctx context.Context) error {
+	ddl := `CREATE TABLE IF NOT EXISTS {t} (
+	tree_id VARCHAR(255) NOT NULL,
+	id BIGINT NOT NULL,
+	parent_id BIGINT,
+	side CHAR(1),
+	val BIGINT NOT NULL,
+	PRIMARY KEY (tree_id, id)
+)`
+	if s.enc == NestedSets {
+		ddl = `CREATE TABLE IF NOT EXISTS {t} (
+	tree_id VARCHAR(255) NOT NULL,
+	lft BIGINT NOT NULL,
+	rgt BIGINT NOT NULL,
+	side CHAR(1),
+	val BIGINT NOT NULL,
+	PRIMARY KEY (tree_id, lft)
+)`
+	}
+	_, err := s.db.ExecContext(ctx, s.query(ddl))
+	return err
+}
+
+// SaveTree replaces the rows of treeID with root, in one transaction.
+// Saving a nil tree deletes treeID.
+func (s *Store) SaveTree(ctx context.Context, treeID string, root *core.Node) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, s.query(`DELETE FROM {t} WHERE tree_id = ?`), treeID); err != nil {
+		return err
+	}
+	insert := `INSERT INTO {t} (tree_id, id, parent_id, side, val) VALUES (?, ?, ?, ?, ?)`
+	if s.enc == NestedSets {
+		insert = `INSERT INTO {t} (tree_id, lft, rgt, side, val) VALUES (?, ?, ?, ?, ?)`
+	}
+	stmt, err := tx.PrepareContext(ctx, s.query(insert))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range s.rows(root) {
+		if _, err = stmt.ExecContext(ctx, treeID, r.a, r.b, r.side, int64(r.node.Val)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// row is one stored node: (id, parent_id) for an adjacency list and
+// (lft, rgt) for nested sets.
+type row struct {
+	node *core.Node
+	a, b sql.NullInt64
+	side sql.NullString
+}
+
+// rows lays the tree out in the Store's encoding, in preorder.
+func (s *Store) rows(root *core.Node) []row {
+	if root == nil {
+		return nil
+	}
+	type frame struct {
+		node   *core.Node
+		parent int // index into out, -1 for the root
+		side   string
+		exit   bool
+	}
+	var out []row
+	counter := int64(0)
+	stack := []frame{{node: root, parent: -1}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if f.exit {
+			// Nested sets close the interval after the subtree.
+			counter++
+			out[f.parent].b = sql.NullInt64{Int64: counter, Valid: true}
+			continue
+		}
+
+		counter++
+		r := row{node: f.node, a: sql.NullInt64{Int64: counter, Valid: true}}
+		if f.side != "" {
+			r.side = sql.NullString{String: f.side, Valid: true}
+		}
+		if s.enc == AdjacencyList && f.parent >= 0 {
+			r.b = out[f.parent].a
+		}
+		out = append(out, r)
+		i := len(out) - 1
+
+		if s.enc == NestedSets {
+			stack = append(stack, frame{parent: i, exit: true})
+		}
+		if f.node.Right != nil {
+			stack = append(stack, frame{node: f.node.Right, parent: i, side: "R"})
+		}
+		if f.node.Left != nil {
+			stack = append(stack, frame{node: f.node.Left, parent: i, side: "L"})
+		}
+	}
+	return out
+}
+
+// LoadTree reads the tree saved under treeID. It returns ErrNotFound if
+// there is none, and an error wrapping ErrCorrupt if the rows have been
+// edited into something that is not a binary tree.
+func (s *Store) LoadTree(ctx context.Context, treeID string) (*core.Node, error) {
+	q := `SELECT id, parent_id, side, val FROM {t} WHERE tree_id = ? ORDER BY id`
+	if s.enc == NestedSets {
+		q = `SELECT lft, rgt, side, val FROM {t} WHERE tree_id = ? ORDER BY lft`
+	}
+	rs, err := s.db.QueryContext(ctx, s.query(q), treeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+
+	var b builder
+	if s.enc == NestedSets {
+		b = &nestedSetBuilder{}
+	} else {
+		b = &adjacencyBuilder{byID: map[int64]*core.Node{}}
+	}
+	for rs.Next() {
+		var (
+			r   row
+			val int64
+		)
+		if err := rs.Scan(&r.a, &r.b, &r.side, &val); err != nil {
+			return nil, err
+		}
+		if err := b.add(r, int(val)); err != nil {
+			return nil, err
+		}
+	}
+	if err := rs.Err(); err != nil {
+		return nil, err
+	}
+	root, err := b.finish()
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, ErrNotFound
+	}
+	return root, nil
+}
+
+// builder rebuilds a tree from rows in key order.
+type builder interface {
+	add(r row, val int) error
+	finish() (*core.Node, error)
+}
+
+// attach hangs child under parent on the row's side.
+func attach(parent, child *core.Node, side sql.NullString) error {
+	var slot **core.Node
+	switch side.String {
+	case "L":
+		slot = &parent.Left
+	case "R":
+		slot = &parent.Right
+	default:
+		return fmt.Errorf("%w: child has side %q", ErrCorrupt, side.String)
+	}
+	if *slot != nil {
+		return fmt.Errorf("%w: two %s children under one parent", ErrCorrupt, side.String)
+	}
+	*slot = child
+	return nil
+}
+
+// adjacencyBuilder relies on SaveTree numbering parents before their
+// children, so a parent is always known by the time a child is read.
+type adjacencyBuilder struct {
+	root *core.Node
+	byID map[int64]*core.Node
+}
+
+func (b *adjacencyBuilder) add(r row, val int) error {
+	if !r.a.Valid {
+		return fmt.Errorf("%w: null id", ErrCorrupt)
+	}
+	node := &core.Node{Val: val}
+	b.byID[r.a.Int64] = node
+	if !r.b.Valid {
+		if b.root != nil {
+			return fmt.Errorf("%w: more than one root", ErrCorrupt)
+		}
+		b.root = node
+		return nil
+	}
+	parent := b.byID[r.b.Int64]
+	if parent == nil || r.b.Int64 >= r.a.Int64 {
+		return fmt.Errorf("%w: node %d has unknown parent %d", ErrCorrupt, r.a.Int64, r.b.Int64)
+	}
+	return attach(parent, node, r.side)
+}
+
+func (b *adjacencyBuilder) finish() (*core.Node, error) {
+	if len(b.byID) > 0 && b.root == nil {
+		return nil, fmt.Errorf("%w: no root", ErrCorrupt)
+	}
+	return b.root, nil
+}
+
+// nestedSetBuilder keeps the chain of open intervals enclosing the
+// current row; a row's parent is the innermost interval containing it.
+type nestedSetBuilder struct {
+	root *core.Node
+	open []nestedSet
+}
+
+type nestedSet struct {
+	lft, rgt int64
+	node     *core.Node
+}
+
+func (b *nestedSetBuilder) add(r row, val int) error {
+	if !r.a.Valid || !r.b.Valid || r.a.Int64 >= r.b.Int64 {
+		return fmt.Errorf("%w: bad interval [%v, %v]", ErrCorrupt, r.a.Int64, r.b.Int64)
+	}
+	for len(b.open) > 0 && b.open[len(b.open)-1].rgt < r.a.Int64 {
+		b.open = b.open[:len(b.open)-1]
+	}
+	node := &core.Node{Val: val}
+	if len(b.open) == 0 {
+		if b.root != nil {
+			return fmt.Errorf("%w: more than one root", ErrCorrupt)
+		}
+		b.root = node
+	} else {
+		parent := b.open[len(b.open)-1]
+		if r.b.Int64 >= parent.rgt {
+			return fmt.Errorf("%w: interval [%d, %d] overlaps its parent", ErrCorrupt, r.a.Int64, r.b.Int64)
+		}
+		if err := attach(parent.node, node, r.side); err != nil {
+			return err
+		}
+	}
+	b.open = append(b.open, nestedSet{r.a.Int64, r.b.Int64, node})
+	return nil
+}
+
+func (b *nestedSetBuilder) finish() (*core.Node, error) {
+	return b.root, nil
+}
@@ -0,0 +1,118 @@
+package persist
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+
+	core "core/main"
+)
+
+// openStore returns a Store on a fresh sqlite database.
+func openStore(t *testing.T, enc Encoding) (*Store, *sql.DB) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "trees.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	s, err := NewStore(db, "trees", enc, WithPlaceholder(QuestionPlaceholder))
+	require.NoError(t, err)
+	require.NoError(t, s.CreateTable(context.Background()))
+	return s, db
+}
+
+func levelOrder(vals ...any) *core.Node {
+	ptrs := make([]*int, len(vals))
+	for i, v := range vals {
+		if v != nil {
+			n := v.(int)
+			ptrs[i] = &n
+		}
+	}
+	return core.BuildFromLevelOrder(ptrs)
+}
+
+// 1. Trees round-trip in both encodings, lone children keeping their side.
+func TestSaveLoadRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	trees := map[string]*core.Node{
+		"single":     {Val: 7},
+		"lone-left":  levelOrder(1, 2, nil, 3),
+		"lone-right": levelOrder(1, nil, 2, nil, 3, 4),
+		"random":     core.GenerateRandomTree(300, core.WithSeed(11)),
+		"skewed":     core.GenerateRandomTree(200, core.WithShape(core.ShapeRightSkewed)),
+	}
+	for _, enc := range []Encoding{AdjacencyList, NestedSets} {
+		s, _ := openStore(t, enc)
+		for id, root := range trees {
+			require.NoError(t, s.SaveTree(ctx, id, root), "%v %s", enc, id)
+		}
+		for id, root := range trees {
+			got, err := s.LoadTree(ctx, id)
+			require.NoError(t, err, "%v %s", enc, id)
+			require.True(t, core.Equal(root, got), "%v %s", enc, id)
+		}
+
+		// Saving again replaces the old rows.
+		require.NoError(t, s.SaveTree(ctx, "random", levelOrder(4, 5)))
+		got, err := s.LoadTree(ctx, "random")
+		require.NoError(t, err)
+		require.True(t, core.Equal(levelOrder(4, 5), got), enc)
+	}
+}
+
+// 2. Missing trees report ErrNotFound, including after saving nil.
+func TestLoadTreeNotFound(t *testing.T) {
+	ctx := context.Background()
+	for _, enc := range []Encoding{AdjacencyList, NestedSets} {
+		s, _ := openStore(t, enc)
+		_, err := s.LoadTree(ctx, "missing")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		require.NoError(t, s.SaveTree(ctx, "gone", levelOrder(1, 2)))
+		require.NoError(t, s.SaveTree(ctx, "gone", nil))
+		_, err = s.LoadTree(ctx, "gone")
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+}
+
+// 3. Rows edited into something other than a binary tree give ErrCorrupt.
+func TestLoadTreeCorrupt(t *testing.T) {
+	ctx := context.Background()
+	cases := map[Encoding][]string{
+		AdjacencyList: {
+			`INSERT INTO trees VALUES ('t', 1, NULL, NULL, 1), ('t', 2, NULL, NULL, 2)`, // two roots
+			`INSERT INTO trees VALUES ('t', 1, NULL, NULL, 1), ('t', 2, 9, 'L', 2)`,     // unknown parent
+			`INSERT INTO trees VALUES ('t', 1, NULL, NULL, 1), ('t', 2, 1, 'X', 2)`,     // bad side
+			`INSERT INTO trees VALUES ('t', 1, NULL, NULL, 1), ('t', 2, 1, 'L', 2), ('t', 3, 1, 'L', 3)`,
+			`INSERT INTO trees VALUES ('t', 1, 2, 'L', 1), ('t', 2, 1, 'L', 2)`, // no root
+		},
+		NestedSets: {
+			`INSERT INTO trees VALUES ('t', 1, 2, NULL, 1), ('t', 3, 4, NULL, 2)`, // two roots
+			`INSERT INTO trees VALUES ('t', 1, 4, NULL, 1), ('t', 2, 5, 'L', 2)`,  // overlaps parent
+			`INSERT INTO trees VALUES ('t', 1, 1, NULL, 1)`,                       // empty interval
+			`INSERT INTO trees VALUES ('t', 1, 4, NULL, 1), ('t', 2, 3, NULL, 2)`, // child without side
+			`INSERT INTO trees VALUES ('t', 1, 6, NULL, 1), ('t', 2, 3, 'R', 2), ('t', 4, 5, 'R', 3)`,
+		},
+	}
+	for enc, inserts := range cases {
+		for _, insert := range inserts {
+			s, db := openStore(t, enc)
+			_, err := db.ExecContext(ctx, insert)
+			require.NoError(t, err)
+			_, err = s.LoadTree(ctx, "t")
+			require.ErrorIs(t, err, ErrCorrupt, "%v: %s", enc, insert)
+		}
+	}
+}
+
+// 4. Table names are checked before being spliced into SQL.
+func TestNewStoreValidates(t *testing.T) {
+	_, err := NewStore(nil, "trees; DROP TABLE x", AdjacencyList)
+	require.Error(t, err)
+	_, err = NewStore(nil, "trees", Encoding(5))
+	require.Error(t, err)
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rowWiseMaxAllocSlack covers the allocations rowWiseMax makes once per
+// call rather than per level: the result map, the first level buffer and
+// the amortised growth of the result slice.
+const rowWiseMaxAllocSlack = 12
+
+// 1. Every benchmarked implementation agrees with rowWiseMax.
+func TestRowWiseMaxImplsAgree(t *testing.T) {
+	for _, root := range []*Node{nil, completeTree(1000), leftSkewedTree(50), GenerateRandomTree(500, WithSeed(7))} {
+		want := rowWiseMax(root)["output"]
+		for _, impl := range rowWiseMaxImpls {
+			require.Equal(t, want, impl.fn(root), impl.name)
+		}
+	}
+}
+
+// 2. Wide trees stay within one allocation per level.
+func TestRowWiseMaxAllocBudgetComplete(t *testing.T) {
+	root := completeTree(1 << 16)
+	allocs := testing.AllocsPerRun(10, func() { rowWiseMax(root) })
+	require.LessOrEqual(t, allocs, float64(Height(root)+rowWiseMaxAllocSlack))
+}
+
+// 3. Deep trees reuse their level buffers, so allocations barely grow
+// with height.
+func TestRowWiseMaxAllocBudgetDeep(t *testing.T) {
+	root := leftSkewedTree(1 << 12)
+	allocs := testing.AllocsPerRun(10, func() { rowWiseMax(root) })
+	require.Less(t, allocs, float64(Height(root))/64)
+}
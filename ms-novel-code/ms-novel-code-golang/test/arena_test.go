@@ -0,0 +1,52 @@
+package core
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. New hands out consecutive nodes from one slab.
+func TestTreeArenaNewIsContiguous(t *testing.T) {
+	a := NewTreeArena(4)
+	nodes := []*Node{a.New(1), a.New(2), a.New(3), a.New(4), a.New(5)}
+	require.Equal(t, 5, a.Len())
+	for i, n := range nodes {
+		require.Equal(t, i+1, n.Val)
+	}
+	size := unsafe.Sizeof(Node{})
+	require.Equal(t, uintptr(unsafe.Pointer(nodes[0]))+3*size, uintptr(unsafe.Pointer(nodes[3])))
+}
+
+// 2. CopyTree produces an equal tree laid out in level order.
+func TestTreeArenaCopyTree(t *testing.T) {
+	src := BuildFromLevelOrder(levelOrderInts(10, 5, 4, 8, 9, nil, 15))
+	a := NewTreeArena(0)
+	dst := a.CopyTree(src)
+
+	require.True(t, Equal(src, dst))
+	require.NotSame(t, src, dst)
+	require.Equal(t, 6, a.Len())
+	require.Equal(t, []int{10, 5, 15}, rowWiseMax(dst)["output"])
+
+	size := unsafe.Sizeof(Node{})
+	require.Equal(t, uintptr(unsafe.Pointer(dst))+size, uintptr(unsafe.Pointer(dst.Left)))
+	require.Equal(t, uintptr(unsafe.Pointer(dst))+2*size, uintptr(unsafe.Pointer(dst.Right)))
+	require.Nil(t, a.CopyTree(nil))
+}
+
+// 3. Reset starts over with a fresh slab.
+func TestTreeArenaReset(t *testing.T) {
+	a := NewTreeArena(8)
+	old := a.CopyTree(completeTree(20))
+	a.Reset()
+	require.Equal(t, 0, a.Len())
+
+	n := a.New(7)
+	require.NotSame(t, old, n)
+	require.Equal(t, 7, n.Val)
+	require.Nil(t, n.Left)
+	require.Nil(t, n.Right)
+	require.Equal(t, 20, Size(old))
+}
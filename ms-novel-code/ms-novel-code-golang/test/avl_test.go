@@ -0,0 +1,65 @@
+package core
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. AVL and BST are interchangeable behind SearchTree.
+func TestAVLMatchesBST(t *testing.T) {
+	for _, tree := range []SearchTree{NewBST(), NewAVL()} {
+		for _, v := range []int{50, 30, 70, 20, 40, 60, 80, 40} {
+			tree.Insert(v)
+		}
+		require.True(t, tree.Delete(50))
+		require.Equal(t, []int{20, 30, 40, 60, 70, 80}, tree.InOrder())
+		require.Equal(t, 6, tree.Len())
+		require.True(t, tree.Search(60))
+		require.False(t, tree.Search(50))
+	}
+}
+
+// 2. Sorted inserts, which degenerate a BST, stay logarithmic.
+func TestAVLSortedInsertsStayBalanced(t *testing.T) {
+	tree := NewAVL()
+	for v := 1; v <= 1023; v++ {
+		tree.Insert(v)
+	}
+	require.NoError(t, tree.Validate())
+	require.Equal(t, 10, tree.Height())
+	require.Len(t, rowWiseMax(tree.Tree())["output"], 10)
+}
+
+// 3. Random inserts and deletes never break the invariants.
+func TestAVLRandomOperations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewAVL()
+	present := map[int]bool{}
+	for i := 0; i < 2000; i++ {
+		v := rng.Intn(300)
+		if rng.Intn(3) == 0 {
+			require.Equal(t, present[v], tree.Delete(v))
+			delete(present, v)
+		} else {
+			require.Equal(t, !present[v], tree.Insert(v))
+			present[v] = true
+		}
+		require.NoError(t, tree.Validate())
+	}
+	require.Equal(t, len(present), tree.Len())
+}
+
+// 4. Min and Max track the extremes and report emptiness.
+func TestAVLMinMax(t *testing.T) {
+	tree := NewAVL()
+	_, ok := tree.Min()
+	require.False(t, ok)
+
+	tree = NewAVL(5, -3, 12)
+	lo, _ := tree.Min()
+	hi, _ := tree.Max()
+	require.Equal(t, -3, lo)
+	require.Equal(t, 12, hi)
+}
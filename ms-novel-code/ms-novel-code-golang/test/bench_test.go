@@ -0,0 +1,222 @@
+package core
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// completeTree builds a complete tree of n nodes valued 0..n-1 in level order.
+func completeTree(n int) *Node {
+	if n == 0 {
+		return nil
+	}
+	nodes := make([]Node, n)
+	for i := range nodes {
+		nodes[i].Val = (i * 7919) % n
+		if l := 2*i + 1; l < n {
+			nodes[i].Left = &nodes[l]
+		}
+		if r := 2*i + 2; r < n {
+			nodes[i].Right = &nodes[r]
+		}
+	}
+	return &nodes[0]
+}
+
+// leftSkewedTree builds a tree of n nodes where every node is a left child.
+func leftSkewedTree(n int) *Node {
+	var root *Node
+	for i := 0; i < n; i++ {
+		root = &Node{Val: i, Left: root}
+	}
+	return root
+}
+
+func benchmarkRowWiseMax(b *testing.B, root *Node, opts ...TraversalOption) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rowWiseMax(root, opts...)
+	}
+}
+
+func BenchmarkRowWiseMaxBFSComplete(b *testing.B) {
+	benchmarkRowWiseMax(b, completeTree(1<<16))
+}
+
+func BenchmarkRowWiseMaxMorrisComplete(b *testing.B) {
+	benchmarkRowWiseMax(b, completeTree(1<<16), WithStrategy(StrategyMorris))
+}
+
+func BenchmarkRowWiseMaxBFSDeep(b *testing.B) {
+	benchmarkRowWiseMax(b, leftSkewedTree(1<<14))
+}
+
+func BenchmarkRowWiseMaxMorrisDeep(b *testing.B) {
+	benchmarkRowWiseMax(b, leftSkewedTree(1<<14), WithStrategy(StrategyMorris))
+}
+
+func BenchmarkRowWiseMaxBFSMillion(b *testing.B) {
+	benchmarkRowWiseMax(b, completeTree(1<<20))
+}
+
+func BenchmarkRowWiseMaxMorrisMillion(b *testing.B) {
+	benchmarkRowWiseMax(b, completeTree(1<<20), WithStrategy(StrategyMorris))
+}
+
+func BenchmarkParallelRowWiseMaxMillion(b *testing.B) {
+	root := completeTree(1 << 20)
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				ParallelRowWiseMax(root, workers)
+			}
+		})
+	}
+}
+
+// dfsRowWiseMax is the depth-first alternative to rowWiseMax used by the
+// benchmark harness: a preorder walk that folds each node into the
+// result slot for its depth.
+func dfsRowWiseMax(root *Node) []int {
+	res := []int{}
+	Walk(root, OrderPre, func(n *Node, depth int) bool {
+		if depth == len(res) {
+			res = append(res, n.Val)
+		} else {
+			res[depth] = max(res[depth], n.Val)
+		}
+		return true
+	})
+	return res
+}
+
+// rowWiseMaxImpls are the implementations compared by
+// BenchmarkRowWiseMaxSizes.
+var rowWiseMaxImpls = []struct {
+	name string
+	fn   func(*Node) []int
+}{
+	{"bfs", func(root *Node) []int { return rowWiseMax(root)["output"] }},
+	{"morris", func(root *Node) []int { return rowWiseMax(root, WithStrategy(StrategyMorris))["output"] }},
+	{"dfs", dfsRowWiseMax},
+	{"parallel", func(root *Node) []int { return ParallelRowWiseMax(root, 4) }},
+}
+
+// BenchmarkRowWiseMaxSizes compares every rowWiseMax implementation on
+// complete trees of 1e3 to 1e7 nodes. The 1e7 tree needs a few hundred
+// megabytes and is skipped in -short mode.
+func BenchmarkRowWiseMaxSizes(b *testing.B) {
+	for _, n := range []int{1e3, 1e4, 1e5, 1e6, 1e7} {
+		if n > 1e6 && testing.Short() {
+			continue
+		}
+		root := completeTree(n)
+		for _, impl := range rowWiseMaxImpls {
+			b.Run(fmt.Sprintf("n=%d/%s", n, impl.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					impl.fn(root)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkBuildDiscardAlloc(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		root := &Node{Val: i}
+		for j := 0; j < 64; j++ {
+			root = &Node{Val: j, Left: root}
+		}
+		rowWiseMax(root)
+	}
+}
+
+func BenchmarkBuildDiscardPool(b *testing.B) {
+	b.ReportAllocs()
+	var pool NodePool
+	for i := 0; i < b.N; i++ {
+		root := pool.AcquireNode(i)
+		for j := 0; j < 64; j++ {
+			n := pool.AcquireNode(j)
+			n.Left = root
+			root = n
+		}
+		rowWiseMax(root)
+		pool.FreeTree(root)
+	}
+}
+
+// scatteredTree builds a complete tree of n nodes allocated in random
+// order, so neighbouring nodes are rarely adjacent in memory.
+func scatteredTree(n int) *Node {
+	nodes := make([]*Node, n)
+	for _, i := range rand.Perm(n) {
+		nodes[i] = &Node{Val: i}
+	}
+	for i, node := range nodes {
+		if l := 2*i + 1; l < n {
+			node.Left = nodes[l]
+		}
+		if r := 2*i + 2; r < n {
+			node.Right = nodes[r]
+		}
+	}
+	return nodes[0]
+}
+
+func BenchmarkRowWiseMaxScattered(b *testing.B) {
+	benchmarkRowWiseMax(b, scatteredTree(1<<20))
+}
+
+func BenchmarkRowWiseMaxArena(b *testing.B) {
+	benchmarkRowWiseMax(b, NewTreeArena(0).CopyTree(scatteredTree(1<<20)))
+}
+
+func BenchmarkImplicitRowWiseMaxMillion(b *testing.B) {
+	it, err := ImplicitFromNode(completeTree(1 << 20))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ImplicitRowWiseMax(it)
+	}
+}
+
+func BenchmarkCountCompleteTreeNodes(b *testing.B) {
+	root := completeTree(1<<20 + 12345)
+	b.Run("log2", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			CountCompleteTreeNodes(root)
+		}
+	})
+	b.Run("size", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Size(root)
+		}
+	})
+}
+
+func BenchmarkOrderedMapGet(b *testing.B) {
+	const n = 1 << 16
+	keys := rand.Perm(n)
+	for _, impl := range orderedMapImpls {
+		b.Run(impl.name, func(b *testing.B) {
+			m := impl.new()
+			for _, k := range keys {
+				m.Put(k, k)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				m.Get(keys[i%n])
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. The encoding starts with the version byte and node count.
+func TestTreeMarshalBinaryLayout(t *testing.T) {
+	data, err := Tree{}.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 0}, data)
+
+	data, err = Tree{Root: BuildFromLevelOrder(levelOrderInts(1, nil, -1))}.MarshalBinary()
+	require.NoError(t, err)
+	// version, count, {right, +1}, {leaf, -1} in zig-zag varints.
+	require.Equal(t, []byte{1, 2, 2, 2, 0, 1}, data)
+}
+
+// 2. Trees round-trip through gob.
+func TestTreeGobRoundTrip(t *testing.T) {
+	type record struct {
+		Name string
+		Tree Tree
+	}
+	in := record{"index", Tree{Root: BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4, nil, 5, 6))}}
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(in))
+	var out record
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&out))
+	require.Equal(t, in.Name, out.Name)
+	require.True(t, Equal(in.Tree.Root, out.Tree.Root))
+}
+
+// 3. Corrupt input is rejected with ErrBinaryFormat.
+func TestTreeUnmarshalBinaryErrors(t *testing.T) {
+	cases := [][]byte{
+		nil,
+		{2, 0},          // unknown version
+		{1, 1},          // count larger than input
+		{1, 1, 1, 2},    // announces a left child that never comes
+		{1, 1, 0, 2, 9}, // trailing byte
+		{1, 1, 8, 2},    // unknown flag
+	}
+	for _, data := range cases {
+		var tree Tree
+		require.ErrorIs(t, tree.UnmarshalBinary(data), ErrBinaryFormat, "%v", data)
+	}
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. An empty BST has no min/max and an empty in-order listing.
+func TestBSTEmpty(t *testing.T) {
+	tree := NewBST()
+	_, ok := tree.Min()
+	require.False(t, ok)
+	_, ok = tree.Max()
+	require.False(t, ok)
+	require.Empty(t, tree.InOrder())
+	require.False(t, tree.Search(1))
+	require.False(t, tree.Delete(1))
+}
+
+// 2. Inserted values come back sorted and duplicates are ignored.
+func TestBSTInsertSearch(t *testing.T) {
+	tree := NewBST(50, 30, 70, 20, 40, 60, 80)
+	require.False(t, tree.Insert(40))
+	require.Equal(t, 7, tree.Len())
+	require.Equal(t, []int{20, 30, 40, 50, 60, 70, 80}, tree.InOrder())
+	require.True(t, tree.Search(60))
+	require.False(t, tree.Search(65))
+	require.Equal(t, 60, tree.Node(60).Val)
+
+	lo, _ := tree.Min()
+	hi, _ := tree.Max()
+	require.Equal(t, 20, lo)
+	require.Equal(t, 80, hi)
+	require.Equal(t, []int{50, 70, 80}, rowWiseMax(tree.Root)["output"])
+}
+
+// 3. Deleting leaves, single-child nodes, and two-child nodes keeps order.
+func TestBSTDelete(t *testing.T) {
+	tree := NewBST(50, 30, 70, 20, 40, 60, 80, 65)
+
+	require.True(t, tree.Delete(20)) // leaf
+	require.True(t, tree.Delete(60)) // one child
+	require.True(t, tree.Delete(50)) // two children, root
+	require.False(t, tree.Delete(50))
+
+	require.Equal(t, []int{30, 40, 65, 70, 80}, tree.InOrder())
+	require.Equal(t, 5, tree.Len())
+	require.Equal(t, 65, tree.Root.Val)
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. RangeValues returns the closed range in sorted order.
+func TestRangeValues(t *testing.T) {
+	bst := NewBST(50, 30, 70, 20, 40, 60, 80, 35, 65)
+	require.Equal(t, []int{35, 40, 50, 60}, RangeValues(bst.Root, 33, 60))
+	require.Equal(t, []int{20}, RangeValues(bst.Root, 0, 20))
+	require.Equal(t, []int{}, RangeValues(bst.Root, 81, 100))
+	require.Equal(t, []int{}, RangeValues(bst.Root, 60, 50))
+	require.Equal(t, []int{}, RangeValues[int](nil, 0, 1))
+	require.Equal(t, bst.InOrder(), RangeValues(bst.Root, 0, 100))
+}
+
+// 2. RangeCount and RangeSum agree with RangeValues.
+func TestRangeCountSum(t *testing.T) {
+	vals := Preorder(GenerateRandomTree(500, WithSeed(5), WithValueRange(-1000, 1000)))
+	bst := NewBST(vals...)
+	for _, r := range [][2]int{{-1000, 1000}, {-50, 50}, {0, 0}, {200, 900}, {5, -5}} {
+		in := RangeValues(bst.Root, r[0], r[1])
+		sum := 0
+		for _, v := range in {
+			sum += v
+			require.True(t, v >= r[0] && v <= r[1])
+		}
+		require.Equal(t, len(in), RangeCount(bst.Root, r[0], r[1]))
+		require.Equal(t, sum, RangeSum(bst.Root, r[0], r[1]))
+	}
+}
+
+// 3. Pruning keeps narrow queries cheap on deep trees.
+func TestRangeValuesDeep(t *testing.T) {
+	bst := NewBST()
+	for v := range 1 << 14 {
+		bst.Insert(v) // a right-leaning chain
+	}
+	require.Equal(t, []int{10, 11, 12}, RangeValues(bst.Root, 10, 12))
+	require.Equal(t, 1<<14, RangeCount(bst.Root, 0, 1<<14))
+	require.Equal(t, []string{"b", "c"}, RangeValues(BuildBalancedBST([]string{"a", "b", "c", "d"}), "b", "c"))
+}
+
+// 4. TrimBST keeps exactly the in-range values as a valid BST.
+func TestTrimBST(t *testing.T) {
+	vals := Preorder(GenerateRandomTree(300, WithSeed(9), WithValueRange(0, 1000)))
+	for _, r := range [][2]int{{0, 1000}, {100, 400}, {500, 500}, {2000, 3000}, {-5, 50}} {
+		want := RangeValues(NewBST(vals...).Root, r[0], r[1])
+
+		got := TrimBST(NewBST(vals...).Root, r[0], r[1])
+		ok, _ := ValidateBST(got)
+		require.True(t, ok)
+		require.Equal(t, want, Inorder(got))
+	}
+
+	//     3
+	//    / \
+	//   0   4
+	//    \
+	//     2
+	//    /
+	//   1
+	root := BuildFromLevelOrder(levelOrderInts(3, 0, 4, nil, 2, nil, nil, 1))
+	require.Equal(t, levelOrderInts(3, 2, nil, 1), ToLevelOrder(TrimBST(root, 1, 3)))
+}
+
+// 5. TrimBSTCopy leaves the source alone and shares untouched subtrees.
+func TestTrimBSTCopy(t *testing.T) {
+	bst := NewBST(50, 30, 70, 20, 40, 60, 80, 10, 25)
+	before := Serialize(bst.Root)
+
+	got := TrimBSTCopy(bst.Root, 22, 100)
+	require.Equal(t, []int{25, 30, 40, 50, 60, 70, 80}, Inorder(got))
+	require.Equal(t, before, Serialize(bst.Root))
+	require.NotSame(t, bst.Root, got)
+	require.Same(t, bst.Root.Right, got.Right)
+	require.Same(t, bst.Root.Left.Right, got.Left.Right)
+
+	require.Same(t, bst.Root, TrimBSTCopy(bst.Root, 0, 100))
+	require.Nil(t, TrimBSTCopy(bst.Root, 81, 90))
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Basic map operations on a small-degree tree.
+func TestBTreeMap(t *testing.T) {
+	bt := NewBTree[int, string](2)
+	for i, w := range []string{"zero", "one", "two", "three", "four", "five", "six"} {
+		require.True(t, bt.Insert(i, w))
+		require.NoError(t, bt.Validate())
+	}
+	require.False(t, bt.Insert(3, "THREE"))
+	require.Equal(t, 7, bt.Len())
+	require.Greater(t, bt.Height(), 1)
+
+	v, ok := bt.Get(3)
+	require.True(t, ok)
+	require.Equal(t, "THREE", v)
+	_, ok = bt.Get(9)
+	require.False(t, ok)
+
+	k, v, ok := bt.Min()
+	require.Equal(t, 0, k)
+	require.Equal(t, "zero", v)
+	require.True(t, ok)
+	k, _, _ = bt.Max()
+	require.Equal(t, 6, k)
+
+	require.Panics(t, func() { NewBTree[int, int](1) })
+}
+
+// 2. Range scans return the closed range in order and stop early.
+func TestBTreeRange(t *testing.T) {
+	bt := NewBTree[int, int](3)
+	for i := 0; i < 1000; i += 2 {
+		bt.Insert(i, i*i)
+	}
+	keys := slices.Collect(maps.Keys(maps.Collect(bt.Range(101, 111))))
+	slices.Sort(keys)
+	require.Equal(t, []int{102, 104, 106, 108, 110}, keys)
+
+	var got []int
+	for k := range bt.Range(0, 998) {
+		got = append(got, k)
+		if len(got) == 3 {
+			break
+		}
+	}
+	require.Equal(t, []int{0, 2, 4}, got)
+	require.Empty(t, maps.Collect(bt.Range(10, 5)))
+	require.Empty(t, maps.Collect(bt.Range(2000, 3000)))
+}
+
+// 3. Random inserts and deletes keep every invariant.
+func TestBTreeMatchesMap(t *testing.T) {
+	for _, degree := range []int{2, 3, 8} {
+		bt := NewBTree[int, int](degree)
+		want := map[int]int{}
+		vals := Preorder(GenerateRandomTree(3000, WithSeed(uint64(degree)), WithValueRange(0, 500)))
+		for i, v := range vals {
+			if i%5 < 3 {
+				_, had := want[v]
+				require.Equal(t, !had, bt.Insert(v, i))
+				want[v] = i
+			} else {
+				_, had := want[v]
+				require.Equal(t, had, bt.Delete(v))
+				delete(want, v)
+			}
+			if i%97 == 0 {
+				require.NoError(t, bt.Validate(), "degree %d step %d", degree, i)
+			}
+		}
+		require.NoError(t, bt.Validate())
+		require.Equal(t, want, maps.Collect(bt.All()))
+		require.Equal(t, len(want), bt.Len())
+	}
+}
+
+// 4. Deleting everything empties the tree.
+func TestBTreeDeleteAll(t *testing.T) {
+	bt := NewBTree[int, int](2)
+	for i := range 200 {
+		bt.Insert(i, i)
+	}
+	for i := 199; i >= 0; i -= 2 {
+		require.True(t, bt.Delete(i))
+	}
+	for i := 0; i < 200; i += 2 {
+		require.True(t, bt.Delete(i))
+		require.NoError(t, bt.Validate())
+	}
+	require.Equal(t, 0, bt.Len())
+	require.Equal(t, 0, bt.Height())
+	require.False(t, bt.Delete(1))
+	_, _, ok := bt.Min()
+	require.False(t, ok)
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// levelOrderInts turns ints and nils into the []*int form used by
+// BuildFromLevelOrder, e.g. levelOrderInts(1, nil, 2).
+func levelOrderInts(xs ...any) []*int {
+	out := make([]*int, len(xs))
+	for i, x := range xs {
+		if v, ok := x.(int); ok {
+			out[i] = &v
+		}
+	}
+	return out
+}
+
+// 1. Empty input and a nil root both give a nil tree.
+func TestBuildFromLevelOrderEmpty(t *testing.T) {
+	require.Nil(t, BuildFromLevelOrder[int](nil))
+	require.Nil(t, BuildFromLevelOrder(levelOrderInts(nil, 1)))
+}
+
+// 2. Missing children are skipped and later values attach correctly.
+func TestBuildFromLevelOrderSparse(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4, nil, 5, 6))
+
+	require.Equal(t, 1, root.Val)
+	require.Nil(t, root.Left.Left)
+	require.Equal(t, 4, root.Left.Right.Val)
+	require.Nil(t, root.Right.Left)
+	require.Equal(t, 5, root.Right.Right.Val)
+	require.Equal(t, 6, root.Left.Right.Left.Val)
+	require.Equal(t, []int{1, 3, 5, 6}, rowWiseMax(root)["output"])
+}
+
+// 3. ToLevelOrder round-trips and trims trailing nils.
+func TestToLevelOrderRoundTrip(t *testing.T) {
+	in := levelOrderInts(1, 2, 3, nil, 4, nil, 5, 6)
+	out := ToLevelOrder(BuildFromLevelOrder(in))
+	require.Equal(t, in, out)
+
+	require.NotNil(t, ToLevelOrder[int](nil))
+	require.Empty(t, ToLevelOrder[int](nil))
+}
+
+// 4. Preorder+inorder and postorder+inorder rebuild the same tree.
+func TestBuildFromTraversalPairs(t *testing.T) {
+	want := BuildFromLevelOrder(levelOrderInts(3, 9, 20, nil, 8, 15, 7, 1))
+	pre, in, post := Preorder(want), Inorder(want), Postorder(want)
+
+	got, err := BuildFromPreIn(pre, in)
+	require.NoError(t, err)
+	require.True(t, Equal(want, got))
+
+	got, err = BuildFromPostIn(post, in)
+	require.NoError(t, err)
+	require.True(t, Equal(want, got))
+
+	got, err = BuildFromPreIn([]int{}, []int{})
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
+
+// 5. Skewed trees of both kinds rebuild correctly.
+func TestBuildFromTraversalPairsSkewed(t *testing.T) {
+	for _, want := range []*Node{leftSkewedTree(50), Invert(leftSkewedTree(50))} {
+		got, err := BuildFromPreIn(Preorder(want), Inorder(want))
+		require.NoError(t, err)
+		require.True(t, Equal(want, got))
+
+		got, err = BuildFromPostIn(Postorder(want), Inorder(want))
+		require.NoError(t, err)
+		require.True(t, Equal(want, got))
+	}
+}
+
+// 6. Duplicates and mismatched traversals are rejected.
+func TestBuildFromTraversalPairsErrors(t *testing.T) {
+	_, err := BuildFromPreIn([]int{1, 1}, []int{1, 1})
+	require.ErrorIs(t, err, ErrDuplicateValue)
+
+	_, err = BuildFromPreIn([]int{1, 2}, []int{1})
+	require.ErrorIs(t, err, ErrInconsistentTraversals)
+
+	_, err = BuildFromPostIn([]int{1, 2, 3}, []int{1, 2, 4})
+	require.ErrorIs(t, err, ErrInconsistentTraversals)
+
+	_, err = BuildFromPreIn([]int{1, 2, 2}, []int{2, 1, 3})
+	require.ErrorIs(t, err, ErrDuplicateValue)
+
+	// Same values, but no tree has preorder 1,2,3 and inorder 3,1,2.
+	_, err = BuildFromPreIn([]int{1, 2, 3}, []int{3, 1, 2})
+	require.ErrorIs(t, err, ErrInconsistentTraversals)
+}
+
+// 7. BuildBalancedBST gives a minimum-height valid BST.
+func TestBuildBalancedBST(t *testing.T) {
+	require.Nil(t, BuildBalancedBST([]int{}))
+
+	sorted := make([]int, 100)
+	for i := range sorted {
+		sorted[i] = i * 3
+	}
+	root := BuildBalancedBST(sorted)
+	require.Equal(t, sorted, Inorder(root))
+	require.Equal(t, 7, Height(root))
+	require.True(t, IsBalanced(root))
+	ok, _ := ValidateBST(root)
+	require.True(t, ok)
+}
+
+// 8. RebalanceBST relinks a degenerate BST without losing nodes.
+func TestRebalanceBST(t *testing.T) {
+	tree := NewBST()
+	for v := 1; v <= 1000; v++ {
+		tree.Insert(v)
+	}
+	require.Equal(t, 1000, Height(tree.Root))
+	node500 := tree.Node(500)
+
+	tree.Rebalance()
+	require.Equal(t, 10, Height(tree.Root))
+	require.Equal(t, 1000, tree.Len())
+	require.Same(t, node500, tree.Node(500))
+	require.Equal(t, Inorder(tree.Root), tree.InOrder())
+}
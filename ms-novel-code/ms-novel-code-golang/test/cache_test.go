@@ -0,0 +1,96 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// evictions records the entries a cache reports as evicted.
+type evictions struct {
+	keys []string
+	vals []int
+}
+
+func (ev *evictions) record(k string, v int) {
+	ev.keys = append(ev.keys, k)
+	ev.vals = append(ev.vals, v)
+}
+
+// 1. LRUCache evicts the least recently used entry.
+func TestLRUCache(t *testing.T) {
+	var ev evictions
+	c := NewLRUCache(2, WithOnEvict(ev.record))
+	require.True(t, c.Put("a", 1))
+	require.True(t, c.Put("b", 2))
+	v, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+
+	require.True(t, c.Put("c", 3)) // evicts b
+	require.Equal(t, []string{"b"}, ev.keys)
+	require.Equal(t, []int{2}, ev.vals)
+	_, ok = c.Get("b")
+	require.False(t, ok)
+
+	require.False(t, c.Put("a", 10)) // update, no eviction
+	require.Equal(t, []string{"a", "c"}, c.Keys())
+	_, ok = c.Peek("c") // Peek leaves recency alone
+	require.True(t, ok)
+	c.Put("d", 4)
+	require.Equal(t, []string{"b", "c"}, ev.keys)
+
+	require.True(t, c.Delete("a"))
+	require.False(t, c.Delete("a"))
+	require.Equal(t, 1, c.Len())
+	require.Equal(t, 2, c.Cap())
+	require.Len(t, ev.keys, 2) // Delete is not an eviction
+}
+
+// 2. LFUCache evicts the least frequently used, oldest first on ties.
+func TestLFUCache(t *testing.T) {
+	var ev evictions
+	c := NewLFUCache(2, WithOnEvict(ev.record))
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")
+	c.Get("a")
+	require.Equal(t, 3, c.Uses("a"))
+
+	c.Put("c", 3) // b has fewer uses
+	require.Equal(t, []string{"b"}, ev.keys)
+	c.Get("c")
+	c.Get("c") // c: 3 uses, last used after a
+	c.Put("d", 4)
+	require.Equal(t, []string{"b", "a"}, ev.keys)
+	require.Equal(t, []int{2, 1}, ev.vals)
+
+	v, ok := c.Peek("c")
+	require.True(t, ok)
+	require.Equal(t, 3, v)
+	require.Equal(t, 3, c.Uses("c"))
+	require.Zero(t, c.Uses("a"))
+
+	require.False(t, c.Put("d", 40))
+	v, _ = c.Get("d")
+	require.Equal(t, 40, v)
+	require.True(t, c.Delete("c"))
+	require.Equal(t, 1, c.Len())
+}
+
+// 3. Capacity one and invalid capacities.
+func TestCacheCapacity(t *testing.T) {
+	lru := NewLRUCache[int, int](1)
+	lfu := NewLFUCache[int, int](1)
+	for i := range 5 {
+		lru.Put(i, i)
+		lfu.Put(i, i)
+	}
+	require.Equal(t, []int{4}, lru.Keys())
+	v, ok := lfu.Get(4)
+	require.True(t, ok)
+	require.Equal(t, 4, v)
+
+	require.Panics(t, func() { NewLRUCache[int, int](0) })
+	require.Panics(t, func() { NewLFUCache[int, int](-1) })
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. The encoding mirrors the MessagePack layout in CBOR.
+func TestTreeMarshalCBORLayout(t *testing.T) {
+	data, err := Tree{}.MarshalCBOR()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x80}, data)
+
+	data, err = Tree{Root: BuildFromLevelOrder(levelOrderInts(1, nil, -1))}.MarshalCBOR()
+	require.NoError(t, err)
+	// array(4), {right, 1}, {leaf, -1}.
+	require.Equal(t, []byte{0x84, 2, 1, 0, 0x20}, data)
+
+	data, err = Tree{Root: &Node{Val: 300}}.MarshalCBOR()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x82, 0, 0x19, 0x01, 0x2c}, data)
+}
+
+// 2. Trees round-trip, including extreme values.
+func TestTreeCBORRoundTrip(t *testing.T) {
+	for _, root := range []*Node{
+		nil,
+		BuildFromLevelOrder(levelOrderInts(-200, 70000, 3, nil, -5_000_000_000, nil, -1<<63)),
+		GenerateRandomTree(1000, WithSeed(9)),
+		GenerateRandomTree(100, WithShape(ShapeRightSkewed)),
+	} {
+		data, err := Tree{Root: root}.MarshalCBOR()
+		require.NoError(t, err)
+		var got Tree
+		require.NoError(t, got.UnmarshalCBOR(data))
+		require.True(t, Equal(root, got.Root))
+	}
+}
+
+// 3. Longer encodings are accepted and corrupt input is rejected.
+func TestTreeUnmarshalCBOR(t *testing.T) {
+	var tree Tree
+	// array with a one-byte length, flags as uint16, value -2.
+	require.NoError(t, tree.UnmarshalCBOR([]byte{0x98, 2, 0x19, 0, 0, 0x21}))
+	require.Equal(t, &Node{Val: -2}, tree.Root)
+
+	for _, data := range [][]byte{
+		nil,
+		{0xa0},             // map, not array
+		{0x9f, 0, 1, 0xff}, // indefinite length
+		{0x83, 0, 1, 0},    // odd length
+		{0x82, 2, 1},       // announces a child that never comes
+		{0x84, 0, 1, 0, 2}, // second root
+		{0x82, 4, 1},       // unknown flag
+		{0x82, 0, 0xf6},    // null value
+		{0x82, 0, 0x19, 1}, // truncated uint16
+		{0x82, 0, 0x3b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, // negative overflow
+		{0x82, 0, 1, 0}, // trailing byte
+	} {
+		tree := Tree{Root: &Node{Val: 9}}
+		require.ErrorIs(t, tree.UnmarshalCBOR(data), ErrBinaryFormat, "%x", data)
+		require.Equal(t, 9, tree.Root.Val)
+	}
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Equal compares values and shape.
+func TestEqual(t *testing.T) {
+	a := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4))
+	b := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4))
+	c := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4))
+	d := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 5))
+
+	require.True(t, Equal[int](nil, nil))
+	require.True(t, Equal(a, b))
+	require.False(t, Equal(a, c))
+	require.False(t, Equal(a, d))
+	require.False(t, Equal(a, nil))
+}
+
+// 2. SameShape ignores values but not structure.
+func TestSameShape(t *testing.T) {
+	a := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4))
+	d := BuildFromLevelOrder(levelOrderInts(9, 8, 7, nil, 6))
+	c := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4))
+
+	require.True(t, SameShape(a, d))
+	require.False(t, SameShape(a, c))
+}
+
+// 3. IsSubtree requires a complete match of some subtree.
+func TestIsSubtree(t *testing.T) {
+	haystack := BuildFromLevelOrder(levelOrderInts(3, 4, 5, 1, 2))
+	needle := BuildFromLevelOrder(levelOrderInts(4, 1, 2))
+	partial := BuildFromLevelOrder(levelOrderInts(4, 1))
+
+	require.True(t, IsSubtree(haystack, needle))
+	require.False(t, IsSubtree(haystack, partial))
+	require.True(t, IsSubtree(haystack, nil))
+	require.False(t, IsSubtree(nil, needle))
+	require.True(t, IsSubtree(haystack, haystack))
+}
+
+// 4. IsMirror matches a tree against its inverted copy.
+func TestIsMirror(t *testing.T) {
+	a := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4, 5))
+	require.True(t, IsMirror(a, CloneInverted(a)))
+	require.False(t, IsMirror(a, a))
+	require.True(t, IsMirror[int](nil, nil))
+	require.False(t, IsMirror(a, nil))
+
+	relabelled := CloneInverted(a)
+	relabelled.Left.Val = 9
+	require.False(t, IsMirror(a, relabelled))
+}
+
+// 5. IsSymmetric checks a tree against itself across the root.
+func TestIsSymmetric(t *testing.T) {
+	require.True(t, IsSymmetric(BuildFromLevelOrder(levelOrderInts(1, 2, 2, 3, 4, 4, 3))))
+	require.False(t, IsSymmetric(BuildFromLevelOrder(levelOrderInts(1, 2, 2, nil, 3, nil, 3))))
+	require.True(t, IsSymmetric[int](nil))
+	require.True(t, IsSymmetric(&Node{Val: 1}))
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Diff produces a positional edit script in application order.
+func TestDiffEditScript(t *testing.T) {
+	a := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5))
+	b := BuildFromLevelOrder(levelOrderInts(1, 7, 3, nil, 5, 6))
+
+	edits := Diff(a, b)
+	require.Equal(t, []Edit{
+		{Op: EditRelabel, Path: "L", Old: 2, New: 7},
+		{Op: EditDelete, Path: "LL", Old: 4},
+		{Op: EditInsert, Path: "RL", New: 6},
+	}, edits)
+	require.Equal(t, "relabel L 2 -> 7", edits[0].String())
+	require.Equal(t, []Edit{}, Diff(a, a))
+}
+
+// 2. ApplyDiff rebuilds b from a without touching a.
+func TestApplyDiffRoundTrip(t *testing.T) {
+	for seed := range uint64(20) {
+		a := GenerateRandomTree(int(seed)*3, WithSeed(seed), WithValueRange(0, 5))
+		b := GenerateRandomTree(int(seed)*2, WithSeed(seed+100), WithValueRange(0, 5))
+		before := Serialize(a)
+
+		got, err := ApplyDiff(a, Diff(a, b))
+		require.NoError(t, err)
+		require.True(t, Equal(b, got), "seed %d", seed)
+		require.Equal(t, before, Serialize(a))
+	}
+}
+
+// 3. Whole subtrees are inserted top-down and deleted bottom-up.
+func TestDiffSubtrees(t *testing.T) {
+	a := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5))
+	edits := Diff(a, nil)
+	require.Len(t, edits, 5)
+	require.Equal(t, Edit{Op: EditDelete, Path: "", Old: 1}, edits[4])
+
+	root, err := ApplyDiff(a, edits)
+	require.NoError(t, err)
+	require.Nil(t, root)
+
+	edits = Diff(nil, a)
+	require.Equal(t, Edit{Op: EditInsert, Path: "", New: 1}, edits[0])
+	root, err = ApplyDiff(nil, edits)
+	require.NoError(t, err)
+	require.True(t, Equal(a, root))
+}
+
+// 4. Edits that do not fit the tree are rejected.
+func TestApplyDiffInvalid(t *testing.T) {
+	a := BuildFromLevelOrder(levelOrderInts(1, 2))
+	for _, e := range []Edit{
+		{Op: EditInsert, Path: "L", New: 9},
+		{Op: EditDelete, Path: "", Old: 1},
+		{Op: EditRelabel, Path: "L", Old: 3, New: 4},
+		{Op: EditRelabel, Path: "R", Old: 3, New: 4},
+		{Op: EditInsert, Path: "RL", New: 9},
+		{Op: EditInsert, Path: "X", New: 9},
+	} {
+		_, err := ApplyDiff(a, []Edit{e})
+		require.ErrorIs(t, err, ErrInvalidEdit, e.String())
+	}
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// failingWriter rejects every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+// 1. Empty tree produces an empty digraph.
+func TestExportDOTEmptyTree(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, ExportDOT(nil, &sb))
+	require.Equal(t, "digraph tree {\n\tnode [shape=circle];\n}\n", sb.String())
+}
+
+// 2. Nodes and labelled edges are emitted in level order.
+func TestExportDOTDefault(t *testing.T) {
+	root := &Node{Val: 5}
+	root.Left = &Node{Val: 2}
+	root.Right = &Node{Val: 7}
+
+	var sb strings.Builder
+	require.NoError(t, ExportDOT(root, &sb))
+	want := "digraph tree {\n" +
+		"\tnode [shape=circle];\n" +
+		"\tn0 [label=\"5\"];\n" +
+		"\tn1 [label=\"2\"];\n" +
+		"\tn2 [label=\"7\"];\n" +
+		"\tn0 -> n1 [label=\"L\", style=\"solid\"];\n" +
+		"\tn0 -> n2 [label=\"R\", style=\"solid\"];\n" +
+		"}\n"
+	require.Equal(t, want, sb.String())
+}
+
+// 3. Options change labels, highlight level maxima, and style edges.
+func TestExportDOTOptions(t *testing.T) {
+	root := &Node{Val: 5}
+	root.Left = &Node{Val: 2}
+	root.Right = &Node{Val: 7}
+
+	var sb strings.Builder
+	err := ExportDOT(root, &sb,
+		WithDOTLabel(func(n *Node) string { return "v" + string(rune('0'+n.Val)) }),
+		WithDOTHighlightMax(),
+		WithDOTEdgeStyles("dashed", "bold"),
+	)
+	require.NoError(t, err)
+	out := sb.String()
+	require.Contains(t, out, "n0 [label=\"v5\", style=filled, fillcolor=gold];")
+	require.Contains(t, out, "n1 [label=\"v2\"];")
+	require.Contains(t, out, "n2 [label=\"v7\", style=filled, fillcolor=gold];")
+	require.Contains(t, out, "n0 -> n1 [label=\"L\", style=\"dashed\"];")
+	require.Contains(t, out, "n0 -> n2 [label=\"R\", style=\"bold\"];")
+}
+
+// 4. Write failures are reported.
+func TestExportDOTWriteError(t *testing.T) {
+	require.Error(t, ExportDOT(&Node{Val: 1}, failingWriter{}))
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Union merges sets once; Find and Connected see the result.
+func TestDSUUnion(t *testing.T) {
+	d := NewDSU(6)
+	require.Equal(t, 6, d.Sets())
+	require.True(t, d.Union(0, 1))
+	require.True(t, d.Union(2, 3))
+	require.True(t, d.Union(1, 3))
+	require.False(t, d.Union(0, 2))
+
+	require.True(t, d.Connected(0, 3))
+	require.False(t, d.Connected(0, 4))
+	require.Equal(t, d.Find(0), d.Find(2))
+	require.Equal(t, 3, d.Sets())
+	require.Equal(t, 6, d.Len())
+}
+
+// 2. ConnectedComponents lists every set, ordered by smallest element.
+func TestDSUConnectedComponents(t *testing.T) {
+	d := NewDSU(7)
+	d.Union(5, 1)
+	d.Union(6, 3)
+	d.Union(3, 0)
+	require.Equal(t, [][]int{{0, 3, 6}, {1, 5}, {2}, {4}}, d.ConnectedComponents())
+	require.Equal(t, [][]int{}, NewDSU(0).ConnectedComponents())
+
+	x := d.Add()
+	require.Equal(t, 7, x)
+	require.Equal(t, 5, d.Sets())
+	require.False(t, d.Connected(x, 0))
+}
+
+// 3. Random unions agree with a naive labelling.
+func TestDSUMatchesNaive(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	const n = 200
+	d := NewDSU(n)
+	label := make([]int, n)
+	for i := range label {
+		label[i] = i
+	}
+	for range 150 {
+		a, b := r.IntN(n), r.IntN(n)
+		merged := d.Union(a, b)
+		require.Equal(t, label[a] != label[b], merged)
+		if from, to := label[b], label[a]; from != to {
+			for i := range label {
+				if label[i] == from {
+					label[i] = to
+				}
+			}
+		}
+	}
+	for range 500 {
+		a, b := r.IntN(n), r.IntN(n)
+		require.Equal(t, label[a] == label[b], d.Connected(a, b))
+	}
+}
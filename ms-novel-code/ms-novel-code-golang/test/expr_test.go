@@ -0,0 +1,87 @@
+package core
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Infix input respects precedence, associativity and parentheses.
+func TestBuildExpressionTreeInfix(t *testing.T) {
+	cases := map[string]float64{
+		"1 + 2 * 3":          7,
+		"( 1 + 2 ) * 3":      9,
+		"8 - 3 - 2":          3,
+		"2 ^ 3 ^ 2":          512,
+		"-1.5 * ( 4 / 2 )":   -3,
+		"( ( 7 ) )":          7,
+		"10 / 4 - 2 ^ 2 * 1": -1.5,
+	}
+	for expr, want := range cases {
+		root, err := BuildExpressionTree(strings.Fields(expr))
+		require.NoError(t, err, expr)
+		got, err := Evaluate(root)
+		require.NoError(t, err, expr)
+		require.InDelta(t, want, got, 1e-9, expr)
+	}
+}
+
+// 2. Postfix input builds the same tree as the equivalent infix.
+func TestBuildExpressionTreePostfix(t *testing.T) {
+	post, err := BuildExpressionTree(strings.Fields("3 4 + 2 *"))
+	require.NoError(t, err)
+	in, err := BuildExpressionTree(strings.Fields("( 3 + 4 ) * 2"))
+	require.NoError(t, err)
+	require.True(t, Equal(post, in))
+	require.Equal(t, 3, Height(post))
+
+	v, err := Evaluate(post)
+	require.NoError(t, err)
+	require.Equal(t, 14.0, v)
+}
+
+// 3. The printers round-trip through the parser with minimal parentheses.
+func TestExpressionPrinters(t *testing.T) {
+	for _, expr := range []string{
+		"1 + 2 * 3", "( 1 + 2 ) * 3", "8 - ( 3 - 2 )", "8 - 3 - 2",
+		"( 2 ^ 3 ) ^ 2", "2 ^ 3 ^ 2", "1 / ( 2 * 3 )", "42",
+	} {
+		root, err := BuildExpressionTree(strings.Fields(expr))
+		require.NoError(t, err)
+		require.Equal(t, expr, ToInfix(root))
+
+		again, err := BuildExpressionTree(strings.Fields(ToPostfix(root)))
+		require.NoError(t, err)
+		require.True(t, Equal(root, again), expr)
+	}
+	root, _ := BuildExpressionTree(strings.Fields("( 1 + 2 ) * 3"))
+	require.Equal(t, "1 2 + 3 *", ToPostfix(root))
+	require.Equal(t, "", ToInfix(nil))
+	require.Equal(t, "", ToPostfix(nil))
+}
+
+// 4. Malformed input and bad arithmetic are reported.
+func TestExpressionErrors(t *testing.T) {
+	for _, expr := range []string{
+		"", "1 +", "+ 1", "1 2", "( 1 + 2", "1 + 2 )", "1 + x", ") 1", "1 (", "1 + +", "1 2 + +",
+	} {
+		_, err := BuildExpressionTree(strings.Fields(expr))
+		require.ErrorIs(t, err, ErrInvalidExpression, expr)
+	}
+
+	root, err := BuildExpressionTree(strings.Fields("1 / ( 2 - 2 )"))
+	require.NoError(t, err)
+	_, err = Evaluate(root)
+	require.ErrorIs(t, err, ErrDivisionByZero)
+
+	_, err = Evaluate(nil)
+	require.ErrorIs(t, err, ErrInvalidExpression)
+	_, err = Evaluate(&ExprNode{Val: ExprToken{Op: "+"}, Left: &ExprNode{}})
+	require.ErrorIs(t, err, ErrInvalidExpression)
+
+	v, err := Evaluate(&ExprNode{Val: ExprToken{Op: "^"}, Left: &ExprNode{Val: ExprToken{Value: 2}}, Right: &ExprNode{Val: ExprToken{Value: 0.5}}})
+	require.NoError(t, err)
+	require.InDelta(t, math.Sqrt2, v, 1e-12)
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// listVals follows Right pointers from head, checking that every Left
+// pointer is nil.
+func listVals(t *testing.T, head *Node) []int {
+	vals := []int{}
+	for n := head; n != nil; n = n.Right {
+		require.Nil(t, n.Left)
+		vals = append(vals, n.Val)
+	}
+	return vals
+}
+
+// 1. FlattenPreorder links nodes in preorder through Right.
+func TestFlattenPreorder(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 5, 3, 4, nil, 6))
+	want := Preorder(root)
+
+	require.Same(t, root, FlattenPreorder(root))
+	require.Equal(t, want, listVals(t, root))
+	require.Nil(t, FlattenPreorder[int](nil))
+
+	deep := leftSkewedTree(1 << 14)
+	FlattenPreorder(deep)
+	require.Len(t, listVals(t, deep), 1<<14)
+}
+
+// 2. A BST becomes a sorted doubly linked list.
+func TestBSTToSortedDoublyLinkedList(t *testing.T) {
+	bst := NewBST(50, 30, 70, 20, 40, 60, 80, 35)
+	want := bst.InOrder()
+
+	head := BSTToSortedDoublyLinkedList(bst.Root)
+	require.Equal(t, 20, head.Val)
+	require.Nil(t, head.Left)
+
+	var got []int
+	var tail *Node
+	for n := head; n != nil; n = n.Right {
+		if tail != nil {
+			require.Same(t, tail, n.Left)
+		}
+		got = append(got, n.Val)
+		tail = n
+	}
+	require.Equal(t, want, got)
+
+	var back []int
+	for n := tail; n != nil; n = n.Left {
+		back = append(back, n.Val)
+	}
+	require.Equal(t, []int{80, 70, 60, 50, 40, 35, 30, 20}, back)
+}
+
+// 3. Edge cases: empty and single-node trees.
+func TestBSTToSortedDoublyLinkedListSmall(t *testing.T) {
+	require.Nil(t, BSTToSortedDoublyLinkedList[int](nil))
+	n := &Node{Val: 1}
+	head := BSTToSortedDoublyLinkedList(n)
+	require.Same(t, n, head)
+	require.Nil(t, head.Left)
+	require.Nil(t, head.Right)
+}
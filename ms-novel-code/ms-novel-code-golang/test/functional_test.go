@@ -0,0 +1,110 @@
+package core
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. MapTree keeps the shape and leaves the source alone.
+func TestMapTree(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4))
+	doubled := MapTree(root, func(v int) int { return v * 2 })
+	require.Equal(t, levelOrderInts(2, 4, 6, nil, 8), ToLevelOrder(doubled))
+	require.Equal(t, levelOrderInts(1, 2, 3, nil, 4), ToLevelOrder(root))
+
+	labels := MapTree(root, strconv.Itoa)
+	require.Equal(t, []string{"1", "2", "4", "3"}, Preorder(labels))
+	require.Nil(t, MapTree[int, int](nil, nil))
+}
+
+// 2. Prune keeps the paths to matching nodes only.
+func TestPrune(t *testing.T) {
+	//        1
+	//      /   \
+	//     2     3
+	//    / \     \
+	//   4   5     6
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, nil, 6))
+	got := Prune(root, func(n *Node) bool { return n.Val == 5 })
+	require.Same(t, root, got)
+	require.Equal(t, levelOrderInts(1, 2, nil, nil, 5), ToLevelOrder(got))
+
+	root = BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, nil, 6))
+	got = Prune(root, func(n *Node) bool { return n.Val%2 == 0 })
+	require.Equal(t, levelOrderInts(1, 2, 3, 4, nil, nil, 6), ToLevelOrder(got))
+}
+
+// 3. Prune returns nil when nothing matches.
+func TestPruneNothingKept(t *testing.T) {
+	root := completeTree(15)
+	require.Nil(t, Prune(root, func(*Node) bool { return false }))
+	require.Nil(t, Prune[int](nil, func(*Node) bool { return true }))
+
+	deep := leftSkewedTree(1 << 14)
+	got := Prune(deep, func(n *Node) bool { return n.Val == 0 })
+	require.Equal(t, 1<<14, Size(got))
+}
+
+// 4. FilterValues collects matches in preorder.
+func TestFilterValues(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, nil, 6))
+	even := FilterValues(root, func(v int) bool { return v%2 == 0 })
+	require.Equal(t, []int{2, 4, 6}, even)
+	require.Equal(t, []int{}, FilterValues(nil, func(int) bool { return true }))
+}
+
+// 5. MergeTrees overlays two trees, adding by default.
+func TestMergeTrees(t *testing.T) {
+	a := BuildFromLevelOrder(levelOrderInts(1, 3, 2, 5))
+	b := BuildFromLevelOrder(levelOrderInts(2, 1, 3, nil, 4, nil, 7))
+
+	sum := MergeTrees(a, b, nil)
+	require.Equal(t, levelOrderInts(3, 4, 5, 5, 4, nil, 7), ToLevelOrder(sum))
+	require.Equal(t, levelOrderInts(1, 3, 2, 5), ToLevelOrder(a))
+
+	hi := MergeTrees(a, b, func(x, y int) int { return max(x, y) })
+	require.Equal(t, levelOrderInts(2, 3, 3, 5, 4, nil, 7), ToLevelOrder(hi))
+
+	// Copied subtrees are not shared with the inputs.
+	require.NotSame(t, b.Right.Right, sum.Right.Right)
+	require.Nil(t, MergeTrees(nil, nil, nil))
+
+	words := MergeTreesFunc(
+		&TreeNode[string]{Val: "a"},
+		&TreeNode[string]{Val: "b", Left: &TreeNode[string]{Val: "c"}},
+		func(x, y string) string { return x + y })
+	require.Equal(t, []string{"ab", "c"}, Preorder(words))
+}
+
+// 6. Clone shares no nodes with the original.
+func TestClone(t *testing.T) {
+	root := GenerateRandomTree(200, WithSeed(8))
+	c := Clone(root)
+	require.True(t, Equal(root, c))
+	seen := map[*Node]bool{}
+	eachNode(root, func(n *Node) { seen[n] = true })
+	eachNode(c, func(n *Node) { require.False(t, seen[n]) })
+
+	c.Left = nil
+	require.NotNil(t, root.Left)
+	require.Nil(t, Clone[int](nil))
+}
+
+// 7. ExtractSubtree copies the first preorder match.
+func TestExtractSubtree(t *testing.T) {
+	//      1
+	//    2   3
+	//   4   2
+	//        5
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, nil, 2, nil, nil, nil, nil, 5))
+	sub := ExtractSubtree(root, func(n *Node) bool { return n.Val == 2 })
+	require.True(t, Equal(root.Left, sub))
+	require.NotSame(t, root.Left, sub)
+
+	sub = ExtractSubtree(root, func(n *Node) bool { return n.Val == 2 && n.Right != nil })
+	require.True(t, Equal(root.Right.Left, sub))
+	require.Nil(t, ExtractSubtree(root, func(n *Node) bool { return n.Val > 10 }))
+	require.Nil(t, ExtractSubtree(nil, func(*Node) bool { return true }))
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Every shape has n nodes and the expected structure.
+func TestGenerateRandomTreeShapes(t *testing.T) {
+	require.Nil(t, GenerateRandomTree(0))
+
+	random := GenerateRandomTree(500, WithSeed(1))
+	require.Equal(t, 500, Size(random))
+
+	balanced := GenerateRandomTree(500, WithShape(ShapeBalanced))
+	require.True(t, IsComplete(balanced))
+	require.Equal(t, 9, Height(balanced))
+
+	left := GenerateRandomTree(50, WithShape(ShapeLeftSkewed))
+	require.Equal(t, 50, Height(left))
+	require.Nil(t, left.Right)
+
+	right := GenerateRandomTree(50, WithShape(ShapeRightSkewed))
+	require.Equal(t, 50, Height(right))
+	require.Nil(t, right.Left)
+}
+
+// 2. Values stay within the requested range.
+func TestGenerateRandomTreeValueRange(t *testing.T) {
+	root := GenerateRandomTree(1000, WithValueRange(-3, 3), WithSeed(2))
+	for _, v := range Preorder(root) {
+		require.GreaterOrEqual(t, v, -3)
+		require.LessOrEqual(t, v, 3)
+	}
+	require.Panics(t, func() { WithValueRange(1, 0) })
+	require.Equal(t, 10, Size(GenerateRandomTree(10, WithValueRange(math.MinInt, math.MaxInt))))
+}
+
+// 3. A seed makes generation reproducible.
+func TestGenerateRandomTreeSeed(t *testing.T) {
+	a := GenerateRandomTree(200, WithSeed(42))
+	b := GenerateRandomTree(200, WithSeed(42))
+	c := GenerateRandomTree(200, WithSeed(43))
+	require.True(t, Equal(a, b))
+	require.False(t, Equal(a, c))
+}
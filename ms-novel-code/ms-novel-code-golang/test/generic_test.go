@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. float64 trees work through the ordered helpers.
+func TestRowWiseMaxOfFloat64(t *testing.T) {
+	root := &TreeNode[float64]{Val: 1.5}
+	root.Left = &TreeNode[float64]{Val: -2.25}
+	root.Right = &TreeNode[float64]{Val: 0.75}
+
+	require.Equal(t, []float64{1.5, 0.75}, RowWiseMaxOf(root))
+	require.Equal(t, []float64{1.5, -2.25}, RowWiseMinOf(root))
+}
+
+// 2. string trees compare lexicographically.
+func TestRowWiseMaxOfString(t *testing.T) {
+	root := &TreeNode[string]{Val: "m"}
+	root.Left = &TreeNode[string]{Val: "apple"}
+	root.Right = &TreeNode[string]{Val: "zebra"}
+
+	require.Equal(t, []string{"m", "zebra"}, RowWiseMaxOf(root))
+}
+
+// 3. Custom structs are ordered by a user-supplied less function.
+func TestRowWiseMaxFuncCustomStruct(t *testing.T) {
+	type employee struct {
+		Name   string
+		Salary int
+	}
+	bySalary := func(a, b employee) bool { return a.Salary < b.Salary }
+
+	root := &TreeNode[employee]{Val: employee{"ceo", 300}}
+	root.Left = &TreeNode[employee]{Val: employee{"cto", 250}}
+	root.Right = &TreeNode[employee]{Val: employee{"cfo", 260}}
+
+	require.Equal(t, []employee{{"ceo", 300}, {"cfo", 260}}, RowWiseMaxFunc(root, bySalary))
+	require.Equal(t, []employee{{"ceo", 300}, {"cto", 250}}, RowWiseMinFunc(root, bySalary))
+}
+
+// 4. Empty generic trees still return a non-nil slice.
+func TestRowWiseMaxOfEmptyTree(t *testing.T) {
+	got := RowWiseMaxOf[string](nil)
+	require.NotNil(t, got)
+	require.Empty(t, got)
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. ToAdjacencyList lists every node with its children, left first.
+func TestToAdjacencyList(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4))
+	adj := ToAdjacencyList(root)
+	require.Len(t, adj, 4)
+	require.Equal(t, []*Node{root.Left, root.Right}, adj[root])
+	require.Equal(t, []*Node{root.Left.Right}, adj[root.Left])
+	require.Equal(t, []*Node{}, adj[root.Right])
+	require.Empty(t, ToAdjacencyList[int](nil))
+}
+
+// 2. The adjacency list round-trips, keeping lone right children.
+func TestFromAdjacencyListRoundTrip(t *testing.T) {
+	for _, shape := range []Shape{ShapeRandom, ShapeRightSkewed, ShapeBalanced} {
+		root := GenerateRandomTree(50, WithShape(shape), WithSeed(3))
+		want := Clone(root)
+		got, err := FromAdjacencyList(ToAdjacencyList(root), root)
+		require.NoError(t, err)
+		require.Same(t, root, got)
+		require.True(t, Equal(want, got))
+	}
+
+	// Unlinked nodes rebuild exactly when no node has a lone right child.
+	for _, shape := range []Shape{ShapeLeftSkewed, ShapeBalanced} {
+		root := GenerateRandomTree(50, WithShape(shape), WithSeed(4))
+		want := Clone(root)
+		adj := ToAdjacencyList(root)
+		for n := range adj {
+			n.Left, n.Right = nil, nil
+		}
+		got, err := FromAdjacencyList(adj, root)
+		require.NoError(t, err)
+		require.True(t, Equal(want, got))
+	}
+
+	// Fresh nodes: lists map to Left then Right.
+	a, b, c := &Node{Val: 1}, &Node{Val: 2}, &Node{Val: 3}
+	got, err := FromAdjacencyList(map[*Node][]*Node{a: {b}, b: {c}}, a)
+	require.NoError(t, err)
+	require.Equal(t, "1,2,3,#,#,#,#", Serialize(got))
+}
+
+// 3. Malformed edges are rejected without touching any node.
+func TestFromAdjacencyListErrors(t *testing.T) {
+	a, b, c, d := &Node{Val: 1}, &Node{Val: 2}, &Node{Val: 3}, &Node{Val: 4}
+	cases := map[string]map[*Node][]*Node{
+		"three children": {a: {b, c, d}},
+		"two parents":    {a: {b, c}, b: {d}, c: {d}},
+		"back to root":   {a: {b}, b: {a}},
+		"cycle":          {a: {b}, c: {d}, d: {c}},
+		"second root":    {a: {b}, c: {d}},
+		"nil child":      {a: {nil}},
+	}
+	for name, edges := range cases {
+		_, err := FromAdjacencyList(edges, a)
+		require.ErrorIs(t, err, ErrNotATree, name)
+		for _, n := range []*Node{a, b, c, d} {
+			require.Nil(t, n.Left, name)
+			require.Nil(t, n.Right, name)
+		}
+	}
+
+	_, err := FromAdjacencyList(map[*Node][]*Node{a: {}}, nil)
+	require.ErrorIs(t, err, ErrNotATree)
+	got, err := FromAdjacencyList[int](nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"cmp"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// priorityQueue is the behaviour shared by Heap and NodeHeap.
+type priorityQueue interface {
+	Push(int)
+	Pop() (int, bool)
+	Peek() (int, bool)
+	Len() int
+}
+
+// drain pops every element in order.
+func drain(h priorityQueue) []int {
+	var out []int
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		out = append(out, v)
+	}
+	return out
+}
+
+// 1. Empty heaps report false from Peek and Pop.
+func TestHeapEmpty(t *testing.T) {
+	for _, h := range []priorityQueue{NewMinHeap[int](), NewNodeHeap(cmp.Less[int])} {
+		_, ok := h.Peek()
+		require.False(t, ok)
+		_, ok = h.Pop()
+		require.False(t, ok)
+	}
+}
+
+// 2. Both variants pop in comparator order after random pushes.
+func TestHeapPushPopOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	vals := make([]int, 500)
+	for i := range vals {
+		vals[i] = rng.Intn(100)
+	}
+	want := append([]int(nil), vals...)
+	sort.Ints(want)
+
+	for _, h := range []priorityQueue{NewMinHeap[int](), NewNodeHeap(cmp.Less[int])} {
+		for _, v := range vals {
+			h.Push(v)
+		}
+		top, _ := h.Peek()
+		require.Equal(t, want[0], top)
+		require.Equal(t, want, drain(h))
+	}
+}
+
+// 3. Heapify builds max-heaps from slices in both variants.
+func TestHeapify(t *testing.T) {
+	greater := func(a, b int) bool { return a > b }
+	require.Equal(t, []int{9, 7, 5, 3, 1}, drain(Heapify([]int{3, 9, 1, 7, 5}, greater)))
+	require.Equal(t, []int{9, 7, 5, 3, 1}, drain(HeapifyNodes([]int{3, 9, 1, 7, 5}, greater)))
+}
+
+// 4. The pointer-backed heap stays a complete tree in heap order.
+func TestNodeHeapShape(t *testing.T) {
+	h := NewNodeHeap(cmp.Less[int])
+	for _, v := range []int{5, 3, 8, 1, 9, 2, 7} {
+		h.Push(v)
+	}
+	h.Pop()
+	require.True(t, IsComplete(h.Root()))
+	require.Equal(t, 6, Size(h.Root()))
+	require.Equal(t, 2, h.Root().Val)
+	require.IsNonDecreasing(t, RowWiseMinOf(h.Root()))
+}
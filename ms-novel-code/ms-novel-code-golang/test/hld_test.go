@@ -0,0 +1,89 @@
+package core
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// naivePath lists the values on the path between a and b.
+func naivePath(p ParentMap[int], a, b *Node) []int {
+	lca := p.LCA(a, b)
+	var vals []int
+	for n := a; n != lca; n = p.Parent(n) {
+		vals = append(vals, n.Val)
+	}
+	vals = append(vals, lca.Val)
+	for n := b; n != lca; n = p.Parent(n) {
+		vals = append(vals, n.Val)
+	}
+	return vals
+}
+
+// 1. Path aggregates on a hand-checked tree.
+func TestHLDPathQueries(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(3, 5, 1, 6, 2, 0, 8, nil, nil, 7, 4))
+	n6, n4, n8 := root.Left.Left, root.Left.Right.Right, root.Right.Right
+	h := NewHLD(root)
+	require.Equal(t, 9, h.Len())
+
+	sum, ok := h.PathSum(n4, n8)
+	require.True(t, ok)
+	require.Equal(t, 4+2+5+3+1+8, sum)
+	hi, _ := h.PathMax(n6, n4)
+	require.Equal(t, 6, hi)
+	lo, _ := h.PathMin(n4, n4)
+	require.Equal(t, 4, lo)
+}
+
+// 2. Random trees with updates agree with a naive path walk.
+func TestHLDMatchesNaive(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 10))
+	for _, shape := range []Shape{ShapeRandom, ShapeLeftSkewed, ShapeBalanced} {
+		root := GenerateRandomTree(120, WithShape(shape), WithSeed(r.Uint64()))
+		nodes := postorderNodes(root)
+		h, parents := NewHLD(root), AttachParents(root)
+		for range 400 {
+			a, b := nodes[r.IntN(len(nodes))], nodes[r.IntN(len(nodes))]
+			if r.IntN(4) == 0 {
+				require.True(t, h.Update(a, r.IntN(1000)-500))
+				continue
+			}
+			vals := naivePath(parents, a, b)
+			sum, ok := h.PathSum(a, b)
+			require.True(t, ok)
+			want := 0
+			for _, v := range vals {
+				want += v
+			}
+			require.Equal(t, want, sum)
+			hi, _ := h.PathMax(a, b)
+			require.Equal(t, slices.Max(vals), hi)
+			lo, _ := h.PathMin(a, b)
+			require.Equal(t, slices.Min(vals), lo)
+		}
+	}
+}
+
+// 3. Foreign nodes and empty trees are reported.
+func TestHLDMissing(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3))
+	h := NewHLD(root)
+	stranger := &Node{Val: 7}
+
+	_, ok := h.PathSum(root, stranger)
+	require.False(t, ok)
+	_, ok = h.PathMax(nil, root)
+	require.False(t, ok)
+	require.False(t, h.Update(stranger, 1))
+	require.Equal(t, 7, stranger.Val)
+
+	require.True(t, h.Update(root.Left, 10))
+	require.Equal(t, 10, root.Left.Val)
+	sum, _ := h.PathSum(root.Left, root.Right)
+	require.Equal(t, 14, sum)
+
+	require.Zero(t, NewHLD(nil).Len())
+}
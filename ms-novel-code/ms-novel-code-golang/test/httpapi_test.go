@@ -0,0 +1,80 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// post sends body to path on h and returns the status and response body.
+func post(h http.Handler, path, body string) (int, string) {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return rec.Code, strings.TrimSpace(rec.Body.String())
+}
+
+const httpTree = `{"val":1,"left":{"val":3,"left":{"val":5},"right":{"val":3}},"right":{"val":2,"right":{"val":9}}}`
+
+// 1. The row-wise endpoints and /stats answer with JSON results.
+func TestHandlerEndpoints(t *testing.T) {
+	h := NewHandler()
+	for path, want := range map[string]string{
+		"/rowwise-max": `{"levels":[1,3,9]}`,
+		"/rowwise-min": `{"levels":[1,2,3]}`,
+		"/rowwise-sum": `{"levels":[1,5,17]}`,
+		"/stats":       `{"size":6,"height":3,"balanced":true,"complete":false}`,
+	} {
+		code, body := post(h, path, httpTree)
+		require.Equal(t, http.StatusOK, code, path)
+		require.JSONEq(t, want, body, path)
+	}
+
+	code, body := post(h, "/rowwise-max", "null")
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `{"levels":[]}`, body)
+}
+
+// 2. Bad requests get an error status and a JSON error message.
+func TestHandlerErrors(t *testing.T) {
+	h := NewHandler(WithMaxBodyBytes(64))
+	code, body := post(h, "/rowwise-max", `{"val":`)
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Contains(t, body, `"error":"decode tree`)
+
+	for _, body := range []string{`{"val":1} {"val":2}`, `{"val":1}}`, `{"val":1}]`, `{"val":1} x`} {
+		code, _ = post(h, "/rowwise-max", body)
+		require.Equal(t, http.StatusBadRequest, code, body)
+	}
+	code, _ = post(h, "/rowwise-max", "{\"val\":1} \n")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = post(h, "/rowwise-max", `{"val":1}`+strings.Repeat(" ", 64))
+	require.Equal(t, http.StatusRequestEntityTooLarge, code)
+
+	code, _ = post(h, "/rowwise-max", httpTree)
+	require.Equal(t, http.StatusRequestEntityTooLarge, code)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/rowwise-max", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+// 3. Traversal limits turn into 422 responses on every endpoint.
+func TestHandlerLimits(t *testing.T) {
+	h := NewHandler(WithTraversalOptions(WithMaxNodes(3)))
+	code, body := post(h, "/rowwise-sum", httpTree)
+	require.Equal(t, http.StatusUnprocessableEntity, code)
+	require.Contains(t, body, "limit")
+
+	code, _ = post(h, "/stats", httpTree)
+	require.Equal(t, http.StatusUnprocessableEntity, code)
+
+	for _, path := range []string{"/rowwise-sum", "/stats"} {
+		code, _ = post(h, path, `{"val":1,"left":{"val":2}}`)
+		require.Equal(t, http.StatusOK, code, path)
+		code, _ = post(h, path, "null")
+		require.Equal(t, http.StatusOK, code, path)
+	}
+}
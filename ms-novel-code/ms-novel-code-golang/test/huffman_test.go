@@ -0,0 +1,77 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// byteFreqs counts the bytes of s.
+func byteFreqs(s string) map[byte]int {
+	freqs := map[byte]int{}
+	for i := 0; i < len(s); i++ {
+		freqs[s[i]]++
+	}
+	return freqs
+}
+
+// 1. Codes are prefix-free and their lengths follow the frequencies.
+func TestHuffmanCodes(t *testing.T) {
+	root := BuildHuffmanTree(map[byte]int{'a': 45, 'b': 13, 'c': 12, 'd': 16, 'e': 9, 'f': 5})
+	require.Equal(t, 100, root.Val)
+
+	codes := HuffmanCodes(root)
+	require.Equal(t, map[byte]string{
+		'a': "0", 'c': "100", 'b': "101", 'f': "1100", 'e': "1101", 'd': "111",
+	}, codes)
+	for s1, c1 := range codes {
+		for s2, c2 := range codes {
+			if s1 != s2 {
+				require.False(t, len(c1) <= len(c2) && c2[:len(c1)] == c1, "%q prefixes %q", c1, c2)
+			}
+		}
+	}
+}
+
+// 2. Encode and Decode round-trip.
+func TestHuffmanRoundTrip(t *testing.T) {
+	text := "abracadabra, the quick brown fox"
+	root := BuildHuffmanTree(byteFreqs(text))
+	bits, err := HuffmanEncode(root, []byte(text))
+	require.NoError(t, err)
+	require.Less(t, len(bits), 8*len(text))
+
+	got, err := HuffmanDecode(root, bits)
+	require.NoError(t, err)
+	require.Equal(t, text, string(got))
+}
+
+// 3. Degenerate inputs: no symbols, a single symbol.
+func TestHuffmanDegenerate(t *testing.T) {
+	require.Nil(t, BuildHuffmanTree(nil))
+	require.Nil(t, BuildHuffmanTree(map[byte]int{'x': 0}))
+	require.Empty(t, HuffmanCodes(nil))
+
+	root := BuildHuffmanTree(map[byte]int{'z': 3})
+	require.Equal(t, map[byte]string{'z': "0"}, HuffmanCodes(root))
+	bits, err := HuffmanEncode(root, []byte("zzz"))
+	require.NoError(t, err)
+	require.Equal(t, "000", bits)
+	got, err := HuffmanDecode(root, bits)
+	require.NoError(t, err)
+	require.Equal(t, "zzz", string(got))
+}
+
+// 4. Bad input is reported with the sentinel errors.
+func TestHuffmanErrors(t *testing.T) {
+	root := BuildHuffmanTree(map[byte]int{'a': 1, 'b': 2, 'c': 3})
+	_, err := HuffmanEncode(root, []byte("abd"))
+	require.ErrorIs(t, err, ErrUnknownSymbol)
+
+	for _, bits := range []string{"1", "012", "1x"} {
+		_, err = HuffmanDecode(root, bits)
+		require.ErrorIs(t, err, ErrInvalidCode, bits)
+	}
+	_, err = HuffmanDecode(BuildHuffmanTree(map[byte]int{'a': 1}), "1")
+	require.ErrorIs(t, err, ErrInvalidCode)
+}
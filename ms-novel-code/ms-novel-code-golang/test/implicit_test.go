@@ -0,0 +1,52 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Row-wise max over the implicit form matches the pointer form.
+func TestImplicitRowWiseMax(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 6, 7, 8, 100, 1023} {
+		root := completeTree(n)
+		it, err := ImplicitFromNode(root)
+		require.NoError(t, err)
+		require.Equal(t, n, it.Len())
+		require.Equal(t, Height(root), it.Height())
+		require.Equal(t, rowWiseMax(root)["output"], ImplicitRowWiseMax(it), "n=%d", n)
+	}
+}
+
+// 2. Conversion round-trips and lays out levels contiguously.
+func TestImplicitRoundTrip(t *testing.T) {
+	it := NewImplicitTree([]int{10, 5, 4, 8, 9, 1})
+	root := it.ToNode()
+	require.Equal(t, [][]int{{10}, {5, 4}, {8, 9, 1}}, levelsOf(root))
+	require.Equal(t, []int{5, 4}, it.Level(1))
+	require.Equal(t, []int{8, 9, 1}, it.Level(2))
+	require.Nil(t, it.Level(3))
+
+	back, err := ImplicitFromNode(root)
+	require.NoError(t, err)
+	require.Equal(t, it.Vals, back.Vals)
+
+	require.Nil(t, NewImplicitTree([]int{}).ToNode())
+	require.Equal(t, []int{}, ImplicitRowWiseMax(NewImplicitTree[int](nil)))
+}
+
+// 3. Trees with gaps cannot be stored implicitly.
+func TestImplicitFromNodeNotComplete(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4))
+	_, err := ImplicitFromNode(root)
+	require.ErrorIs(t, err, ErrNotComplete)
+}
+
+// levelsOf returns the values of each level of root.
+func levelsOf(root *Node) [][]int {
+	var res [][]int
+	for _, vals := range Levels(root) {
+		res = append(res, vals)
+	}
+	return res
+}
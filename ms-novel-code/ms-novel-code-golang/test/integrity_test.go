@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Well-formed trees pass, including deep chains.
+func TestValidateTreeValid(t *testing.T) {
+	require.NoError(t, ValidateTree[int](nil))
+	require.NoError(t, ValidateTree(BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4))))
+	require.NoError(t, ValidateTree(GenerateRandomTree(100000, WithShape(ShapeLeftSkewed))))
+}
+
+// 2. Back links are cycles and report both paths.
+func TestValidateTreeCycle(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4))
+	root.Left.Right.Left = root.Left // 4 points back to 2
+	err := ValidateTree(root)
+	require.ErrorIs(t, err, ErrCycle)
+	require.EqualError(t, err, `core: tree contains a cycle: link "LRL" points back to ancestor 2 at "L"`)
+
+	self := &Node{Val: 7}
+	self.Right = self
+	require.ErrorIs(t, ValidateTree(self), ErrCycle)
+}
+
+// 3. Subtrees linked twice are shared, even between siblings.
+func TestValidateTreeShared(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4))
+	root.Right.Right = root.Left.Left // 4 hangs under 2 and 3
+	err := ValidateTree(root)
+	require.ErrorIs(t, err, ErrSharedNode)
+	require.NotErrorIs(t, err, ErrCycle)
+	require.Contains(t, err.Error(), `"LL"`)
+	require.Contains(t, err.Error(), `"RR"`)
+
+	twin := &Node{Val: 5}
+	require.ErrorIs(t, ValidateTree(&Node{Val: 1, Left: twin, Right: twin}), ErrSharedNode)
+}
+
+// 4. The depth limit is configurable and can be switched off.
+func TestValidateTreeDepth(t *testing.T) {
+	chain := GenerateRandomTree(10, WithShape(ShapeRightSkewed))
+	require.NoError(t, ValidateTree(chain, WithDepthLimit(9)))
+	require.ErrorIs(t, ValidateTree(chain, WithDepthLimit(8)), ErrTooDeep)
+	require.NoError(t, ValidateTree(chain, WithDepthLimit(-1)))
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Point and range queries return overlapping intervals in order.
+func TestIntervalTreeQueries(t *testing.T) {
+	tree := NewIntervalTree(
+		Interval{15, 20}, Interval{10, 30}, Interval{17, 19},
+		Interval{5, 20}, Interval{12, 15}, Interval{30, 40},
+	)
+	require.Equal(t, 6, tree.Len())
+	require.Equal(t, []Interval{{5, 20}, {10, 30}, {12, 15}}, tree.QueryPoint(14))
+	require.Equal(t, []Interval{{10, 30}, {30, 40}}, tree.QueryPoint(30))
+	require.Equal(t, []Interval{{5, 20}, {10, 30}, {15, 20}, {17, 19}, {30, 40}}, tree.QueryOverlapping(Interval{19, 35}))
+	require.Equal(t, []Interval{{10, 30}, {30, 40}}, tree.QueryOverlapping(Interval{21, 35}))
+	require.Empty(t, tree.QueryOverlapping(Interval{41, 50}))
+}
+
+// 2. Insert and Delete keep set semantics.
+func TestIntervalTreeInsertDelete(t *testing.T) {
+	tree := NewIntervalTree(Interval{1, 5})
+	require.False(t, tree.Insert(Interval{1, 5}))
+	require.True(t, tree.Insert(Interval{1, 6}))
+	require.True(t, tree.Delete(Interval{1, 5}))
+	require.False(t, tree.Delete(Interval{1, 5}))
+	require.Equal(t, []Interval{{1, 6}}, tree.All())
+	require.Panics(t, func() { tree.Insert(Interval{3, 2}) })
+}
+
+// 3. Random bookings agree with a brute-force scan.
+func TestIntervalTreeRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	tree := NewIntervalTree()
+	present := map[Interval]bool{}
+	for i := 0; i < 1500; i++ {
+		lo := rng.Intn(200)
+		iv := Interval{lo, lo + rng.Intn(20)}
+		if rng.Intn(4) == 0 {
+			require.Equal(t, present[iv], tree.Delete(iv))
+			delete(present, iv)
+		} else {
+			require.Equal(t, !present[iv], tree.Insert(iv))
+			present[iv] = true
+		}
+
+		q := Interval{rng.Intn(220), 0}
+		q.Hi = q.Lo + rng.Intn(10)
+		want := 0
+		for iv := range present {
+			if iv.Overlaps(q) {
+				want++
+			}
+		}
+		require.Len(t, tree.QueryOverlapping(q), want)
+	}
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Marshalling uses val/left/right keys with null for missing children.
+func TestNodeMarshalJSON(t *testing.T) {
+	root := &Node{Val: 10}
+	root.Left = &Node{Val: 5}
+
+	got, err := json.Marshal(root)
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`{"val":10,"left":{"val":5,"left":null,"right":null},"right":null}`,
+		string(got))
+}
+
+// 2. Trees round-trip through JSON unchanged.
+func TestNodeJSONRoundTrip(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4, nil, 5, 6))
+
+	data, err := json.Marshal(root)
+	require.NoError(t, err)
+
+	var back *Node
+	require.NoError(t, json.Unmarshal(data, &back))
+	require.Equal(t, root, back)
+}
+
+// 3. Omitted child keys decode as nil and null decodes to a nil tree.
+func TestNodeUnmarshalJSONSparse(t *testing.T) {
+	var root *Node
+	require.NoError(t, json.Unmarshal([]byte(`{"val":7,"right":{"val":9}}`), &root))
+	require.Equal(t, 7, root.Val)
+	require.Nil(t, root.Left)
+	require.Equal(t, 9, root.Right.Val)
+
+	var empty *Node
+	require.NoError(t, json.Unmarshal([]byte(`null`), &empty))
+	require.Nil(t, empty)
+}
+
+// 4. Malformed input surfaces the decoder error.
+func TestNodeUnmarshalJSONInvalid(t *testing.T) {
+	var root *Node
+	require.Error(t, json.Unmarshal([]byte(`{"val":"ten"}`), &root))
+}
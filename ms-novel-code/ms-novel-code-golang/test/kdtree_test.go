@@ -0,0 +1,140 @@
+package core
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// randomPoints returns n points in [0, 100)^dim.
+func randomPoints(r *rand.Rand, n, dim int) [][]float64 {
+	pts := make([][]float64, n)
+	for i := range pts {
+		pts[i] = make([]float64, dim)
+		for j := range pts[i] {
+			pts[i][j] = r.Float64() * 100
+		}
+	}
+	return pts
+}
+
+// 1. NearestNeighbor agrees with a linear scan.
+func TestKDTreeNearestNeighbor(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	pts := randomPoints(r, 500, 3)
+	kd, err := BuildKDTree(3, pts)
+	require.NoError(t, err)
+	require.Equal(t, 500, kd.Len())
+
+	for range 200 {
+		q := randomPoints(r, 1, 3)[0]
+		best := math.Inf(1)
+		for _, p := range pts {
+			best = min(best, math.Sqrt(sqDist(p, q)))
+		}
+		p, d, ok := kd.NearestNeighbor(q)
+		require.True(t, ok)
+		require.InDelta(t, best, d, 1e-9)
+		require.InDelta(t, d, math.Sqrt(sqDist(p, q)), 1e-9)
+	}
+
+	_, _, ok := NewKDTree(2).NearestNeighbor([]float64{0, 0})
+	require.False(t, ok)
+}
+
+// 2. RangeSearch returns exactly the points in the box.
+func TestKDTreeRangeSearch(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	kd := NewKDTree(2)
+	pts := randomPoints(r, 400, 2)
+	for _, p := range pts {
+		require.NoError(t, kd.Insert(p))
+	}
+	lo, hi := []float64{20, 30}, []float64{60, 45}
+	var want [][]float64
+	for _, p := range pts {
+		if p[0] >= lo[0] && p[0] <= hi[0] && p[1] >= lo[1] && p[1] <= hi[1] {
+			want = append(want, p)
+		}
+	}
+	require.ElementsMatch(t, want, kd.RangeSearch(lo, hi))
+	require.Equal(t, [][]float64{}, kd.RangeSearch([]float64{200, 200}, []float64{300, 300}))
+}
+
+// 3. Points on a splitting plane and duplicates are found.
+func TestKDTreeTies(t *testing.T) {
+	pts := [][]float64{{1, 1}, {1, 2}, {1, 3}, {1, 1}, {2, 1}}
+	kd, err := BuildKDTree(2, pts)
+	require.NoError(t, err)
+	require.Len(t, kd.RangeSearch([]float64{1, 1}, []float64{1, 1}), 2)
+	require.Len(t, kd.RangeSearch([]float64{1, 0}, []float64{1, 9}), 4)
+
+	require.NoError(t, kd.Insert([]float64{1, 2}))
+	require.Len(t, kd.RangeSearch([]float64{1, 2}, []float64{1, 2}), 2)
+	p, d, _ := kd.NearestNeighbor([]float64{1.9, 1})
+	require.Equal(t, []float64{2, 1}, p)
+	require.InDelta(t, 0.1, d, 1e-12)
+}
+
+// 4. Dimension mismatches are reported.
+func TestKDTreeDimensionMismatch(t *testing.T) {
+	_, err := BuildKDTree(2, [][]float64{{1, 2}, {3}})
+	require.ErrorIs(t, err, ErrDimensionMismatch)
+	kd := NewKDTree(2)
+	require.ErrorIs(t, kd.Insert([]float64{1, 2, 3}), ErrDimensionMismatch)
+	require.Panics(t, func() { kd.NearestNeighbor([]float64{1}) })
+	require.Panics(t, func() { NewKDTree(0) })
+}
+
+// kdHeight returns the number of levels of a k-d tree.
+func kdHeight(n *kdNode) int {
+	h := 0
+	level := []*kdNode{n}
+	for len(level) > 0 && level[0] != nil {
+		h++
+		var next []*kdNode
+		for _, n := range level {
+			for _, c := range []*kdNode{n.left, n.right} {
+				if c != nil {
+					next = append(next, c)
+				}
+			}
+		}
+		level = next
+	}
+	return h
+}
+
+// 5. Duplicate-heavy input still builds a balanced tree and is searchable.
+func TestKDTreeDuplicatesBalanced(t *testing.T) {
+	same := make([][]float64, 40_000)
+	for i := range same {
+		same[i] = []float64{3, 4}
+	}
+	kd, err := BuildKDTree(2, same)
+	require.NoError(t, err)
+	require.Equal(t, 16, kdHeight(kd.root)) // ceil(log2(40001))
+	require.Len(t, kd.RangeSearch([]float64{3, 4}, []float64{3, 4}), 40_000)
+
+	// A few distinct coordinates, as with snapped geo points.
+	r := rand.New(rand.NewPCG(5, 6))
+	grid := make([][]float64, 10_000)
+	for i := range grid {
+		grid[i] = []float64{float64(r.IntN(4)), float64(r.IntN(3))}
+	}
+	kd, err = BuildKDTree(2, grid)
+	require.NoError(t, err)
+	require.LessOrEqual(t, kdHeight(kd.root), 14)
+	want := 0
+	for _, p := range grid {
+		if p[0] == 2 && p[1] >= 1 {
+			want++
+		}
+	}
+	require.Len(t, kd.RangeSearch([]float64{2, 1}, []float64{2, 2}), want)
+	p, d, _ := kd.NearestNeighbor([]float64{2.2, 0.9})
+	require.Equal(t, []float64{2, 1}, p)
+	require.InDelta(t, math.Hypot(0.2, 0.1), d, 1e-12)
+}
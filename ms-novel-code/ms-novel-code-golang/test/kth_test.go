@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. KthSmallest and KthLargest walk the BST in (reverse) inorder.
+func TestKthSmallestLargest(t *testing.T) {
+	bst := NewBST(50, 30, 70, 20, 40, 60, 80)
+	sorted := bst.InOrder()
+	for k := 1; k <= len(sorted); k++ {
+		v, err := KthSmallest(bst.Root, k)
+		require.NoError(t, err)
+		require.Equal(t, sorted[k-1], v)
+
+		v, err = KthLargest(bst.Root, k)
+		require.NoError(t, err)
+		require.Equal(t, sorted[len(sorted)-k], v)
+	}
+}
+
+// 2. Out-of-range k is an error, including on empty trees.
+func TestKthOutOfRange(t *testing.T) {
+	bst := NewBST(2, 1, 3)
+	for _, k := range []int{0, -1, 4} {
+		_, err := KthSmallest(bst.Root, k)
+		require.ErrorIs(t, err, ErrKOutOfRange)
+		_, err = KthLargest(bst.Root, k)
+		require.ErrorIs(t, err, ErrKOutOfRange)
+		_, err = NewOrderStatTree(bst.Root).KthSmallest(k)
+		require.ErrorIs(t, err, ErrKOutOfRange)
+		_, err = NewTreap(1, 2, 3).KthLargest(k)
+		require.ErrorIs(t, err, ErrKOutOfRange)
+	}
+	_, err := KthSmallest[int](nil, 1)
+	require.ErrorIs(t, err, ErrKOutOfRange)
+	require.Equal(t, 0, NewOrderStatTree[int](nil).Len())
+}
+
+// 3. The size-augmented variants agree with the plain walk.
+func TestKthAugmented(t *testing.T) {
+	vals := Preorder(GenerateRandomTree(300, WithSeed(11), WithValueRange(0, 10000)))
+	bst := NewBST(vals...)
+	bst.Rebalance()
+	ost := NewOrderStatTree(bst.Root)
+	treap := NewTreap(vals...)
+	require.Equal(t, bst.Len(), ost.Len())
+
+	for k := 1; k <= bst.Len(); k++ {
+		want, _ := KthSmallest(bst.Root, k)
+		got, err := ost.KthSmallest(k)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+		got, _ = treap.KthSmallest(k)
+		require.Equal(t, want, got)
+
+		want, _ = KthLargest(bst.Root, k)
+		got, _ = ost.KthLargest(k)
+		require.Equal(t, want, got)
+		got, _ = treap.KthLargest(k)
+		require.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. LCA handles ancestor-of-itself and split cases in a general tree.
+func TestLCAGeneralTree(t *testing.T) {
+	//        3
+	//      /   \
+	//     5     1
+	//    / \   / \
+	//   6   2 0   8
+	//      / \
+	//     7   4
+	root := BuildFromLevelOrder(levelOrderInts(3, 5, 1, 6, 2, 0, 8, nil, nil, 7, 4))
+	n5, n1, n4, n6 := root.Left, root.Right, root.Left.Right.Right, root.Left.Left
+
+	require.Same(t, root, LCA(root, n5, n1))
+	require.Same(t, n5, LCA(root, n5, n4))
+	require.Same(t, n5, LCA(root, n6, n4))
+	require.Same(t, n4, LCA(root, n4, n4))
+}
+
+// 2. Nodes outside the tree give nil.
+func TestLCAMissingNode(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(3, 5, 1))
+	stranger := &Node{Val: 5}
+
+	require.Nil(t, LCA(root, root.Left, stranger))
+	require.Nil(t, LCA(nil, root, root))
+	require.Nil(t, LCABST(root, root.Left, nil))
+}
+
+// 3. LCABST follows the ordering and checks membership.
+func TestLCABST(t *testing.T) {
+	tree := NewBST(6, 2, 8, 0, 4, 7, 9, 3, 5)
+	n2, n8, n4, n3, n5 := tree.Node(2), tree.Node(8), tree.Node(4), tree.Node(3), tree.Node(5)
+
+	require.Same(t, tree.Root, LCABST(tree.Root, n2, n8))
+	require.Same(t, n2, LCABST(tree.Root, n2, n4))
+	require.Same(t, n4, LCABST(tree.Root, n3, n5))
+	require.Same(t, LCA(tree.Root, n3, n8), LCABST(tree.Root, n3, n8))
+	require.Nil(t, LCABST(tree.Root, n3, &Node{Val: 5}))
+}
+
+// 4. BatchLCA matches LCA for every pair, and gives nil for strangers.
+func TestBatchLCA(t *testing.T) {
+	root := GenerateRandomTree(60, WithSeed(11))
+	nodes := postorderNodes(root)
+	var queries [][2]*Node
+	for _, a := range nodes {
+		for _, b := range nodes {
+			queries = append(queries, [2]*Node{a, b})
+		}
+	}
+	stranger := &Node{Val: 1}
+	queries = append(queries, [2]*Node{root, stranger}, [2]*Node{nil, root})
+
+	got := BatchLCA(root, queries)
+	require.Len(t, got, len(queries))
+	for i, q := range queries[:len(queries)-2] {
+		require.Same(t, LCA(root, q[0], q[1]), got[i])
+	}
+	require.Nil(t, got[len(got)-2])
+	require.Nil(t, got[len(got)-1])
+	require.Equal(t, []*Node{nil}, BatchLCA(nil, [][2]*Node{{root, root}}))
+	require.Empty(t, BatchLCA(root, nil))
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. LCA and Distance on a hand-checked tree.
+func TestLCAIndexSmall(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(3, 5, 1, 6, 2, 0, 8, nil, nil, 7, 4))
+	n5, n1, n4, n6, n8 := root.Left, root.Right, root.Left.Right.Right, root.Left.Left, root.Right.Right
+	x := PreprocessLCA(root)
+
+	require.Equal(t, 9, x.Len())
+	require.Same(t, root, x.LCA(n5, n1))
+	require.Same(t, n5, x.LCA(n4, n6))
+	require.Same(t, n4, x.LCA(n4, n4))
+
+	d, ok := x.Distance(n4, n8)
+	require.True(t, ok)
+	require.Equal(t, 5, d)
+	d, ok = x.Depth(n4)
+	require.True(t, ok)
+	require.Equal(t, 3, d)
+}
+
+// 2. The index agrees with ParentMap on every pair of a random tree.
+func TestLCAIndexMatchesParentMap(t *testing.T) {
+	for _, shape := range []Shape{ShapeRandom, ShapeLeftSkewed, ShapeBalanced} {
+		root := GenerateRandomTree(80, WithShape(shape), WithSeed(5))
+		x, parents := PreprocessLCA(root), AttachParents(root)
+		for _, a := range postorderNodes(root) {
+			for _, b := range postorderNodes(root) {
+				require.Same(t, parents.LCA(a, b), x.LCA(a, b))
+				want, _ := parents.Distance(a, b)
+				got, ok := x.Distance(a, b)
+				require.True(t, ok)
+				require.Equal(t, want, got)
+			}
+		}
+	}
+}
+
+// 3. Foreign nodes and empty trees are reported, not guessed.
+func TestLCAIndexMissing(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3))
+	x := PreprocessLCA(root)
+	stranger := &Node{Val: 2}
+
+	require.Nil(t, x.LCA(root, stranger))
+	_, ok := x.Distance(stranger, root)
+	require.False(t, ok)
+	_, ok = x.Depth(nil)
+	require.False(t, ok)
+
+	empty := PreprocessLCA[int](nil)
+	require.Zero(t, empty.Len())
+	require.Nil(t, empty.LCA(root, root))
+
+	single := PreprocessLCA(stranger)
+	require.Same(t, stranger, single.LCA(stranger, stranger))
+}
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. An empty tree yields no levels.
+func TestLevelsEmptyTree(t *testing.T) {
+	for range Levels[int](nil) {
+		t.Fatal("expected no levels")
+	}
+}
+
+// 2. Every level is yielded with its depth, top-to-bottom.
+func TestLevelsAllLevels(t *testing.T) {
+	root := &Node{Val: 1}
+	root.Left = &Node{Val: 2}
+	root.Right = &Node{Val: 3}
+	root.Left.Left = &Node{Val: 8}
+	root.Right.Right = &Node{Val: 5}
+
+	var depths []int
+	var got [][]int
+	for depth, vals := range Levels(root) {
+		depths = append(depths, depth)
+		got = append(got, vals)
+	}
+	require.Equal(t, []int{0, 1, 2}, depths)
+	require.Equal(t, [][]int{{1}, {2, 3}, {8, 5}}, got)
+}
+
+// 3. Breaking early stops the traversal.
+func TestLevelsBreakEarly(t *testing.T) {
+	root := &Node{Val: 1}
+	root.Left = &Node{Val: 2}
+	root.Left.Left = &Node{Val: 3}
+	root.Left.Left.Left = &Node{Val: 4}
+
+	seen := 0
+	for depth := range Levels(root) {
+		seen++
+		if depth == 1 {
+			break
+		}
+	}
+	require.Equal(t, 2, seen)
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. KthAncestor climbs exactly k levels and stops at the root.
+func TestAncestorTableKth(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(3, 5, 1, 6, 2, 0, 8, nil, nil, 7, 4))
+	n5, n2, n4 := root.Left, root.Left.Right, root.Left.Right.Right
+	a := PreprocessAncestors(root)
+
+	require.Equal(t, 9, a.Len())
+	require.Same(t, n4, a.KthAncestor(n4, 0))
+	require.Same(t, n2, a.KthAncestor(n4, 1))
+	require.Same(t, n5, a.KthAncestor(n4, 2))
+	require.Same(t, root, a.KthAncestor(n4, 3))
+	require.Nil(t, a.KthAncestor(n4, 4))
+	require.Nil(t, a.KthAncestor(n4, -1))
+	d, ok := a.Depth(n4)
+	require.True(t, ok)
+	require.Equal(t, 3, d)
+}
+
+// 2. A deep chain exercises every power of two.
+func TestAncestorTableChain(t *testing.T) {
+	root := GenerateRandomTree(1000, WithShape(ShapeLeftSkewed), WithSeed(1))
+	chain := postorderNodes(root) // deepest first
+	a := PreprocessAncestors(root)
+	for _, k := range []int{0, 1, 2, 3, 255, 256, 511, 512, 998, 999} {
+		require.Same(t, chain[k], a.KthAncestor(chain[0], k), "k=%d", k)
+	}
+	require.Nil(t, a.KthAncestor(chain[0], 1000))
+	require.Same(t, chain[500], a.LCA(chain[10], chain[500]))
+}
+
+// 3. LCA agrees with ParentMap on every pair, and rejects strangers.
+func TestAncestorTableLCA(t *testing.T) {
+	for _, shape := range []Shape{ShapeRandom, ShapeRightSkewed, ShapeBalanced} {
+		root := GenerateRandomTree(70, WithShape(shape), WithSeed(2))
+		a, parents := PreprocessAncestors(root), AttachParents(root)
+		for _, x := range postorderNodes(root) {
+			for _, y := range postorderNodes(root) {
+				require.Same(t, parents.LCA(x, y), a.LCA(x, y))
+			}
+		}
+	}
+
+	root := BuildFromLevelOrder(levelOrderInts(1, 2))
+	a := PreprocessAncestors(root)
+	require.Nil(t, a.LCA(root, &Node{Val: 2}))
+	require.Nil(t, a.KthAncestor(nil, 0))
+	require.Zero(t, PreprocessAncestors[int](nil).Len())
+	require.Same(t, root, PreprocessAncestors(root).LCA(root, root))
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. WithMaxDepth stops before the first level that is too deep.
+func TestWithMaxDepth(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 3, 2, 5, 3, nil, 9))
+	sum := func(a, b int) int { return a + b }
+
+	got, err := RowWiseReduceE(root, sum, WithMaxDepth(1))
+	require.Equal(t, []int{1, 5}, got)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+	var lerr *LimitError
+	require.True(t, errors.As(err, &lerr))
+	require.Equal(t, LimitDepth, lerr.Kind)
+	require.Equal(t, 2, lerr.Depth)
+	require.Equal(t, 3, lerr.Nodes)
+
+	got, err = RowWiseReduceE(root, sum, WithMaxDepth(2))
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 5, 17}, got)
+	require.Equal(t, []int{1}, rowWiseMax(root, WithMaxDepth(0))["output"])
+}
+
+// 2. WithMaxNodes counts whole levels; Morris falls back to BFS.
+func TestWithMaxNodes(t *testing.T) {
+	root := completeTree(15) // levels of 1, 2, 4, 8
+	got, err := RowWiseReduceE(root, func(a, b int) int { return max(a, b) },
+		WithMaxNodes(10), WithStrategy(StrategyMorris))
+	require.Len(t, got, 3)
+	var lerr *LimitError
+	require.True(t, errors.As(err, &lerr))
+	require.Equal(t, LimitNodes, lerr.Kind)
+	require.Equal(t, "nodes", lerr.Kind.String())
+	require.Equal(t, 7, lerr.Nodes)
+
+	_, err = RowWiseReduceE(root, func(a, b int) int { return a }, WithMaxNodes(15))
+	require.NoError(t, err)
+	require.Equal(t, rowWiseMax(root)["output"], rowWiseMax(root, WithMaxNodes(1<<20))["output"])
+}
+
+// 3. WithTimeout stops a long walk; the error says how far it got.
+func TestWithTimeout(t *testing.T) {
+	root := leftSkewedTree(2000)
+	first := func(a, b int) int { return a }
+	got, err := RowWiseReduceE(root, first, WithTimeout(time.Nanosecond))
+	require.ErrorIs(t, err, ErrLimitExceeded)
+	var lerr *LimitError
+	require.True(t, errors.As(err, &lerr))
+	require.Equal(t, LimitTimeout, lerr.Kind)
+	require.Less(t, len(got), 2000)
+	require.Contains(t, err.Error(), "timeout limit exceeded")
+
+	got, err = RowWiseReduceE(root, first, WithTimeout(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, got, 2000)
+}
+
+// 4. The E variants distinguish empty, corrupted and limited walks.
+func TestRowWiseMaxE(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 3, 2, 5, 3, nil, 9))
+	got, err := RowWiseMaxE(root)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 3, 9}, got)
+	got, err = RowWiseMinE(root, WithStrategy(StrategyMorris))
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, got)
+
+	got, err = RowWiseMaxE[int](nil)
+	require.ErrorIs(t, err, ErrNilRoot)
+	require.Equal(t, []int{}, got)
+	require.Equal(t, []int{}, RowWiseMaxOf[int](nil)) // old behaviour kept
+
+	root.Right.Right.Left = root.Left // shared subtree
+	_, err = RowWiseMaxE(root, WithCycleCheck())
+	require.ErrorIs(t, err, ErrSharedNode)
+	require.Equal(t, []int{}, rowWiseMax(root, WithCycleCheck())["output"])
+
+	_, err = RowWiseMaxE(root, WithMaxDepth(1))
+	require.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+// 5. A cyclic tree fails fast instead of hanging the walk.
+func TestRowWiseReduceECycle(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3))
+	root.Left.Left = root
+	_, err := RowWiseReduceE(root, func(a, b int) int { return a + b }, WithCycleCheck())
+	require.ErrorIs(t, err, ErrCycle)
+
+	got, err := RowWiseMaxE(root, WithMaxNodes(100))
+	require.ErrorIs(t, err, ErrLimitExceeded) // bounded even without the check
+	require.NotEmpty(t, got)
+}
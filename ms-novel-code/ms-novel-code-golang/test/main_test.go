@@ -118,4 +118,120 @@ func TestRowWiseMaxLargeTree(t *testing.T) {
 
 	got := rowWiseMax(root)
 	require.Equal(t, want, got["output"])
-}
\ No newline at end of file
+}
+
+// 11. rowWiseMin on an empty tree should yield an empty slice.
+func TestRowWiseMinEmptyTree(t *testing.T) {
+	got := rowWiseMin(nil)
+	require.NotNil(t, got["output"])
+	require.Empty(t, got["output"])
+}
+
+// 12. rowWiseMin picks the smallest value on each level.
+func TestRowWiseMinMixedTree(t *testing.T) {
+	root := &Node{Val: 100}
+	root.Left = &Node{Val: 200}
+	root.Right = &Node{Val: -50}
+	root.Left.Left = &Node{Val: 70}
+	root.Left.Right = &Node{Val: 90}
+	root.Right.Left = &Node{Val: 0}
+	root.Right.Right = &Node{Val: 300}
+	want := []int{100, -50, 0}
+
+	got := rowWiseMin(root)
+	require.Equal(t, want, got["output"])
+}
+
+// 13. rowWiseMin follows a skewed tree level by level.
+func TestRowWiseMinRightSkewed(t *testing.T) {
+	root := &Node{Val: 3}
+	root.Right = &Node{Val: 1}
+	root.Right.Right = &Node{Val: 0}
+	want := []int{3, 1, 0}
+
+	got := rowWiseMin(root)
+	require.Equal(t, want, got["output"])
+}
+
+// 14. RowWiseReduce on an empty tree returns a non-nil empty slice.
+func TestRowWiseReduceEmptyTree(t *testing.T) {
+	got := RowWiseReduce(nil, func(a, b int) int { return a + b })
+	require.NotNil(t, got)
+	require.Empty(t, got)
+}
+
+// 15. RowWiseReduce with addition yields per-level sums.
+func TestRowWiseReduceSum(t *testing.T) {
+	root := &Node{Val: 1}
+	root.Left = &Node{Val: 2}
+	root.Right = &Node{Val: 3}
+	root.Left.Left = &Node{Val: 8}
+	root.Left.Right = &Node{Val: 4}
+	root.Right.Right = &Node{Val: 5}
+	want := []int{1, 5, 17}
+
+	got := RowWiseReduce(root, func(a, b int) int { return a + b })
+	require.Equal(t, want, got)
+}
+
+// 16. RowWiseReduce with bitwise-or combines every value on a level.
+func TestRowWiseReduceBitwiseOr(t *testing.T) {
+	root := &Node{Val: 8}
+	root.Left = &Node{Val: 1}
+	root.Right = &Node{Val: 4}
+	want := []int{8, 5}
+
+	got := RowWiseReduce(root, func(a, b int) int { return a | b })
+	require.Equal(t, want, got)
+}
+
+// 17. RowWiseMaxNodes returns the nodes themselves, left-most on ties.
+func TestRowWiseMaxNodes(t *testing.T) {
+	root := &Node{Val: 10}
+	root.Left = &Node{Val: 12}
+	root.Right = &Node{Val: 12}
+	root.Left.Left = &Node{Val: 20}
+	root.Right.Right = &Node{Val: 25}
+
+	got := RowWiseMaxNodes(root)
+	require.Equal(t, []*Node{root, root.Left, root.Right.Right}, got)
+
+	// The nodes are live: annotating one is visible through the tree.
+	got[2].Val = 0
+	require.Equal(t, []int{10, 12, 20}, rowWiseMax(root)["output"])
+}
+
+// 18. RowWiseMaxNodes on an empty tree returns a non-nil empty slice.
+func TestRowWiseMaxNodesEmptyTree(t *testing.T) {
+	got := RowWiseMaxNodes[int](nil)
+	require.NotNil(t, got)
+	require.Empty(t, got)
+}
+
+// 19. RowWiseMaxWithDepths pairs each maximum with its depth.
+func TestRowWiseMaxWithDepths(t *testing.T) {
+	root := &Node{Val: 1}
+	root.Left = &Node{Val: 2}
+	root.Right = &Node{Val: 3}
+	root.Left.Left = &Node{Val: 8}
+	root.Left.Right = &Node{Val: 4}
+	root.Right.Right = &Node{Val: 5}
+	want := RowWiseMaxResult{
+		Values:    []int{1, 3, 8},
+		Depths:    []int{0, 1, 2},
+		NodeCount: 6,
+	}
+
+	got := RowWiseMaxWithDepths(root)
+	require.Equal(t, want, got)
+	require.Equal(t, rowWiseMax(root)["output"], got.Values)
+}
+
+// 20. RowWiseMaxWithDepths on an empty tree has non-nil empty slices.
+func TestRowWiseMaxWithDepthsEmptyTree(t *testing.T) {
+	got := RowWiseMaxWithDepths(nil)
+	require.NotNil(t, got.Values)
+	require.NotNil(t, got.Depths)
+	require.Empty(t, got.Values)
+	require.Zero(t, got.NodeCount)
+}
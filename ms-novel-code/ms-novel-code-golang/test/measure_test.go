@@ -0,0 +1,101 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Empty and single-node trees.
+func TestMeasureSmallTrees(t *testing.T) {
+	require.Zero(t, Height[int](nil))
+	require.Zero(t, Size[int](nil))
+	require.Zero(t, CountLeaves[int](nil))
+	require.Zero(t, CountInternal[int](nil))
+
+	single := &Node{Val: 1}
+	require.Equal(t, 1, Height(single))
+	require.Equal(t, 1, Size(single))
+	require.Equal(t, 1, CountLeaves(single))
+	require.Zero(t, CountInternal(single))
+}
+
+// 2. Counts on a mixed tree add up.
+func TestMeasureMixedTree(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 8, 4, nil, 5, nil, nil, 6))
+	require.Equal(t, 4, Height(root))
+	require.Equal(t, 7, Size(root))
+	require.Equal(t, 3, CountLeaves(root))
+	require.Equal(t, 4, CountInternal(root))
+	require.Len(t, rowWiseMax(root)["output"], Height(root))
+}
+
+// 3. A million-node chain does not overflow the stack.
+func TestMeasureDeepChain(t *testing.T) {
+	root := leftSkewedTree(1_000_000)
+	require.Equal(t, 1_000_000, Height(root))
+	require.Equal(t, 1_000_000, Size(root))
+	require.Equal(t, 1, CountLeaves(root))
+	require.Equal(t, 999_999, CountInternal(root))
+}
+
+// 4. Diameter finds the longest path even when it skips the root.
+func TestDiameter(t *testing.T) {
+	require.Equal(t, 0, Diameter[int](nil))
+	require.Equal(t, 0, Diameter(&Node{Val: 1}))
+	require.Equal(t, 3, Diameter(BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5))))
+
+	// The longest path runs through the root's left child only.
+	//      1
+	//     /
+	//    2
+	//   / \
+	//  3   4
+	//  /    \
+	// 5      6
+	off := BuildFromLevelOrder(levelOrderInts(1, 2, nil, 3, 4, 5, nil, nil, 6))
+	require.Equal(t, 4, Diameter(off))
+	require.Equal(t, 999, Diameter(leftSkewedTree(1000)))
+}
+
+// 5. MaxWidth counts nodes, or positions with null gaps.
+func TestMaxWidth(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 3, 2, 5, nil, nil, 9, 6, nil, nil, 7))
+	require.Equal(t, 2, MaxWidth(root))
+	require.Equal(t, 8, MaxWidth(root, WithWidthNullGaps()))
+	require.Equal(t, 0, MaxWidth[int](nil, WithWidthNullGaps()))
+	require.Equal(t, 8, MaxWidth(completeTree(15), WithWidthNullGaps()))
+
+	// Two 100-deep chains at the far left and far right saturate.
+	left, right := leftSkewedTree(100), &Node{Val: 0}
+	for n, i := right, 0; i < 99; i++ {
+		n.Right = &Node{Val: i}
+		n = n.Right
+	}
+	wide := &Node{Left: left, Right: right}
+	require.Equal(t, 2, MaxWidth(wide))
+	require.Equal(t, math.MaxInt, MaxWidth(wide, WithWidthNullGaps()))
+}
+
+// 6. CountCompleteTreeNodes matches Size on complete trees.
+func TestCountCompleteTreeNodes(t *testing.T) {
+	for n := range 200 {
+		require.Equal(t, n, CountCompleteTreeNodes(completeTree(n)), "n=%d", n)
+	}
+	big := NewImplicitTree(make([]int, 3_000_001)).ToNode()
+	require.Equal(t, 3_000_001, CountCompleteTreeNodes(big))
+}
+
+// 7. CountCompleteTreeNodes does not panic on trees that are not complete.
+func TestCountCompleteTreeNodesNotComplete(t *testing.T) {
+	lone := BuildFromLevelOrder(levelOrderInts(1, nil, 2))
+	require.NotPanics(t, func() { CountCompleteTreeNodes(lone) })
+	require.Equal(t, 2, CountCompleteTreeNodes(lone))
+
+	deeper := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4))
+	require.NotPanics(t, func() { CountCompleteTreeNodes(deeper) })
+	for range 100 {
+		require.NotPanics(t, func() { CountCompleteTreeNodes(GenerateRandomTree(50)) })
+	}
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"crypto/sha1"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Hashes depend on shape and values, not on node identity.
+func TestHashTreeStructural(t *testing.T) {
+	a := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4))
+	b := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4))
+	require.Equal(t, HashTree(a, nil), HashTree(b, nil))
+
+	mirrored := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4))
+	changed := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 5))
+	require.NotEqual(t, HashTree(a, nil), HashTree(mirrored, nil))
+	require.NotEqual(t, HashTree(a, nil), HashTree(changed, nil))
+	require.NotEqual(t, HashTree(a, nil), HashTree(nil, nil))
+
+	require.Len(t, HashTree(a, sha1.New()), sha1.Size)
+}
+
+// 2. Every subtree gets its own hash, and equal subtrees hash equal.
+func TestSubtreeHashes(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 2, 3, nil, nil, 3))
+	hashes := SubtreeHashes(root, nil)
+	require.Len(t, hashes, 5)
+	require.Equal(t, hashes[root.Left.Left], hashes[root.Right.Right])
+	require.NotEqual(t, hashes[root.Left], hashes[root.Right])
+	require.Equal(t, HashTree(root, nil), hashes[root])
+}
+
+// 3. DiffByHash reports only the smallest changed subtrees.
+func TestDiffByHash(t *testing.T) {
+	a := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, 6, 7))
+	b := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 9, 6, nil, nil, nil, 8))
+
+	diffs := DiffByHash(a, b, nil)
+	require.Len(t, diffs, 2)
+	require.Equal(t, "LR", diffs[0].Path)
+	require.Equal(t, 5, diffs[0].A.Val)
+	require.Equal(t, 9, diffs[0].B.Val)
+	require.Equal(t, "RR", diffs[1].Path)
+	require.Equal(t, 7, diffs[1].A.Val)
+	require.Nil(t, diffs[1].B)
+
+	require.Equal(t, []HashDiff{}, DiffByHash(a, a, nil))
+	require.Equal(t, []HashDiff{{Path: "", A: a}}, DiffByHash(a, nil, nil))
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Empty tree produces a bare flowchart header.
+func TestExportMermaidEmptyTree(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, ExportMermaid(nil, &sb))
+	require.Equal(t, "flowchart TD\n", sb.String())
+}
+
+// 2. Nodes and labelled edges are emitted in level order.
+func TestExportMermaidDefault(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(5, 2, 7, nil, 3))
+	var sb strings.Builder
+	require.NoError(t, ExportMermaid(root, &sb))
+	want := "flowchart TD\n" +
+		"    n0((\"5\"))\n" +
+		"    n1((\"2\"))\n" +
+		"    n2((\"7\"))\n" +
+		"    n3((\"3\"))\n" +
+		"    n0 -->|L| n1\n" +
+		"    n0 -->|R| n2\n" +
+		"    n1 -->|R| n3\n"
+	require.Equal(t, want, sb.String())
+}
+
+// 3. Options set labels, direction and level-max highlighting.
+func TestExportMermaidOptions(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 3, 2, 5, 3, nil, 9))
+	var sb strings.Builder
+	require.NoError(t, ExportMermaid(root, &sb,
+		WithMermaidDirection("LR"),
+		WithMermaidHighlightMax(),
+		WithMermaidLabel(func(n *Node) string { return `v"` + strconv.Itoa(n.Val) }),
+	))
+	out := sb.String()
+	require.True(t, strings.HasPrefix(out, "flowchart LR\n"))
+	require.Contains(t, out, `n0(("v#quot;1"))`)
+	require.True(t, strings.HasSuffix(out, "    class n0,n1,n5 levelMax\n"))
+}
+
+// 4. Write errors are reported.
+func TestExportMermaidWriteError(t *testing.T) {
+	require.Error(t, ExportMermaid(&Node{Val: 1}, failingWriter{}))
+}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Morris gives the same row-wise max as the queue version.
+func TestRowWiseMaxMorrisMatchesBFS(t *testing.T) {
+	trees := []*Node{
+		nil,
+		{Val: 42},
+		BuildFromLevelOrder(levelOrderInts(1, 2, 3, 8, 4, nil, 5)),
+		BuildFromLevelOrder(levelOrderInts(3, 4, nil, 10)),
+		BuildFromLevelOrder(levelOrderInts(3, nil, 1, nil, 0)),
+		BuildFromLevelOrder(levelOrderInts(100, 200, -50, 70, 90, 0, 300, nil, 6, 7)),
+	}
+	for _, root := range trees {
+		want := rowWiseMax(root)["output"]
+		got := rowWiseMax(root, WithStrategy(StrategyMorris))["output"]
+		require.Equal(t, want, got)
+	}
+}
+
+// 2. Non-commutative folds see each level left-to-right, as with BFS.
+func TestRowWiseReduceMorrisPreservesOrder(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, 6, 7))
+	concat := func(a, b int) int { return a*10 + b }
+
+	require.Equal(t, []int{1, 23, 4567}, RowWiseReduce(root, concat, WithStrategy(StrategyMorris)))
+}
+
+// 3. The threads are removed again, leaving the tree untouched.
+func TestRowWiseMaxMorrisRestoresTree(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 8, 4, nil, 5, 9))
+	before := ToLevelOrder(root)
+
+	rowWiseMax(root, WithStrategy(StrategyMorris))
+	require.Equal(t, before, ToLevelOrder(root))
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. The encoding is a flat array of flags and values in preorder.
+func TestTreeMarshalMsgpackLayout(t *testing.T) {
+	data, err := Tree{}.MarshalMsgpack()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x90}, data)
+
+	data, err = Tree{Root: BuildFromLevelOrder(levelOrderInts(1, nil, -1))}.MarshalMsgpack()
+	require.NoError(t, err)
+	// array(4), {right, 1}, {leaf, -1} as fixints.
+	require.Equal(t, []byte{0x94, 2, 1, 0, 0xff}, data)
+
+	data, err = Tree{Root: &Node{Val: 300}}.MarshalMsgpack()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x92, 0, 0xd1, 0x01, 0x2c}, data)
+}
+
+// 2. Trees round-trip, and deep trees are much smaller than as JSON.
+func TestTreeMsgpackRoundTrip(t *testing.T) {
+	for _, root := range []*Node{
+		nil,
+		BuildFromLevelOrder(levelOrderInts(-200, 70000, 3, nil, -5_000_000_000, nil, 1<<62)),
+		GenerateRandomTree(1000, WithSeed(8)),
+		GenerateRandomTree(100, WithShape(ShapeLeftSkewed)),
+	} {
+		data, err := Tree{Root: root}.MarshalMsgpack()
+		require.NoError(t, err)
+		var got Tree
+		require.NoError(t, got.UnmarshalMsgpack(data))
+		require.True(t, Equal(root, got.Root))
+	}
+
+	deep := Tree{Root: GenerateRandomTree(100, WithShape(ShapeLeftSkewed))}
+	packed, err := deep.MarshalMsgpack()
+	require.NoError(t, err)
+	js, err := json.Marshal(deep.Root)
+	require.NoError(t, err)
+	require.Less(t, 5*len(packed), len(js))
+}
+
+// 3. Other integer encodings are accepted and corrupt input is rejected.
+func TestTreeUnmarshalMsgpack(t *testing.T) {
+	var tree Tree
+	// array16(2), uint8 flags, int32 value.
+	require.NoError(t, tree.UnmarshalMsgpack([]byte{0xdc, 0, 2, 0xcc, 0, 0xd2, 0xff, 0xff, 0xff, 0xfe}))
+	require.Equal(t, &Node{Val: -2}, tree.Root)
+
+	for _, data := range [][]byte{
+		nil,
+		{0x80},                // map, not array
+		{0x93, 0, 1, 0},       // odd length
+		{0x92, 2, 1},          // announces a child that never comes
+		{0x94, 0, 1, 0, 2},    // second root
+		{0x92, 4, 1},          // unknown flag
+		{0x92, 0, 0xc0},       // nil value
+		{0x92, 0, 0xcd, 0x01}, // truncated uint16
+		{0x92, 0, 0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, // uint64 overflow
+		{0x92, 0, 1, 0}, // trailing byte
+	} {
+		tree := Tree{Root: &Node{Val: 9}}
+		require.ErrorIs(t, tree.UnmarshalMsgpack(data), ErrBinaryFormat, "%x", data)
+		require.Equal(t, 9, tree.Root.Val)
+	}
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// orgChart builds a small n-ary tree:
+//
+//	      1
+//	   /  |  \
+//	  3   2   4
+//	 / \      |
+//	5   6     9
+func orgChart() *NaryNode {
+	return &NaryNode{Val: 1, Children: []*NaryNode{
+		{Val: 3, Children: []*NaryNode{{Val: 5}, {Val: 6}}},
+		{Val: 2},
+		{Val: 4, Children: []*NaryNode{{Val: 9}}},
+	}}
+}
+
+// 1. Empty trees give non-nil empty results.
+func TestNaryEmptyTree(t *testing.T) {
+	require.NotNil(t, NaryLevelOrder[int](nil))
+	require.Empty(t, NaryLevelOrder[int](nil))
+	require.NotNil(t, NaryRowWiseMax[int](nil))
+}
+
+// 2. Level order and row-wise max over several children.
+func TestNaryLevelOrderAndMax(t *testing.T) {
+	root := orgChart()
+	require.Equal(t, [][]int{{1}, {3, 2, 4}, {5, 6, 9}}, NaryLevelOrder(root))
+	require.Equal(t, []int{1, 4, 9}, NaryRowWiseMax(root))
+	require.Equal(t, []int{1, 9, 20}, NaryRowWiseReduce(root, func(a, b int) int { return a + b }))
+}
+
+// 3. Nil children are skipped.
+func TestNaryNilChildren(t *testing.T) {
+	root := &NaryNode{Val: 1, Children: []*NaryNode{nil, {Val: 7}, nil}}
+	require.Equal(t, [][]int{{1}, {7}}, NaryLevelOrder(root))
+}
+
+// 4. Left-child right-sibling form links first children and siblings.
+func TestToLeftChildRightSibling(t *testing.T) {
+	bin := ToLeftChildRightSibling(orgChart())
+	require.Equal(t, 1, bin.Val)
+	require.Nil(t, bin.Right)
+	require.Equal(t, 3, bin.Left.Val)
+	require.Equal(t, 2, bin.Left.Right.Val)
+	require.Equal(t, 4, bin.Left.Right.Right.Val)
+	require.Equal(t, 5, bin.Left.Left.Val)
+	require.Equal(t, 6, bin.Left.Left.Right.Val)
+	require.Equal(t, 9, bin.Left.Right.Right.Left.Val)
+	require.Equal(t, 7, Size(bin))
+}
+
+// 5. Converting back restores the original n-ary tree.
+func TestLeftChildRightSiblingRoundTrip(t *testing.T) {
+	require.Nil(t, ToLeftChildRightSibling[int](nil))
+	require.Nil(t, FromLeftChildRightSibling[int](nil))
+	require.Equal(t, orgChart(), FromLeftChildRightSibling(ToLeftChildRightSibling(orgChart())))
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// orderedMapImpls are the OrderedMap backends the conformance suite
+// (and BenchmarkOrderedMapGet) runs against.
+var orderedMapImpls = []struct {
+	name string
+	new  func() OrderedMap[int, int]
+}{
+	{"btree-2", func() OrderedMap[int, int] { return NewBTree[int, int](2) }},
+	{"btree-16", func() OrderedMap[int, int] { return NewBTree[int, int](16) }},
+	{"skiplist", func() OrderedMap[int, int] { return NewSkipList[int, int]() }},
+	{"splay", func() OrderedMap[int, int] { return NewSplayTree[int, int]() }},
+	{"avl", func() OrderedMap[int, int] { return NewAVLMap[int]() }},
+	{"bst", func() OrderedMap[int, int] { return NewBSTMap[int]() }},
+}
+
+// collectRange gathers the keys of m.Range(lo, hi), checking that each
+// value is the one ref holds.
+func collectRange(t *testing.T, m OrderedMap[int, int], ref map[int]int, lo, hi int) []int {
+	keys := []int{}
+	for k, v := range m.Range(lo, hi) {
+		require.Equal(t, ref[k], v, "key %d", k)
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// 1. An empty map behaves the same on every backend.
+func TestOrderedMapEmpty(t *testing.T) {
+	for _, impl := range orderedMapImpls {
+		t.Run(impl.name, func(t *testing.T) {
+			m := impl.new()
+			require.Zero(t, m.Len())
+			_, ok := m.Get(1)
+			require.False(t, ok)
+			_, _, ok = m.Min()
+			require.False(t, ok)
+			_, _, ok = m.Max()
+			require.False(t, ok)
+			require.False(t, m.Delete(1))
+			require.Empty(t, collectRange(t, m, nil, 0, 100))
+		})
+	}
+}
+
+// 2. Put replaces values and reports new keys; Range bounds are closed.
+func TestOrderedMapBasics(t *testing.T) {
+	for _, impl := range orderedMapImpls {
+		t.Run(impl.name, func(t *testing.T) {
+			m := impl.new()
+			for _, k := range []int{5, 1, 9, 3, 7} {
+				require.True(t, m.Put(k, k*10))
+			}
+			require.False(t, m.Put(3, 33))
+			v, ok := m.Get(3)
+			require.True(t, ok)
+			require.Equal(t, 33, v)
+			require.Equal(t, 5, m.Len())
+
+			k, v, ok := m.Min()
+			require.True(t, ok)
+			require.Equal(t, [2]int{1, 10}, [2]int{k, v})
+			k, v, _ = m.Max()
+			require.Equal(t, [2]int{9, 90}, [2]int{k, v})
+
+			ref := map[int]int{1: 10, 3: 33, 5: 50, 7: 70, 9: 90}
+			require.Equal(t, []int{3, 5, 7}, collectRange(t, m, ref, 3, 7))
+			require.Equal(t, []int{3, 5, 7}, collectRange(t, m, ref, 2, 8))
+			require.Empty(t, collectRange(t, m, ref, 8, 2))
+			require.Empty(t, collectRange(t, m, ref, 10, 20))
+
+			for range m.Range(0, 100) {
+				break // early exit must be honoured
+			}
+			require.True(t, m.Delete(5))
+			require.False(t, m.Delete(5))
+			require.Equal(t, []int{3, 7}, collectRange(t, m, ref, 3, 7))
+		})
+	}
+}
+
+// 3. Random operations keep every backend in step with a Go map.
+func TestOrderedMapConformance(t *testing.T) {
+	for _, impl := range orderedMapImpls {
+		t.Run(impl.name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(21, 22))
+			m, ref := impl.new(), map[int]int{}
+			for range 3000 {
+				k := r.IntN(300)
+				switch r.IntN(4) {
+				case 0, 1:
+					_, existed := ref[k]
+					require.Equal(t, !existed, m.Put(k, r.Int()))
+					ref[k], _ = m.Get(k)
+				case 2:
+					_, existed := ref[k]
+					require.Equal(t, existed, m.Delete(k))
+					delete(ref, k)
+				default:
+					want, wantOK := ref[k]
+					got, ok := m.Get(k)
+					require.Equal(t, wantOK, ok)
+					require.Equal(t, want, got)
+				}
+				require.Equal(t, len(ref), m.Len())
+			}
+
+			keys := slices.Sorted(maps.Keys(ref))
+			lo, hi := 50, 250
+			var want []int
+			for _, k := range keys {
+				if k >= lo && k <= hi {
+					want = append(want, k)
+				}
+			}
+			require.Equal(t, want, collectRange(t, m, ref, lo, hi))
+			if len(keys) > 0 {
+				k, _, _ := m.Min()
+				require.Equal(t, keys[0], k)
+				k, _, _ = m.Max()
+				require.Equal(t, keys[len(keys)-1], k)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Small trees take the serial path and match rowWiseMax.
+func TestParallelRowWiseMaxSmallTree(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(100, 200, -50, 70, 90, 0, 300))
+	require.Equal(t, rowWiseMax(root)["output"], ParallelRowWiseMax(root, 4))
+
+	got := ParallelRowWiseMax(nil, 4)
+	require.NotNil(t, got)
+	require.Empty(t, got)
+}
+
+// 2. Large trees fan out and still match rowWiseMax for any worker count.
+func TestParallelRowWiseMaxLargeTree(t *testing.T) {
+	root := completeTree(100_000)
+	// Hang an uneven tail off one leaf so subtrees differ in height.
+	leaf := root
+	for leaf.Right != nil {
+		leaf = leaf.Right
+	}
+	leaf.Left = leftSkewedTree(50)
+
+	want := rowWiseMax(root)["output"]
+	for _, workers := range []int{0, 1, 2, 3, 8} {
+		require.Equal(t, want, ParallelRowWiseMax(root, workers))
+	}
+}
+
+// 3. A deep chain never gets wide enough to fan out but stays correct.
+func TestParallelRowWiseMaxDeepChain(t *testing.T) {
+	root := leftSkewedTree(10_000)
+	require.Equal(t, rowWiseMax(root)["output"], ParallelRowWiseMax(root, 4))
+}
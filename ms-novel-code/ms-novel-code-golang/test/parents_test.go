@@ -0,0 +1,78 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Every node maps to its parent and the root to nil.
+func TestAttachParents(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, nil, 6))
+	parents := AttachParents(root)
+	require.Len(t, parents, 6)
+	require.Nil(t, parents.Parent(root))
+	require.True(t, parents.Contains(root))
+	require.Same(t, root, parents.Parent(root.Left))
+	require.Same(t, root.Right, parents.Parent(root.Right.Right))
+	require.False(t, parents.Contains(&Node{Val: 1}))
+	require.Empty(t, AttachParents[int](nil))
+}
+
+// 2. Ancestors and Depth walk upwards only.
+func TestParentMapAncestorsDepth(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, nil, 6))
+	parents := AttachParents(root)
+	leaf := root.Left.Right
+
+	require.Equal(t, []*Node{root.Left, root}, parents.Ancestors(leaf))
+	require.Equal(t, []*Node{}, parents.Ancestors(root))
+	depth, ok := parents.Depth(leaf)
+	require.True(t, ok)
+	require.Equal(t, 2, depth)
+	_, ok = parents.Depth(&Node{})
+	require.False(t, ok)
+}
+
+// 3. LCA and Distance agree with the root-walking LCA.
+func TestParentMapLCADistance(t *testing.T) {
+	root := GenerateRandomTree(200, WithSeed(4))
+	parents := AttachParents(root)
+	nodes := postorderNodes(root)
+	for i := 0; i < len(nodes); i += 7 {
+		for j := 0; j < len(nodes); j += 11 {
+			require.Same(t, LCA(root, nodes[i], nodes[j]), parents.LCA(nodes[i], nodes[j]))
+		}
+	}
+
+	small := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, nil, 6))
+	p := AttachParents(small)
+	d, ok := p.Distance(small.Left.Left, small.Right.Right)
+	require.True(t, ok)
+	require.Equal(t, 4, d)
+	d, _ = p.Distance(small.Left, small.Left)
+	require.Equal(t, 0, d)
+	_, ok = p.Distance(small, &Node{})
+	require.False(t, ok)
+}
+
+// 4. NodesAtDistanceK spreads both down and up from the target.
+func TestNodesAtDistanceK(t *testing.T) {
+	//         3
+	//       /   \
+	//      5     1
+	//     / \   / \
+	//    6   2 0   8
+	//       / \
+	//      7   4
+	root := BuildFromLevelOrder(levelOrderInts(3, 5, 1, 6, 2, 0, 8, nil, nil, 7, 4))
+	target := root.Left
+
+	require.ElementsMatch(t, []int{7, 4, 1}, NodesAtDistanceK(root, target, 2))
+	require.Equal(t, []int{5}, NodesAtDistanceK(root, target, 0))
+	require.ElementsMatch(t, []int{6, 2, 3}, NodesAtDistanceK(root, target, 1))
+	require.ElementsMatch(t, []int{0, 8}, NodesAtDistanceK(root, target, 3))
+	require.Equal(t, []int{}, NodesAtDistanceK(root, target, 4))
+	require.Equal(t, []int{}, NodesAtDistanceK(root, target, -1))
+	require.Equal(t, []int{}, NodesAtDistanceK(root, &Node{Val: 5}, 1))
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pathSumTree is the classic example:
+//
+//	      5
+//	     / \
+//	    4   8
+//	   /   / \
+//	  11  13  4
+//	 /  \    / \
+//	7    2  5   1
+func pathSumTree() *Node {
+	return BuildFromLevelOrder(levelOrderInts(5, 4, 8, 11, nil, 13, 4, 7, 2, nil, nil, 5, 1))
+}
+
+// 1. Every leaf gets its own root path, left to right.
+func TestAllRootToLeafPaths(t *testing.T) {
+	require.Equal(t, [][]int{
+		{5, 4, 11, 7},
+		{5, 4, 11, 2},
+		{5, 8, 13},
+		{5, 8, 4, 5},
+		{5, 8, 4, 1},
+	}, AllRootToLeafPaths(pathSumTree()))
+	require.Equal(t, [][]int{}, AllRootToLeafPaths[int](nil))
+	require.Len(t, AllRootToLeafPaths(leftSkewedTree(5000))[0], 5000)
+}
+
+// 2. PathToNode finds the chain of nodes by identity.
+func TestPathToNode(t *testing.T) {
+	root := pathSumTree()
+	target := root.Right.Right.Left
+	path, ok := PathToNode(root, target)
+	require.True(t, ok)
+	require.Equal(t, []*Node{root, root.Right, root.Right.Right, target}, path)
+
+	path, ok = PathToNode(root, root)
+	require.True(t, ok)
+	require.Equal(t, []*Node{root}, path)
+
+	_, ok = PathToNode(root, &Node{Val: 5})
+	require.False(t, ok)
+}
+
+// 3. PathSumExists only counts complete root-to-leaf paths.
+func TestPathSumExists(t *testing.T) {
+	root := pathSumTree()
+	require.True(t, PathSumExists(root, 22))
+	require.True(t, PathSumExists(root, 26))
+	require.False(t, PathSumExists(root, 9)) // 5+4 stops short of a leaf
+	require.False(t, PathSumExists(nil, 0))
+}
+
+// 4. CountPathsWithSum counts every downward path.
+func TestCountPathsWithSum(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(10, 5, -3, 3, 2, nil, 11, 3, -2, nil, 1))
+	require.Equal(t, 3, CountPathsWithSum(root, 8))
+	require.Equal(t, 3, CountPathsWithSum(pathSumTree(), 22))
+	require.Equal(t, 0, CountPathsWithSum(nil, 0))
+
+	zeros := BuildFromLevelOrder(levelOrderInts(0, 0, 0))
+	require.Equal(t, 5, CountPathsWithSum(zeros, 0)) // 3 singles + 2 pairs
+}
+
+// 5. Ancestors lists the chain above a node, nearest first.
+func TestAncestors(t *testing.T) {
+	root := pathSumTree()
+	leaf := root.Left.Left.Right
+	require.Equal(t, []*Node{root.Left.Left, root.Left, root}, Ancestors(root, leaf))
+	require.Equal(t, AttachParents(root).Ancestors(leaf), Ancestors(root, leaf))
+	require.Equal(t, []*Node{}, Ancestors(root, root))
+	require.Equal(t, []*Node{}, Ancestors(root, &Node{}))
+}
+
+// 6. DepthOf indexes into rowWiseMax's output.
+func TestDepthOf(t *testing.T) {
+	root := pathSumTree()
+	maxes := rowWiseMax(root)["output"]
+	for _, n := range postorderNodes(root) {
+		d, ok := DepthOf(root, n)
+		require.True(t, ok)
+		require.LessOrEqual(t, n.Val, maxes[d])
+	}
+	d, _ := DepthOf(root, root.Right.Right.Right)
+	require.Equal(t, 3, d)
+	_, ok := DepthOf(root, &Node{})
+	require.False(t, ok)
+	_, ok = DepthOf(nil, root)
+	require.False(t, ok)
+}
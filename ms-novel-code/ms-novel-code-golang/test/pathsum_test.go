@@ -0,0 +1,58 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pathVals returns the values of the nodes on a path.
+func pathVals(path []*Node) []int {
+	vals := []int{}
+	for _, n := range path {
+		vals = append(vals, n.Val)
+	}
+	return vals
+}
+
+// 1. Empty trees give a zero sum and an empty path.
+func TestPathSumEmptyTree(t *testing.T) {
+	require.Zero(t, MaxRootToLeafSum(nil))
+	require.Zero(t, MaxPathSum(nil))
+	require.NotNil(t, MaxRootToLeafPath(nil))
+	require.NotNil(t, MaxPathSumPath(nil))
+}
+
+// 2. Root-to-leaf paths must end at a leaf, even through negatives.
+func TestMaxRootToLeafPath(t *testing.T) {
+	//        5
+	//      /   \
+	//     4     8
+	//    /     / \
+	//  11    13   4
+	//  / \         \
+	// 7   2         1
+	root := BuildFromLevelOrder(levelOrderInts(5, 4, 8, 11, nil, 13, 4, 7, 2, nil, nil, nil, 1))
+	require.Equal(t, []int{5, 4, 11, 7}, pathVals(MaxRootToLeafPath(root)))
+	require.Equal(t, 27, MaxRootToLeafSum(root))
+
+	neg := BuildFromLevelOrder(levelOrderInts(-1, -5, nil, -2))
+	require.Equal(t, []int{-1, -5, -2}, pathVals(MaxRootToLeafPath(neg)))
+}
+
+// 3. Any-to-any paths may bend through their highest node.
+func TestMaxPathSumBends(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(-10, 9, 20, nil, nil, 15, 7))
+	require.Equal(t, 42, MaxPathSum(root))
+	require.Equal(t, []int{15, 20, 7}, pathVals(MaxPathSumPath(root)))
+}
+
+// 4. A single node wins when every extension hurts.
+func TestMaxPathSumSingleNode(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(-3, -1, -2))
+	require.Equal(t, -1, MaxPathSum(root))
+	require.Equal(t, []int{-1}, pathVals(MaxPathSumPath(root)))
+
+	chain := BuildFromLevelOrder(levelOrderInts(1, 2, nil, 3, nil, -10, nil, 20))
+	require.Equal(t, 20, MaxPathSum(chain))
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Updates leave earlier snapshots untouched.
+func TestPersistentBSTSnapshots(t *testing.T) {
+	v1 := NewPersistentBST(50, 30, 70, 20, 40, 60, 80)
+	v2 := v1.Insert(45)
+	v3 := v2.Delete(30)
+	v4 := v3.Delete(50)
+
+	require.Equal(t, []int{20, 30, 40, 50, 60, 70, 80}, v1.InOrder())
+	require.Equal(t, []int{20, 30, 40, 45, 50, 60, 70, 80}, v2.InOrder())
+	require.Equal(t, []int{20, 40, 45, 50, 60, 70, 80}, v3.InOrder())
+	require.Equal(t, []int{20, 40, 45, 60, 70, 80}, v4.InOrder())
+	require.Equal(t, []int{7, 8, 7, 6}, []int{v1.Len(), v2.Len(), v3.Len(), v4.Len()})
+
+	for _, v := range []PersistentBST{v1, v2, v3, v4} {
+		ok, _ := ValidateBST(v.Root())
+		require.True(t, ok)
+	}
+	require.True(t, v1.Search(30))
+	require.False(t, v3.Search(30))
+}
+
+// 2. Only the modified path is copied; other subtrees are shared.
+func TestPersistentBSTStructuralSharing(t *testing.T) {
+	v1 := NewPersistentBST(50, 30, 70, 20, 40, 60, 80)
+	v2 := v1.Insert(65)
+	require.NotSame(t, v1.Root(), v2.Root())
+	require.Same(t, v1.Root().Left, v2.Root().Left)
+	require.Same(t, v1.Root().Right.Right, v2.Root().Right.Right)
+
+	v3 := v2.Delete(20)
+	require.Same(t, v2.Root().Right, v3.Root().Right)
+
+	// No-op updates return the same tree.
+	require.Same(t, v3.Root(), v3.Insert(50).Root())
+	require.Same(t, v3.Root(), v3.Delete(99).Root())
+}
+
+// 3. VersionedBST supports time travel and rollback.
+func TestVersionedBSTRollback(t *testing.T) {
+	h := NewVersionedBST()
+	for _, v := range []int{5, 3, 8} {
+		require.True(t, h.Insert(v))
+	}
+	require.False(t, h.Insert(5))
+	require.True(t, h.Delete(3))
+	require.False(t, h.Delete(3))
+	require.Equal(t, 4, h.Version())
+
+	v2, ok := h.At(2)
+	require.True(t, ok)
+	require.Equal(t, []int{3, 5}, v2.InOrder())
+	_, ok = h.At(5)
+	require.False(t, ok)
+
+	require.True(t, h.Rollback(3))
+	require.Equal(t, []int{3, 5, 8}, h.Current().InOrder())
+	require.False(t, h.Rollback(-1))
+
+	v0, _ := h.At(0)
+	require.Equal(t, []int{}, v0.InOrder())
+}
+
+// 4. A persistent tree agrees with BST under random updates.
+func TestPersistentBSTMatchesBST(t *testing.T) {
+	var p PersistentBST
+	b := NewBST()
+	vals := Preorder(GenerateRandomTree(400, WithSeed(3), WithValueRange(0, 100)))
+	for i, v := range vals {
+		if i%3 == 2 {
+			p, _ = p.Delete(v), b.Delete(v)
+		} else {
+			p, _ = p.Insert(v), b.Insert(v)
+		}
+		require.Equal(t, b.InOrder(), p.InOrder())
+		require.Equal(t, b.Len(), p.Len())
+	}
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pooledTree builds a complete tree of n nodes from pool.
+func pooledTree(pool *NodePool, n int) *Node {
+	nodes := make([]*Node, n)
+	for i := range nodes {
+		nodes[i] = pool.AcquireNode(i)
+	}
+	for i, node := range nodes {
+		if l := 2*i + 1; l < n {
+			node.Left = nodes[l]
+		}
+		if r := 2*i + 2; r < n {
+			node.Right = nodes[r]
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	return nodes[0]
+}
+
+// 1. Acquired nodes are always clean, whether fresh or recycled.
+func TestNodePoolAcquireIsClean(t *testing.T) {
+	var pool NodePool
+	for range 3 {
+		root := pooledTree(&pool, 15)
+		require.Equal(t, []int{0, 2, 6, 14}, rowWiseMax(root)["output"])
+		pool.FreeTree(root)
+
+		n := pool.AcquireNode(42)
+		require.Equal(t, 42, n.Val)
+		require.Nil(t, n.Left)
+		require.Nil(t, n.Right)
+		pool.ReleaseNode(n)
+	}
+}
+
+// 2. ReleaseNode clears the node so it does not pin its children.
+func TestNodePoolReleaseClearsChildren(t *testing.T) {
+	var pool NodePool
+	n := pool.AcquireNode(1)
+	n.Left, n.Right = &Node{Val: 2}, &Node{Val: 3}
+	pool.ReleaseNode(n)
+	require.Nil(t, n.Left)
+	require.Nil(t, n.Right)
+
+	require.NotPanics(t, func() {
+		pool.ReleaseNode(nil)
+		pool.FreeTree(nil)
+		FreeTree(nil)
+	})
+}
+
+// 3. The default pool can be shared by concurrent builders.
+func TestNodePoolConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				root := AcquireNode(g)
+				root.Left = AcquireNode(g + 1)
+				root.Right = AcquireNode(g + 2)
+				assert.Equal(t, []int{g, g + 2}, rowWiseMax(root)["output"])
+				FreeTree(root)
+			}
+		}()
+	}
+	wg.Wait()
+}
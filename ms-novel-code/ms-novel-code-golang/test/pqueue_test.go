@@ -0,0 +1,107 @@
+package core
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// task is a queue element with a mutable priority.
+type task struct {
+	name string
+	prio int
+}
+
+func byPrio(a, b task) bool { return a.prio < b.prio }
+
+// 1. Push, Peek and Pop behave like Heap; handles track their element.
+func TestPriorityQueueOrder(t *testing.T) {
+	q := NewPriorityQueue(func(a, b int) bool { return a < b })
+	_, ok := q.Pop()
+	require.False(t, ok)
+
+	h := q.Push(5)
+	for _, v := range []int{3, 9, 1, 7} {
+		q.Push(v)
+	}
+	require.Equal(t, 5, h.Value())
+	top, _ := q.Peek()
+	require.Equal(t, 1, top)
+
+	var got []int
+	for q.Len() > 0 {
+		v, _ := q.Pop()
+		got = append(got, v)
+	}
+	require.Equal(t, []int{1, 3, 5, 7, 9}, got)
+	require.False(t, q.Contains(h))
+}
+
+// 2. UpdatePriority moves elements both ways; Remove drops them.
+func TestPriorityQueueHandles(t *testing.T) {
+	q := NewPriorityQueue(byPrio)
+	a := q.Push(task{"a", 10})
+	b := q.Push(task{"b", 20})
+	c := q.Push(task{"c", 30})
+
+	require.True(t, q.UpdatePriority(c, task{"c", 5})) // decrease-key
+	top, _ := q.Peek()
+	require.Equal(t, "c", top.name)
+	require.True(t, q.UpdatePriority(c, task{"c", 50}))
+	require.True(t, q.Remove(a))
+	require.False(t, q.Remove(a))
+	require.False(t, q.UpdatePriority(a, task{"a", 1}))
+
+	v, _ := q.Pop()
+	require.Equal(t, "b", v.name)
+	require.Equal(t, 1, q.Len())
+	require.True(t, q.Contains(c))
+	require.False(t, q.Contains(b))
+
+	other := NewPriorityQueue(byPrio)
+	other.Push(task{"x", 1})
+	require.False(t, other.Remove(c)) // handles belong to their own queue
+	require.False(t, q.Contains(nil))
+}
+
+// 3. Random operations agree with a sorted reference.
+func TestPriorityQueueRandomized(t *testing.T) {
+	r := rand.New(rand.NewPCG(13, 14))
+	q := NewPriorityQueue(byPrio)
+	live := map[*PQHandle[task]]bool{}
+	for range 2000 {
+		switch r.IntN(4) {
+		case 0:
+			live[q.Push(task{prio: r.IntN(1000)})] = true
+		case 1:
+			for h := range live {
+				require.True(t, q.UpdatePriority(h, task{prio: r.IntN(1000)}))
+				break
+			}
+		case 2:
+			for h := range live {
+				require.True(t, q.Remove(h))
+				delete(live, h)
+				break
+			}
+		default:
+			var prios []int
+			for h := range live {
+				prios = append(prios, h.Value().prio)
+			}
+			v, ok := q.Pop()
+			require.Equal(t, len(prios) > 0, ok)
+			if ok {
+				require.Equal(t, slices.Min(prios), v.prio)
+				for h := range live {
+					if !q.Contains(h) {
+						delete(live, h)
+					}
+				}
+			}
+		}
+		require.Equal(t, len(live), q.Len())
+	}
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Empty tree prints nothing.
+func TestPrintTreeEmpty(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, PrintTree(nil, &sb))
+	require.Empty(t, sb.String())
+}
+
+// 2. Branches, labels, and indentation follow the unix tree layout.
+func TestPrintTreeLayout(t *testing.T) {
+	root := &Node{Val: 10}
+	root.Left = &Node{Val: 5}
+	root.Right = &Node{Val: 4}
+	root.Left.Left = &Node{Val: 8}
+	root.Left.Right = &Node{Val: 9}
+	root.Right.Right = &Node{Val: 15}
+
+	var sb strings.Builder
+	require.NoError(t, PrintTree(root, &sb))
+	want := "10\n" +
+		"|-- L: 5\n" +
+		"|   |-- L: 8\n" +
+		"|   `-- R: 9\n" +
+		"`-- R: 4\n" +
+		"    `-- R: 15\n"
+	require.Equal(t, want, sb.String())
+}
+
+// 3. Max depth truncates and the formatter renders values.
+func TestPrintTreeOptions(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4))
+
+	var sb strings.Builder
+	err := PrintTree(root, &sb,
+		WithPrintMaxDepth(1),
+		WithPrintFormat(func(v int) string { return fmt.Sprintf("<%d>", v) }),
+	)
+	require.NoError(t, err)
+	want := "<1>\n" +
+		"|-- L: <2>\n" +
+		"|   `-- ...\n" +
+		"`-- R: <3>\n"
+	require.Equal(t, want, sb.String())
+}
+
+// 4. Write failures are reported.
+func TestPrintTreeWriteError(t *testing.T) {
+	require.Error(t, PrintTree(&Node{Val: 1}, failingWriter{}))
+}
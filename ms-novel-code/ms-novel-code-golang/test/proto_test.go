@@ -0,0 +1,83 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. The wire bytes match what protoc-generated code produces.
+func TestProtoWireLayout(t *testing.T) {
+	pt := ToProto(BuildFromLevelOrder(levelOrderInts(1, -2)))
+	data, err := pt.MarshalProto()
+	require.NoError(t, err)
+	// nodes {val: 1 left: 1}, nodes {val: -2}, with zig-zag values.
+	require.Equal(t, []byte{0x0a, 0x04, 0x08, 0x02, 0x10, 0x01, 0x0a, 0x02, 0x08, 0x03}, data)
+
+	data, err = ToProto(nil).MarshalProto()
+	require.NoError(t, err)
+	require.Empty(t, data)
+}
+
+// 2. Trees round-trip, keeping absent children and zero values.
+func TestProtoRoundTrip(t *testing.T) {
+	for _, root := range []*Node{
+		nil,
+		BuildFromLevelOrder(levelOrderInts(0, nil, 0, nil, 7)),
+		GenerateRandomTree(500, WithSeed(5)),
+		GenerateRandomTree(2000, WithShape(ShapeLeftSkewed)),
+	} {
+		data, err := ToProto(root).MarshalProto()
+		require.NoError(t, err)
+		pt, err := UnmarshalProto(data)
+		require.NoError(t, err)
+		got, err := FromProto(pt)
+		require.NoError(t, err)
+		require.True(t, Equal(root, got))
+	}
+}
+
+// 3. Unknown fields are skipped.
+func TestProtoUnknownFields(t *testing.T) {
+	// Tree field 9 (fixed32), then nodes {val: 1, field 4: "x"}.
+	data := []byte{0x4d, 1, 2, 3, 4, 0x0a, 0x05, 0x08, 0x02, 0x22, 0x01, 'x'}
+	pt, err := UnmarshalProto(data)
+	require.NoError(t, err)
+	root, err := FromProto(pt)
+	require.NoError(t, err)
+	require.Equal(t, &Node{Val: 1}, root)
+}
+
+// 4. Malformed messages, bad indices and oversized trees are rejected.
+func TestProtoErrors(t *testing.T) {
+	for _, data := range [][]byte{
+		{0x0a, 0x05, 0x08}, // truncated node
+		{0x0a, 0x02, 0x08}, // truncated value
+		{0x00, 0x00},       // field number 0
+		{0x0b},             // unknown wire type
+	} {
+		_, err := UnmarshalProto(data)
+		require.ErrorIs(t, err, ErrProtoFormat, "%x", data)
+	}
+
+	idx := func(i uint32) *uint32 { return &i }
+	for _, pt := range []*ProtoTree{
+		{Nodes: []ProtoNode{{Left: idx(0)}}},                     // self loop
+		{Nodes: []ProtoNode{{Left: idx(2)}, {}}},                 // out of range
+		{Nodes: []ProtoNode{{Left: idx(1), Right: idx(1)}, {}}},  // two parents
+		{Nodes: []ProtoNode{{Left: idx(1)}, {}, {}}},             // orphan
+		{Nodes: []ProtoNode{{Left: idx(2)}, {}, {Left: idx(1)}}}, // backwards
+	} {
+		_, err := FromProto(pt)
+		require.ErrorIs(t, err, ErrProtoFormat)
+	}
+
+	data, err := ToProto(GenerateRandomTree(10, WithSeed(1))).MarshalProto()
+	require.NoError(t, err)
+	_, err = UnmarshalProto(data, WithProtoMaxNodes(9))
+	require.ErrorIs(t, err, ErrProtoFormat)
+	pt, err := UnmarshalProto(data)
+	require.NoError(t, err)
+	_, err = FromProto(pt, WithProtoMaxNodes(9))
+	require.ErrorIs(t, err, ErrProtoFormat)
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Inserted points are found; points outside the bounds are rejected.
+func TestQuadtreeInsertContains(t *testing.T) {
+	q := NewQuadtree(Rect{0, 0, 100, 100}, 2)
+	pts := []Point{{10, 10}, {90, 90}, {50, 50}, {10, 90}, {90, 10}, {50, 50}, {100, 100}}
+	for _, p := range pts {
+		require.True(t, q.Insert(p))
+	}
+	require.False(t, q.Insert(Point{101, 0}))
+	require.Equal(t, len(pts), q.Len())
+
+	for _, p := range pts {
+		require.True(t, q.Contains(p))
+	}
+	require.False(t, q.Contains(Point{11, 10}))
+	require.False(t, q.Contains(Point{-1, 0}))
+
+	require.Panics(t, func() { NewQuadtree(Rect{0, 0, 1, 1}, 0) })
+	require.Panics(t, func() { NewQuadtree(Rect{1, 0, 0, 1}, 4) })
+}
+
+// 2. Region queries agree with a linear scan.
+func TestQuadtreeQuery(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	q := NewQuadtree(Rect{0, 0, 1000, 1000}, 4)
+	var pts []Point
+	for range 2000 {
+		p := Point{uniform(r, 1000), uniform(r, 1000)}
+		pts = append(pts, p)
+		q.Insert(p)
+	}
+	for range 50 {
+		x, y := uniform(r, 900), uniform(r, 900)
+		box := Rect{x, y, x + uniform(r, 100), y + uniform(r, 100)}
+		var want []Point
+		for _, p := range pts {
+			if box.Contains(p) {
+				want = append(want, p)
+			}
+		}
+		require.ElementsMatch(t, want, q.Query(box))
+	}
+	require.Equal(t, []Point{}, q.Query(Rect{2000, 2000, 3000, 3000}))
+}
+
+// 3. Duplicates beyond the capacity stop splitting at the depth limit.
+func TestQuadtreeDuplicates(t *testing.T) {
+	q := NewQuadtree(Rect{0, 0, 1, 1}, 1)
+	for range 10 {
+		require.True(t, q.Insert(Point{0.5, 0.5}))
+	}
+	require.Len(t, q.Query(Rect{0.5, 0.5, 0.5, 0.5}), 10)
+}
+
+// 4. ExportDOT draws regions with quadrant-labelled edges.
+func TestQuadtreeExportDOT(t *testing.T) {
+	q := NewQuadtree(Rect{0, 0, 4, 4}, 1)
+	q.Insert(Point{1, 1})
+	q.Insert(Point{3, 3})
+
+	var sb strings.Builder
+	require.NoError(t, q.ExportDOT(&sb))
+	want := "digraph quadtree {\n" +
+		"\tnode [shape=box];\n" +
+		"\tn0 [label=\"[0,4]x[0,4]\"];\n" +
+		"\tn1 [label=\"[0,2]x[0,2]\\n(1,1)\"];\n" +
+		"\tn2 [label=\"[2,4]x[0,2]\"];\n" +
+		"\tn3 [label=\"[0,2]x[2,4]\"];\n" +
+		"\tn4 [label=\"[2,4]x[2,4]\\n(3,3)\"];\n" +
+		"\tn0 -> n1 [label=\"SW\"];\n" +
+		"\tn0 -> n2 [label=\"SE\"];\n" +
+		"\tn0 -> n3 [label=\"NW\"];\n" +
+		"\tn0 -> n4 [label=\"NE\"];\n" +
+		"}\n"
+	require.Equal(t, want, sb.String())
+	require.Error(t, q.ExportDOT(failingWriter{}))
+}
+
+// uniform returns a uniform float in [0, hi).
+func uniform(r *rand.Rand, hi float64) float64 { return r.Float64() * hi }
@@ -0,0 +1,44 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Elements come out in FIFO order across wrap-around and growth.
+func TestRingQueueFIFO(t *testing.T) {
+	var q ringQueue[int]
+	next := 0
+	for i := 0; i < 100; i++ {
+		q.Push(i)
+		if i%3 == 0 {
+			require.Equal(t, next, q.Pop())
+			next++
+		}
+	}
+	for q.Len() > 0 {
+		require.Equal(t, next, q.Pop())
+		next++
+	}
+	require.Equal(t, 100, next)
+}
+
+// 2. Steady push/pop traffic reuses the buffer instead of growing it.
+func TestRingQueueBoundedCapacity(t *testing.T) {
+	var q ringQueue[int]
+	for i := 0; i < 10000; i++ {
+		q.Push(i)
+		q.Push(i)
+		q.Pop()
+		q.Pop()
+	}
+	require.Equal(t, 0, q.Len())
+	require.LessOrEqual(t, len(q.buf), 8)
+}
+
+// 3. Popping an empty queue panics.
+func TestRingQueuePopEmpty(t *testing.T) {
+	var q ringQueue[int]
+	require.Panics(t, func() { q.Pop() })
+}
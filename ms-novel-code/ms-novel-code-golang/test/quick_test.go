@@ -0,0 +1,60 @@
+package core
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Property: rowWiseMax reports one value per level.
+func TestQuickRowWiseMaxLengthIsHeight(t *testing.T) {
+	prop := func(tree Tree) bool {
+		return len(rowWiseMax(tree.Root)["output"]) == Height(tree.Root)
+	}
+	require.NoError(t, quick.Check(prop, nil))
+}
+
+// 2. Property: each level's max bounds every value on that level.
+func TestQuickRowWiseMaxBoundsLevel(t *testing.T) {
+	prop := func(tree Tree) bool {
+		maxes := rowWiseMax(tree.Root)["output"]
+		for depth, vals := range Levels(tree.Root) {
+			for _, v := range vals {
+				if v > maxes[depth] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	require.NoError(t, quick.Check(prop, nil))
+}
+
+// 3. The fuzz corpus encoding round-trips generated trees.
+func TestFuzzCorpusRoundTrip(t *testing.T) {
+	prop := func(tree Tree) bool {
+		return Equal(tree.Root, DecodeFuzzCorpus(EncodeFuzzCorpus(tree)).Root)
+	}
+	require.NoError(t, quick.Check(prop, nil))
+	require.Nil(t, DecodeFuzzCorpus(nil).Root)
+}
+
+// FuzzRowWiseMax checks rowWiseMax against LevelStats on arbitrary trees.
+func FuzzRowWiseMax(f *testing.F) {
+	f.Add(EncodeFuzzCorpus(Tree{Root: BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4))}))
+	f.Add(EncodeFuzzCorpus(Tree{Root: leftSkewedTree(20)}))
+	f.Add([]byte{0xff, 0x01, 0x03})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		root := DecodeFuzzCorpus(data).Root
+		require.LessOrEqual(t, Size(root), len(data))
+
+		maxes := rowWiseMax(root)["output"]
+		stats := LevelStats(root)
+		require.Len(t, maxes, len(stats))
+		for i, st := range stats {
+			require.Equal(t, st.Max, maxes[i])
+		}
+	})
+}
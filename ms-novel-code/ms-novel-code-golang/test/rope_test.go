@@ -0,0 +1,119 @@
+package core
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Basic editing operations.
+func TestRopeEditing(t *testing.T) {
+	r := NewRope("hello world")
+	r.Insert(5, ",")
+	r.Insert(r.Len(), "!")
+	require.Equal(t, "hello, world!", r.String())
+	r.Delete(0, 7)
+	require.Equal(t, "world!", r.String())
+	require.Equal(t, byte('w'), r.Index(0))
+	require.Equal(t, "orl", r.Slice(1, 4))
+	require.Equal(t, "world!", r.String()) // Slice leaves the text alone
+	require.Equal(t, 6, r.Len())
+}
+
+// 2. Split and Concat move text between ropes.
+func TestRopeSplitConcat(t *testing.T) {
+	text := strings.Repeat("abcdefghij", 300) // several chunks
+	r := NewRope(text)
+	tail := r.Split(1234)
+	require.Equal(t, text[:1234], r.String())
+	require.Equal(t, text[1234:], tail.String())
+
+	r.Concat(tail)
+	require.Equal(t, text, r.String())
+	require.Zero(t, tail.Len())
+	require.Equal(t, "", tail.String())
+
+	empty := NewRope("")
+	require.Zero(t, empty.Split(0).Len())
+	empty.Concat(NewRope("x"))
+	require.Equal(t, "x", empty.String())
+
+	// Concatenating a rope with itself doubles it instead of losing it.
+	r = NewRope(text)
+	r.Concat(r)
+	require.Equal(t, text+text, r.String())
+	require.Equal(t, byte('e'), r.Index(len(text)+4))
+}
+
+// 3. Random edits agree with plain string operations.
+func TestRopeRandomized(t *testing.T) {
+	rng := rand.New(rand.NewPCG(19, 20))
+	model := ""
+	r := NewRope("")
+	for range 1500 {
+		switch i := rng.IntN(len(model) + 1); rng.IntN(4) {
+		case 0, 1:
+			s := strings.Repeat(string(rune('a'+rng.IntN(26))), rng.IntN(700))
+			r.Insert(i, s)
+			model = model[:i] + s + model[i:]
+		case 2:
+			j := i + rng.IntN(len(model)-i+1)
+			r.Delete(i, j)
+			model = model[:i] + model[j:]
+		default:
+			if i < len(model) {
+				require.Equal(t, model[i], r.Index(i))
+			}
+			j := i + rng.IntN(len(model)-i+1)
+			require.Equal(t, model[i:j], r.Slice(i, j))
+		}
+		require.Equal(t, len(model), r.Len())
+	}
+	require.Equal(t, model, r.String())
+}
+
+// 4. Out-of-range positions panic.
+func TestRopeBounds(t *testing.T) {
+	r := NewRope("abc")
+	require.Panics(t, func() { r.Index(3) })
+	require.Panics(t, func() { r.Insert(4, "x") })
+	require.Panics(t, func() { r.Delete(2, 1) })
+	require.Panics(t, func() { r.Slice(-1, 2) })
+	require.Panics(t, func() { r.Split(5) })
+}
+
+// ropeChunks returns the number of chunks, i.e. tree nodes, in r.
+func ropeChunks(r *Rope) int {
+	n := 0
+	r.each(func(string) { n++ })
+	return n
+}
+
+// 5. Typing and reading do not fragment the rope into tiny chunks.
+func TestRopeCoalescesChunks(t *testing.T) {
+	r := NewRope("")
+	for i := range 5000 {
+		r.Insert(r.Len(), string(rune('a'+i%26)))
+	}
+	require.Equal(t, (5000+ropeMaxChunk-1)/ropeMaxChunk, ropeChunks(r))
+
+	// Typing at a moving cursor in the middle, with reads in between.
+	rng := rand.New(rand.NewPCG(21, 22))
+	r = NewRope(strings.Repeat("x", 20_000))
+	model := r.String()
+	cursor := 10_000
+	for range 5000 {
+		if rng.IntN(20) == 0 {
+			cursor = rng.IntN(r.Len() + 1)
+		}
+		r.Insert(cursor, "y")
+		model = model[:cursor] + "y" + model[cursor:]
+		cursor++
+		lo := rng.IntN(r.Len())
+		require.Equal(t, model[lo:min(lo+10, len(model))], r.Slice(lo, min(lo+10, r.Len())))
+	}
+	require.Equal(t, model, r.String())
+	require.LessOrEqual(t, ropeChunks(r), 2*r.Len()/ropeMaxChunk+2)
+}
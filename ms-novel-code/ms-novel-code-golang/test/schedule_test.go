@@ -0,0 +1,77 @@
+package core
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. The greedy schedule picks the most meetings; shared endpoints clash.
+func TestMaxNonOverlapping(t *testing.T) {
+	ivs := []Interval{{1, 4}, {3, 5}, {0, 6}, {5, 7}, {3, 9}, {5, 9}, {6, 10}, {8, 11}, {8, 12}, {2, 14}, {12, 16}}
+	require.Equal(t, []Interval{{1, 4}, {5, 7}, {8, 11}, {12, 16}}, MaxNonOverlapping(ivs))
+	require.Equal(t, []Interval{{1, 2}}, MaxNonOverlapping([]Interval{{1, 2}, {2, 3}}))
+	require.Equal(t, []Interval{}, MaxNonOverlapping(nil))
+	require.Equal(t, Interval{1, 4}, ivs[0]) // input untouched
+	require.Panics(t, func() { MaxNonOverlapping([]Interval{{2, 1}}) })
+}
+
+// 2. FindConflicts reports index pairs, including duplicates.
+func TestFindConflicts(t *testing.T) {
+	ivs := []Interval{{1, 3}, {5, 8}, {2, 4}, {9, 9}, {1, 3}, {8, 10}}
+	require.Equal(t, [][2]int{{0, 2}, {0, 4}, {1, 5}, {2, 4}, {3, 5}}, FindConflicts(ivs))
+	require.Equal(t, [][2]int{}, FindConflicts([]Interval{{1, 2}, {3, 4}}))
+	require.Equal(t, [][2]int{}, FindConflicts(nil))
+}
+
+// 3. Extreme endpoints sort correctly instead of overflowing.
+func TestScheduleExtremes(t *testing.T) {
+	ivs := []Interval{{math.MinInt, math.MaxInt}, {0, math.MaxInt}, {math.MinInt, -1}, {math.MaxInt, math.MaxInt}}
+	require.Equal(t, []Interval{{math.MinInt, -1}, {math.MaxInt, math.MaxInt}}, MaxNonOverlapping(ivs))
+	require.Equal(t, [][2]int{{0, 1}, {0, 2}, {0, 3}, {1, 3}}, FindConflicts(ivs))
+}
+
+// 4. Both helpers agree with brute force on random input.
+func TestScheduleRandomized(t *testing.T) {
+	r := rand.New(rand.NewPCG(17, 18))
+	for range 50 {
+		ivs := make([]Interval, r.IntN(40))
+		for i := range ivs {
+			lo := r.IntN(100)
+			ivs[i] = Interval{lo, lo + r.IntN(15)}
+		}
+
+		want := [][2]int{}
+		for i := range ivs {
+			for j := i + 1; j < len(ivs); j++ {
+				if ivs[i].Overlaps(ivs[j]) {
+					want = append(want, [2]int{i, j})
+				}
+			}
+		}
+		require.Equal(t, want, FindConflicts(ivs))
+
+		picked := MaxNonOverlapping(ivs)
+		require.Empty(t, FindConflicts(picked))
+		require.Equal(t, bruteMaxNonOverlapping(ivs), len(picked))
+	}
+}
+
+// bruteMaxNonOverlapping finds the optimum by dynamic programming over
+// the intervals sorted by start: best[i] is the most that fit from i on.
+func bruteMaxNonOverlapping(ivs []Interval) int {
+	sorted := slices.Clone(ivs)
+	slices.SortFunc(sorted, compareIntervals)
+	best := make([]int, len(sorted)+1)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		next := i + 1
+		for next < len(sorted) && sorted[next].Lo <= sorted[i].Hi {
+			next++
+		}
+		best[i] = max(best[i+1], 1+best[next])
+	}
+	return best[0]
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Queries over a small sequence.
+func TestSegmentTreeQueries(t *testing.T) {
+	st := NewSegmentTree([]int{5, -2, 7, 3, 0, 9})
+	require.Equal(t, 6, st.Len())
+	require.Equal(t, -2, st.RangeMin(0, 6))
+	require.Equal(t, 9, st.RangeMax(0, 6))
+	require.Equal(t, 22, st.RangeSum(0, 6))
+	require.Equal(t, 7, st.RangeMax(1, 4))
+	require.Equal(t, 3, st.RangeMin(3, 4))
+	require.Zero(t, st.RangeSum(2, 2))
+}
+
+// 2. Random updates and queries agree with a brute-force scan.
+func TestSegmentTreeRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	vals := make([]int, 37)
+	for i := range vals {
+		vals[i] = rng.Intn(200) - 100
+	}
+	st := NewSegmentTree(vals)
+
+	for step := 0; step < 2000; step++ {
+		if rng.Intn(2) == 0 {
+			i, v := rng.Intn(len(vals)), rng.Intn(200)-100
+			vals[i] = v
+			st.Update(i, v)
+			require.Equal(t, v, st.Get(i))
+			continue
+		}
+		lo := rng.Intn(len(vals))
+		hi := lo + 1 + rng.Intn(len(vals)-lo)
+		wantMin, wantMax, wantSum := vals[lo], vals[lo], 0
+		for _, v := range vals[lo:hi] {
+			wantMin, wantMax, wantSum = min(wantMin, v), max(wantMax, v), wantSum+v
+		}
+		require.Equal(t, wantMin, st.RangeMin(lo, hi))
+		require.Equal(t, wantMax, st.RangeMax(lo, hi))
+		require.Equal(t, wantSum, st.RangeSum(lo, hi))
+	}
+}
+
+// 3. Invalid indexes and ranges panic.
+func TestSegmentTreeBounds(t *testing.T) {
+	st := NewSegmentTree([]int{1, 2, 3})
+	require.Panics(t, func() { st.Get(3) })
+	require.Panics(t, func() { st.Update(-1, 0) })
+	require.Panics(t, func() { st.RangeMin(1, 1) })
+	require.Panics(t, func() { st.RangeSum(2, 4) })
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. The encoding is preorder with null markers.
+func TestSerializeFormat(t *testing.T) {
+	require.Equal(t, "#", Serialize(nil))
+	require.Equal(t, "1,2,#,#,3,#,#", Serialize(BuildFromLevelOrder(levelOrderInts(1, 2, 3))))
+	require.Equal(t, "-5,#,7,#,#", Serialize(BuildFromLevelOrder(levelOrderInts(-5, nil, 7))))
+}
+
+// 2. Trees round-trip, including empty and deep ones.
+func TestSerializeRoundTrip(t *testing.T) {
+	trees := []*Node{
+		nil,
+		{Val: 0},
+		BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4, nil, 5, 6)),
+		leftSkewedTree(100_000),
+	}
+	for _, root := range trees {
+		back, err := Deserialize(Serialize(root))
+		require.NoError(t, err)
+		require.True(t, Equal(root, back))
+	}
+}
+
+// 3. Malformed input is rejected.
+func TestDeserializeErrors(t *testing.T) {
+	for _, s := range []string{"", "1,#", "1,#,#,#", "1,x,#", "1,,#"} {
+		_, err := Deserialize(s)
+		require.Error(t, err, s)
+	}
+}
+
+// 4. ParseLevelOrder accepts the common level-order spellings.
+func TestParseLevelOrder(t *testing.T) {
+	want := BuildFromLevelOrder(levelOrderInts(1, 2, nil, 3))
+	for _, s := range []string{"[1,2,null,3]", "1 2 nil 3", " [1, 2, #, 3] \n", "1,2,null,3,null,null"} {
+		got, err := ParseLevelOrder(s)
+		require.NoError(t, err, s)
+		require.True(t, Equal(want, got), s)
+	}
+
+	got, err := ParseLevelOrder("[]")
+	require.NoError(t, err)
+	require.Nil(t, got)
+	_, err = ParseLevelOrder("[1,x]")
+	require.ErrorContains(t, err, `token 1: invalid value "x"`)
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Add, Remove and Contains track membership; iteration is ordered.
+func TestSetBasics(t *testing.T) {
+	s := NewSet(5, 3, 8, 3)
+	require.Equal(t, 3, s.Len())
+	require.False(t, s.Add(8))
+	require.True(t, s.Add(1))
+	require.True(t, s.Contains(3))
+	require.True(t, s.Remove(3))
+	require.False(t, s.Remove(3))
+	require.False(t, s.Contains(3))
+
+	require.Equal(t, []int{1, 5, 8}, s.Values())
+	require.Equal(t, []int{5, 8}, slices.Collect(s.Range(2, 9)))
+	lo, _ := s.Min()
+	hi, _ := s.Max()
+	require.Equal(t, [2]int{1, 8}, [2]int{lo, hi})
+}
+
+// 2. Set algebra returns new sets and leaves the operands alone.
+func TestSetAlgebra(t *testing.T) {
+	a := NewSet("apple", "banana", "cherry")
+	b := NewSet("banana", "date", "cherry", "elder")
+
+	require.Equal(t, []string{"apple", "banana", "cherry", "date", "elder"}, a.Union(b).Values())
+	require.Equal(t, []string{"banana", "cherry"}, a.Intersection(b).Values())
+	require.Equal(t, []string{"banana", "cherry"}, b.Intersection(a).Values())
+	require.Equal(t, []string{"apple"}, a.Difference(b).Values())
+	require.Equal(t, []string{"date", "elder"}, b.Difference(a).Values())
+	require.Equal(t, 3, a.Len())
+	require.Equal(t, 4, b.Len())
+}
+
+// 3. Empty sets are handled everywhere.
+func TestSetEmpty(t *testing.T) {
+	empty := NewSet[int]()
+	full := NewSet(1, 2)
+	require.Equal(t, []int{}, empty.Values())
+	_, ok := empty.Min()
+	require.False(t, ok)
+	require.Equal(t, []int{1, 2}, empty.Union(full).Values())
+	require.Zero(t, empty.Intersection(full).Len())
+	require.Equal(t, []int{1, 2}, full.Difference(empty).Values())
+
+	for v := range full.All() {
+		require.Equal(t, 1, v) // early exit is honoured
+		break
+	}
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. An empty tree satisfies every predicate.
+func TestShapePredicatesEmptyTree(t *testing.T) {
+	require.True(t, IsBalanced[int](nil))
+	require.True(t, IsComplete[int](nil))
+	require.True(t, IsPerfect[int](nil))
+	require.True(t, IsFull[int](nil))
+}
+
+// 2. Each predicate on trees that separate them.
+func TestShapePredicates(t *testing.T) {
+	cases := []struct {
+		name                              string
+		tree                              *Node
+		balanced, complete, perfect, full bool
+	}{
+		{"perfect", BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, 6, 7)), true, true, true, true},
+		{"complete", BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4)), true, true, false, false},
+		{"gap", BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4)), true, false, false, false},
+		{"full unbalanced", BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, nil, nil, 6, 7)), false, false, false, true},
+		{"chain", leftSkewedTree(3), false, false, false, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.balanced, IsBalanced(tc.tree))
+			require.Equal(t, tc.complete, IsComplete(tc.tree))
+			require.Equal(t, tc.perfect, IsPerfect(tc.tree))
+			require.Equal(t, tc.full, IsFull(tc.tree))
+		})
+	}
+}
+
+// 3. Balance is checked at every node, not just the root.
+func TestIsBalancedDeepImbalance(t *testing.T) {
+	// Root heights are 3 and 3, but node 2 has heights 2 and 0.
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, nil, 5, 6, 7, nil, 8))
+	require.False(t, IsBalanced(root))
+}
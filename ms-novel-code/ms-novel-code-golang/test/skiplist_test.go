@@ -0,0 +1,87 @@
+package core
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Basic map operations.
+func TestSkipListMap(t *testing.T) {
+	s := NewSkipList[string, int]()
+	_, _, ok := s.Min()
+	require.False(t, ok)
+	_, _, ok = s.Max()
+	require.False(t, ok)
+
+	require.True(t, s.Insert("m", 1))
+	require.True(t, s.Insert("c", 2))
+	require.True(t, s.Insert("x", 3))
+	require.False(t, s.Insert("c", 20))
+	require.Equal(t, 3, s.Len())
+
+	v, ok := s.Get("c")
+	require.True(t, ok)
+	require.Equal(t, 20, v)
+	_, ok = s.Get("d")
+	require.False(t, ok)
+
+	k, _, _ := s.Min()
+	require.Equal(t, "c", k)
+	k, _, _ = s.Max()
+	require.Equal(t, "x", k)
+
+	require.True(t, s.Delete("m"))
+	require.False(t, s.Delete("m"))
+	var keys []string
+	for k := range s.All() {
+		keys = append(keys, k)
+	}
+	require.Equal(t, []string{"c", "x"}, keys)
+}
+
+// 2. Range returns the closed range in order.
+func TestSkipListRange(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for i := 0; i < 100; i += 3 {
+		s.Insert(i, -i)
+	}
+	var keys []int
+	for k, v := range s.Range(10, 22) {
+		require.Equal(t, -k, v)
+		keys = append(keys, k)
+	}
+	require.Equal(t, []int{12, 15, 18, 21}, keys)
+	require.Empty(t, maps.Collect(s.Range(200, 300)))
+	require.Empty(t, maps.Collect(s.Range(5, 1)))
+}
+
+// 3. A SkipList, BTree and SplayTree agree under the same operations.
+func TestSkipListMatchesOrderedMaps(t *testing.T) {
+	s := NewSkipList[int, int]()
+	bt := NewBTree[int, int](4)
+	st := NewSplayTree[int, int]()
+	vals := Preorder(GenerateRandomTree(3000, WithSeed(17), WithValueRange(0, 400)))
+	for i, v := range vals {
+		if i%4 == 3 {
+			want := bt.Delete(v)
+			require.Equal(t, want, s.Delete(v))
+			require.Equal(t, want, st.Delete(v))
+		} else {
+			want := bt.Insert(v, i)
+			require.Equal(t, want, s.Insert(v, i))
+			require.Equal(t, want, st.Insert(v, i))
+		}
+	}
+	require.Equal(t, bt.Len(), s.Len())
+	require.Equal(t, maps.Collect(bt.All()), maps.Collect(s.All()))
+	require.Equal(t, maps.Collect(bt.Range(100, 200)), maps.Collect(s.Range(100, 200)))
+
+	var prev int
+	first := true
+	for k := range s.All() {
+		require.True(t, first || k > prev)
+		prev, first = k, false
+	}
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Get, Insert and Delete behave like a map.
+func TestSplayTreeMap(t *testing.T) {
+	st := NewSplayTree[string, int]()
+	require.True(t, st.Insert("b", 2))
+	require.True(t, st.Insert("a", 1))
+	require.True(t, st.Insert("c", 3))
+	require.False(t, st.Insert("a", 10))
+	require.Equal(t, 3, st.Len())
+
+	v, ok := st.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 10, v)
+	_, ok = st.Get("z")
+	require.False(t, ok)
+
+	require.True(t, st.Delete("b"))
+	require.False(t, st.Delete("b"))
+	require.Equal(t, map[string]int{"a": 10, "c": 3}, maps.Collect(st.All()))
+
+	k, v, ok := st.Max()
+	require.Equal(t, "c", k)
+	require.Equal(t, 3, v)
+	require.True(t, ok)
+}
+
+// 2. Accessed keys move to the root.
+func TestSplayTreeSplaysToRoot(t *testing.T) {
+	st := NewSplayTree[int, struct{}]()
+	for i := range 100 {
+		st.Insert(i, struct{}{})
+	}
+	for _, k := range []int{0, 57, 99, 13} {
+		st.Get(k)
+		require.Equal(t, k, st.Tree().Val)
+	}
+	k, _, _ := st.Min()
+	require.Equal(t, 0, k)
+	require.Equal(t, 0, st.Tree().Val)
+
+	// A miss splays a neighbour of the key instead.
+	require.False(t, st.Splay(-5))
+	require.Equal(t, 0, st.Tree().Val)
+}
+
+// 3. Random operations keep the tree a valid BST in step with a map.
+func TestSplayTreeMatchesMap(t *testing.T) {
+	st := NewSplayTree[int, int]()
+	want := map[int]int{}
+	vals := Preorder(GenerateRandomTree(2000, WithSeed(31), WithValueRange(0, 300)))
+	for i, v := range vals {
+		switch i % 3 {
+		case 0, 1:
+			_, had := want[v]
+			require.Equal(t, !had, st.Insert(v, i))
+			want[v] = i
+		case 2:
+			_, had := want[v]
+			require.Equal(t, had, st.Delete(v))
+			delete(want, v)
+		}
+	}
+	require.Equal(t, len(want), st.Len())
+	require.Equal(t, want, maps.Collect(st.All()))
+	keys := Inorder(st.Tree())
+	require.True(t, slices.IsSorted(keys))
+	require.Len(t, keys, len(want))
+}
+
+// 4. Sequential access degrades gracefully without recursion.
+func TestSplayTreeDeep(t *testing.T) {
+	st := NewSplayTree[int, int]()
+	for i := range 1 << 16 {
+		st.Insert(i, i) // each insert leaves a left-leaning chain
+	}
+	require.Equal(t, 1<<16, Height(st.Tree()))
+	v, ok := st.Get(0)
+	require.True(t, ok)
+	require.Equal(t, 0, v)
+	require.Less(t, Height(st.Tree()), 1<<16)
+
+	_, _, ok = NewSplayTree[int, int]().Min()
+	require.False(t, ok)
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Empty tree yields a non-nil empty slice.
+func TestLevelStatsEmptyTree(t *testing.T) {
+	got := LevelStats(nil)
+	require.NotNil(t, got)
+	require.Empty(t, got)
+}
+
+// 2. Every field is populated for each level.
+func TestLevelStatsMixedTree(t *testing.T) {
+	root := &Node{Val: 1}
+	root.Left = &Node{Val: 2}
+	root.Right = &Node{Val: 3}
+	root.Left.Left = &Node{Val: 8}
+	root.Left.Right = &Node{Val: -4}
+	root.Right.Right = &Node{Val: 5}
+	want := []LevelStat{
+		{Depth: 0, Count: 1, Min: 1, Max: 1, Sum: 1, Mean: 1},
+		{Depth: 1, Count: 2, Min: 2, Max: 3, Sum: 5, Mean: 2.5},
+		{Depth: 2, Count: 3, Min: -4, Max: 8, Sum: 9, Mean: 3},
+	}
+
+	require.Equal(t, want, LevelStats(root))
+}
+
+// 3. Stats agree with the dedicated row-wise functions.
+func TestLevelStatsMatchesRowWise(t *testing.T) {
+	root := &Node{Val: 10}
+	root.Left = &Node{Val: 5}
+	root.Right = &Node{Val: 12}
+	root.Left.Left = &Node{Val: 20}
+	root.Right.Right = &Node{Val: 25}
+
+	stats := LevelStats(root)
+	maxes := rowWiseMax(root)["output"]
+	mins := rowWiseMin(root)["output"]
+	require.Len(t, stats, len(maxes))
+	for i, st := range stats {
+		require.Equal(t, maxes[i], st.Max)
+		require.Equal(t, mins[i], st.Min)
+	}
+}
+
+// 4. Annotate records per-subtree stats for every node.
+func TestAnnotate(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(10, 5, 12, 20, nil, nil, 25))
+	stats := Annotate(root)
+	require.Len(t, stats, 5)
+	require.Equal(t, SubtreeStats{Size: 5, Height: 3, Min: 5, Max: 25, Sum: 72}, stats[root])
+	require.Equal(t, SubtreeStats{Size: 2, Height: 2, Min: 5, Max: 20, Sum: 25}, stats[root.Left])
+	require.Equal(t, SubtreeStats{Size: 1, Height: 1, Min: 25, Max: 25, Sum: 25}, stats[root.Right.Right])
+	require.Empty(t, Annotate(nil))
+}
+
+// 5. Annotations agree with the standalone measures on every subtree.
+func TestAnnotateMatchesMeasures(t *testing.T) {
+	root := GenerateRandomTree(300, WithSeed(21), WithValueRange(-50, 50))
+	for node, st := range Annotate(root) {
+		vals := Preorder(node)
+		require.Equal(t, Size(node), st.Size)
+		require.Equal(t, Height(node), st.Height)
+		require.Equal(t, slices.Min(vals), st.Min)
+		require.Equal(t, slices.Max(vals), st.Max)
+		sum := 0
+		for _, v := range vals {
+			sum += v
+		}
+		require.Equal(t, sum, st.Sum)
+	}
+}
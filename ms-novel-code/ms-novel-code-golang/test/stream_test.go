@@ -0,0 +1,60 @@
+package core
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// collect drains a channel into a slice.
+func collect(ch <-chan int) []int {
+	out := []int{}
+	for v := range ch {
+		out = append(out, v)
+	}
+	return out
+}
+
+// 1. Every order streams the same values as its slice counterpart.
+func TestTraverseChanOrders(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, nil, 6))
+	ctx := context.Background()
+
+	require.Equal(t, Preorder(root), collect(TraverseChan(ctx, root, OrderPre)))
+	require.Equal(t, Inorder(root), collect(TraverseChan(ctx, root, OrderIn)))
+	require.Equal(t, Postorder(root), collect(TraverseChan(ctx, root, OrderPost)))
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6}, collect(TraverseChan(ctx, root, OrderLevel)))
+	require.Empty(t, collect(TraverseChan[int](ctx, nil, OrderIn)))
+}
+
+// 2. Cancelling the context closes the channel and stops the producer.
+func TestTraverseChanCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := TraverseChan(ctx, leftSkewedTree(100_000), OrderIn)
+
+	<-ch
+	<-ch
+	cancel()
+	for range ch {
+		// At most one value may already be in flight.
+	}
+
+	// Poll by hand: require.Eventually runs its condition on a goroutine
+	// of its own, which would be counted.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	require.LessOrEqual(t, runtime.NumGoroutine(), before)
+}
+
+// 3. Unknown orders are rejected up front.
+func TestTraverseChanUnknownOrder(t *testing.T) {
+	require.Panics(t, func() { TraverseChan(context.Background(), &Node{}, TraversalOrder(42)) })
+	require.Equal(t, "TraversalOrder(42)", TraversalOrder(42).String())
+	require.Equal(t, "inorder", OrderIn.String())
+}
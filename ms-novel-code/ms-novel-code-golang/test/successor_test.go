@@ -0,0 +1,55 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// inorderNodes returns the nodes of root in inorder.
+func inorderNodes(root *Node) []*Node {
+	var nodes []*Node
+	VisitInorder(root, func(n *Node) { nodes = append(nodes, n) })
+	return nodes
+}
+
+// 1. Both versions step through a BST in sorted order.
+func TestInorderSuccessorPredecessorBST(t *testing.T) {
+	bst := NewBST(50, 30, 70, 20, 40, 60, 80, 35, 65)
+	nodes := inorderNodes(bst.Root)
+	for i, n := range nodes {
+		var next, prev *Node
+		if i+1 < len(nodes) {
+			next = nodes[i+1]
+		}
+		if i > 0 {
+			prev = nodes[i-1]
+		}
+		require.Same(t, next, InorderSuccessor(bst.Root, n))
+		require.Same(t, next, InorderSuccessorBST(bst.Root, n))
+		require.Same(t, prev, InorderPredecessor(bst.Root, n))
+		require.Same(t, prev, InorderPredecessorBST(bst.Root, n))
+	}
+}
+
+// 2. The general versions work on trees that are not BSTs.
+func TestInorderSuccessorGeneral(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(5, 9, 1, 7, nil, 3))
+	nodes := inorderNodes(root)
+	require.Equal(t, []int{7, 9, 5, 3, 1}, Inorder(root))
+	require.Same(t, nodes[2], InorderSuccessor(root, nodes[1]))
+	require.Same(t, nodes[3], InorderPredecessor(root, nodes[4]))
+	require.Nil(t, InorderSuccessor(root, nodes[4]))
+	require.Nil(t, InorderPredecessor(root, nodes[0]))
+}
+
+// 3. Nodes outside the tree have no neighbours.
+func TestInorderSuccessorForeignNode(t *testing.T) {
+	bst := NewBST(2, 1, 3)
+	stranger := &Node{Val: 2, Right: &Node{Val: 3}}
+	require.Nil(t, InorderSuccessor(bst.Root, stranger))
+	require.Nil(t, InorderSuccessorBST(bst.Root, stranger))
+	require.Nil(t, InorderPredecessorBST(bst.Root, stranger))
+	require.Nil(t, InorderSuccessorBST(bst.Root, nil))
+	require.Nil(t, InorderPredecessor[int](nil, stranger))
+}
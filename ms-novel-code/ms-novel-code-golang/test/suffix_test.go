@@ -0,0 +1,74 @@
+package core
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countOverlapping counts occurrences of sub in s, overlaps included.
+func countOverlapping(s, sub string) int {
+	count := 0
+	for i := 0; i+len(sub) <= len(s); i++ {
+		if s[i:i+len(sub)] == sub {
+			count++
+		}
+	}
+	return count
+}
+
+// 1. Contains and CountOccurrences on a classic example.
+func TestSuffixTreeBanana(t *testing.T) {
+	st := NewSuffixTree("banana")
+	for _, p := range []string{"b", "ana", "nana", "banana", "a", "an"} {
+		require.True(t, st.Contains(p), p)
+	}
+	require.False(t, st.Contains("nab"))
+	require.False(t, st.Contains("bananas"))
+	require.Equal(t, 3, st.CountOccurrences("a"))
+	require.Equal(t, 2, st.CountOccurrences("ana"))
+	require.Equal(t, 0, st.CountOccurrences("x"))
+	require.Equal(t, 7, st.CountOccurrences(""))
+	require.Equal(t, "ana", st.LongestRepeatedSubstring())
+}
+
+// 2. Every substring count agrees with brute force on random texts.
+func TestSuffixTreeMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 8))
+	for range 30 {
+		var sb strings.Builder
+		for range r.IntN(60) {
+			sb.WriteByte("ab"[r.IntN(2)])
+		}
+		text := sb.String()
+		st := NewSuffixTree(text)
+		for i := 0; i <= len(text); i++ {
+			for j := i; j <= len(text) && j <= i+6; j++ {
+				require.Equal(t, countOverlapping(text, text[i:j]), st.CountOccurrences(text[i:j]), "%q in %q", text[i:j], text)
+			}
+		}
+		for _, p := range []string{"aaaaaaaaaa", "abba", "c"} {
+			require.Equal(t, countOverlapping(text, p), st.CountOccurrences(p))
+		}
+	}
+}
+
+// 3. LongestRepeatedSubstring prefers the earliest of equal length.
+func TestSuffixTreeLongestRepeated(t *testing.T) {
+	require.Equal(t, "", NewSuffixTree("").LongestRepeatedSubstring())
+	require.Equal(t, "", NewSuffixTree("abc").LongestRepeatedSubstring())
+	require.Equal(t, "aaaa", NewSuffixTree("aaaaa").LongestRepeatedSubstring())
+	require.Equal(t, "ab", NewSuffixTree("abxyabcdcd").LongestRepeatedSubstring())
+	require.Equal(t, "abcab", NewSuffixTree("abcabcab").LongestRepeatedSubstring())
+	require.True(t, NewSuffixTree("").Contains(""))
+}
+
+// 4. Arbitrary bytes, including zero, are handled.
+func TestSuffixTreeBinary(t *testing.T) {
+	text := "\x00\xff\x00\xff\x00"
+	st := NewSuffixTree(text)
+	require.Equal(t, 2, st.CountOccurrences("\x00\xff"))
+	require.Equal(t, "\x00\xff\x00", st.LongestRepeatedSubstring())
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. The layout is tidy: levels keep their spacing, parents sit over
+// their children, and lone children lean to their side.
+func TestLayoutTreeInvariants(t *testing.T) {
+	for _, shape := range []Shape{ShapeRandom, ShapeBalanced, ShapeLeftSkewed} {
+		root := GenerateRandomTree(300, WithShape(shape), WithSeed(6))
+		xs := layoutTree(root)
+		require.Len(t, xs, 300)
+
+		left := math.Inf(1)
+		walkLevels(root, func(_ int, level []*Node) bool {
+			for i, n := range level {
+				left = min(left, xs[n])
+				if i > 0 {
+					require.GreaterOrEqual(t, xs[n]-xs[level[i-1]], 1-1e-9)
+				}
+				switch {
+				case n.Left != nil && n.Right != nil:
+					require.InDelta(t, xs[n], (xs[n.Left]+xs[n.Right])/2, 1e-9)
+				case n.Left != nil:
+					require.Less(t, xs[n.Left], xs[n])
+				case n.Right != nil:
+					require.Greater(t, xs[n.Right], xs[n])
+				}
+			}
+			return true
+		})
+		require.Zero(t, left)
+	}
+}
+
+// 2. A symmetric tree is laid out as its own mirror image.
+func TestLayoutTreeSymmetric(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 2, 3, nil, nil, 3, 4, nil, nil, 4))
+	xs := layoutTree(root)
+	mirror := CloneInverted(root)
+	mx := layoutTree(mirror)
+	width := 0.0
+	for _, x := range xs {
+		width = max(width, x)
+	}
+	for _, pair := range [][2]*Node{{root, mirror}, {root.Left.Left.Left, mirror.Right.Right.Right}} {
+		require.InDelta(t, xs[pair[0]], width-mx[pair[1]], 1e-9)
+	}
+	require.InDelta(t, xs[root], width/2, 1e-9)
+}
+
+// 3. RenderSVG draws one circle and label per node and one line per edge.
+func TestRenderSVG(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(5, 2, 7, nil, 3))
+	var sb strings.Builder
+	require.NoError(t, RenderSVG(root, &sb,
+		WithSVGNodeRadius(10),
+		WithSVGSpacing(30, 50),
+		WithSVGColors("#eef", "navy", "red"),
+		WithSVGLabel(func(n *Node) string { return "<" + strings.Repeat("*", n.Val%3) + ">" }),
+	))
+	out := sb.String()
+	require.True(t, strings.HasPrefix(out, `<svg xmlns="http://www.w3.org/2000/svg"`))
+	require.True(t, strings.HasSuffix(out, "</svg>\n"))
+	require.Equal(t, 4, strings.Count(out, "<circle"))
+	require.Equal(t, 3, strings.Count(out, "<line"))
+	require.Contains(t, out, `r="10" fill="#eef" stroke="navy"`)
+	require.Contains(t, out, `fill="red">&lt;**&gt;</text>`)
+	require.Contains(t, out, `<circle cx="25" cy="10"`) // root half a unit in, over 2 and 7
+	require.Contains(t, out, `height="120"`)
+}
+
+// 4. Empty trees and write errors.
+func TestRenderSVGEdgeCases(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, RenderSVG(nil, &sb))
+	require.Contains(t, sb.String(), `width="0" height="0"`)
+	require.NotContains(t, sb.String(), "<circle")
+	require.Error(t, RenderSVG(&Node{Val: 1}, failingWriter{}))
+}
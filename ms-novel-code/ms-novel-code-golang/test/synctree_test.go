@@ -0,0 +1,83 @@
+package core
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Single-goroutine use behaves like a BST.
+func TestSyncTreeBasics(t *testing.T) {
+	tree := NewSyncTree(50, 30, 70)
+	require.True(t, tree.Insert(20))
+	require.False(t, tree.Insert(20))
+	require.True(t, tree.Delete(30))
+	require.True(t, tree.Search(20))
+	require.Equal(t, 3, tree.Len())
+	require.Equal(t, []int{50, 70}, tree.RowWiseMax())
+	require.Len(t, tree.LevelStats(), 2)
+}
+
+// 2. Readers never observe a partially applied write. Assertions inside
+// goroutines use assert, since require may only stop the test goroutine.
+func TestSyncTreeConcurrentReadersAndWriter(t *testing.T) {
+	tree := NewSyncTree()
+	const n = 2000
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for v := 0; v < n; v++ {
+			tree.Insert((v * 7919) % n)
+		}
+	}()
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				tree.View(func(root *Node) {
+					// Within one View the node count, the level
+					// stats, and the BST ordering all agree.
+					count := 0
+					for _, st := range LevelStats(root) {
+						count += st.Count
+					}
+					inorder := (&BST{Root: root}).InOrder()
+					assert.Len(t, inorder, count)
+					assert.IsIncreasing(t, inorder)
+				})
+				tree.RowWiseMax()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, n, tree.Len())
+}
+
+// 3. Update applies a batch atomically.
+func TestSyncTreeUpdateBatch(t *testing.T) {
+	tree := NewSyncTree()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			tree.Update(func(b *BST) {
+				b.Insert(2 * g)
+				b.Insert(2*g + 1)
+			})
+			tree.View(func(root *Node) {
+				// Batches land in pairs, so the size is always even.
+				assert.Zero(t, len((&BST{Root: root}).InOrder())%2)
+			})
+		}(g)
+	}
+	wg.Wait()
+	require.Equal(t, 16, tree.Len())
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Threaded iteration matches inorder in both directions.
+func TestThreadedTreeIteration(t *testing.T) {
+	for seed := range uint64(10) {
+		root := GenerateRandomTree(int(seed)*17, WithSeed(seed))
+		tt := NewThreadedTree(root)
+		want := Inorder(root)
+		require.Equal(t, want, append([]int{}, slices.Collect(tt.All())...))
+
+		back := append([]int{}, slices.Collect(tt.Backward())...)
+		slices.Reverse(back)
+		require.Equal(t, want, back)
+	}
+}
+
+// 2. Threads point at inorder neighbours; only the ends stay nil.
+func TestThreadedTreeThreads(t *testing.T) {
+	//     2
+	//    / \
+	//   1   4
+	//      /
+	//     3
+	tt := NewThreadedTree(BuildFromLevelOrder(levelOrderInts(2, 1, 4, nil, nil, 3)))
+	one, three := tt.Root.Left, tt.Root.Right.Left
+	require.Same(t, one, tt.First())
+	require.Nil(t, one.Left)
+	require.True(t, one.RightThread)
+	require.Same(t, tt.Root, one.Right)
+	require.True(t, three.LeftThread)
+	require.Same(t, tt.Root, three.Left)
+	require.Same(t, tt.Root.Right, three.Next())
+	require.Same(t, tt.Root, three.Prev())
+	require.Nil(t, tt.Last().Right)
+}
+
+// 3. Converting back recovers the original tree.
+func TestThreadedTreeRoundTrip(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4, 5))
+	before := Serialize(root)
+	require.True(t, Equal(root, NewThreadedTree(root).Tree()))
+	require.Equal(t, before, Serialize(root))
+
+	empty := NewThreadedTree[int](nil)
+	require.Nil(t, empty.Tree())
+	require.Empty(t, slices.Collect(empty.All()))
+}
+
+// 4. Iteration follows threads without allocating.
+func TestThreadedTreeNoAllocs(t *testing.T) {
+	tt := NewThreadedTree(completeTree(1 << 12))
+	allocs := testing.AllocsPerRun(10, func() {
+		for n := tt.First(); n != nil; n = n.Next() {
+		}
+	})
+	require.Zero(t, allocs)
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Nil trees stay nil.
+func TestInvertNil(t *testing.T) {
+	require.Nil(t, Invert[int](nil))
+	require.Nil(t, CloneInverted[int](nil))
+}
+
+// 2. Invert mirrors in place, so views swap sides.
+func TestInvertInPlace(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(4, 2, 7, 1, 3, 6, 9))
+	left, right := LeftView(root), RightView(root)
+
+	got := Invert(root)
+	require.Same(t, root, got)
+	require.Equal(t, levelOrderInts(4, 7, 2, 9, 6, 3, 1), ToLevelOrder(root))
+	require.Equal(t, left, RightView(root))
+	require.Equal(t, right, LeftView(root))
+}
+
+// 3. CloneInverted leaves the original alone and inverts back to it.
+func TestCloneInverted(t *testing.T) {
+	in := levelOrderInts(1, 2, nil, 3, nil, 4)
+	root := BuildFromLevelOrder(in)
+
+	mirror := CloneInverted(root)
+	require.Equal(t, in, ToLevelOrder(root))
+	require.Equal(t, levelOrderInts(1, nil, 2, nil, 3, nil, 4), ToLevelOrder(mirror))
+	require.Equal(t, in, ToLevelOrder(Invert(mirror)))
+}
+
+// 4. ConvertToBST keeps the shape and sorts the values in inorder.
+func TestConvertToBST(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(10, 2, 7, 8, 4))
+	shape := CloneInverted(CloneInverted(root))
+
+	require.Same(t, root, ConvertToBST(root))
+	require.True(t, SameShape(shape, root))
+	require.Equal(t, levelOrderInts(8, 4, 10, 2, 7), ToLevelOrder(root))
+	ok, _ := ValidateBST(root)
+	require.True(t, ok)
+
+	random := GenerateRandomTree(500, WithSeed(8), WithValueRange(-1e9, 1e9))
+	ConvertToBST(random)
+	ok, _ = ValidateBST(random)
+	require.True(t, ok)
+	require.Nil(t, ConvertToBST[int](nil))
+}
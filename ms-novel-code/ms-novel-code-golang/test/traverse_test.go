@@ -0,0 +1,82 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. The three orders on a small tree.
+func TestDepthFirstOrders(t *testing.T) {
+	//      1
+	//     / \
+	//    2   3
+	//   / \   \
+	//  4   5   6
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, nil, 6))
+	require.Equal(t, []int{1, 2, 4, 5, 3, 6}, Preorder(root))
+	require.Equal(t, []int{4, 2, 5, 1, 3, 6}, Inorder(root))
+	require.Equal(t, []int{4, 5, 2, 6, 3, 1}, Postorder(root))
+}
+
+// 2. Empty trees give non-nil empty slices and no visits.
+func TestDepthFirstEmptyTree(t *testing.T) {
+	require.NotNil(t, Preorder[int](nil))
+	require.Empty(t, Inorder[int](nil))
+	require.Empty(t, Postorder[int](nil))
+	VisitInorder[int](nil, func(*Node) { t.Fatal("unexpected visit") })
+}
+
+// 3. Visitor variants see the same nodes as the slice variants.
+func TestDepthFirstVisitors(t *testing.T) {
+	root := NewBST(50, 30, 70, 20, 40, 60, 80).Root
+	var pre, in, post []int
+	VisitPreorder(root, func(n *Node) { pre = append(pre, n.Val) })
+	VisitInorder(root, func(n *Node) { in = append(in, n.Val) })
+	VisitPostorder(root, func(n *Node) { post = append(post, n.Val) })
+	require.Equal(t, Preorder(root), pre)
+	require.Equal(t, []int{20, 30, 40, 50, 60, 70, 80}, in)
+	require.Equal(t, Postorder(root), post)
+}
+
+// 4. A million-node skewed tree is traversed without recursion.
+func TestDepthFirstDeepTree(t *testing.T) {
+	root := leftSkewedTree(1_000_000)
+	in := Inorder(root)
+	require.Len(t, in, 1_000_000)
+	require.Equal(t, 0, in[0])
+	require.Equal(t, 999_999, Postorder(root)[999_999])
+}
+
+// 5. Walk reports depths and stops when fn returns false.
+func TestWalkEarlyTermination(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, nil, 6))
+
+	var seen, depths []int
+	Walk(root, OrderPre, func(n *Node, depth int) bool {
+		seen = append(seen, n.Val)
+		depths = append(depths, depth)
+		return n.Val != 4
+	})
+	require.Equal(t, []int{1, 2, 4}, seen)
+	require.Equal(t, []int{0, 1, 2}, depths)
+
+	seen = nil
+	Walk(root, OrderLevel, func(n *Node, depth int) bool {
+		seen = append(seen, n.Val)
+		return depth < 2
+	})
+	require.Equal(t, []int{1, 2, 3, 4}, seen)
+}
+
+// 6. Find returns the first match in the chosen order.
+func TestFind(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, nil, 6))
+	even := func(n *Node) bool { return n.Val%2 == 0 }
+
+	require.Equal(t, 2, Find(root, OrderPre, even).Val)
+	require.Equal(t, 4, Find(root, OrderIn, even).Val)
+	require.Equal(t, 4, Find(root, OrderPost, even).Val)
+	require.Nil(t, Find(root, OrderLevel, func(n *Node) bool { return n.Val > 10 }))
+	require.Panics(t, func() { Walk(root, TraversalOrder(-1), func(*Node, int) bool { return true }) })
+}
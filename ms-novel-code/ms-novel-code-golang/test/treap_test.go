@@ -0,0 +1,72 @@
+package core
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Treap behaves like the other SearchTrees.
+func TestTreapSearchTree(t *testing.T) {
+	var tree SearchTree = NewTreap(50, 30, 70, 20, 40, 60, 80)
+	require.False(t, tree.Insert(40))
+	require.True(t, tree.Delete(50))
+	require.False(t, tree.Delete(50))
+	require.Equal(t, []int{20, 30, 40, 60, 70, 80}, tree.InOrder())
+	require.Equal(t, 6, tree.Len())
+	lo, _ := tree.Min()
+	hi, _ := tree.Max()
+	require.Equal(t, 20, lo)
+	require.Equal(t, 80, hi)
+}
+
+// 2. Split and Merge cut and rejoin key ranges.
+func TestTreapSplitMerge(t *testing.T) {
+	tree := NewTreap(1, 2, 3, 4, 5, 6, 7, 8)
+	right := tree.Split(5)
+	require.Equal(t, []int{1, 2, 3, 4}, tree.InOrder())
+	require.Equal(t, []int{5, 6, 7, 8}, right.InOrder())
+	require.Equal(t, 4, right.Len())
+
+	require.ErrorIs(t, right.Merge(tree), ErrTreapOverlap)
+	require.Equal(t, 4, tree.Len())
+
+	require.NoError(t, tree.Merge(right))
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, tree.InOrder())
+	require.Zero(t, right.Len())
+}
+
+// 3. All supports early exit and matches random operations.
+func TestTreapRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	tree, ref := NewTreap(), NewAVL()
+	for i := 0; i < 3000; i++ {
+		v := rng.Intn(500)
+		if rng.Intn(3) == 0 {
+			require.Equal(t, ref.Delete(v), tree.Delete(v))
+		} else {
+			require.Equal(t, ref.Insert(v), tree.Insert(v))
+		}
+	}
+	require.Equal(t, ref.InOrder(), tree.InOrder())
+
+	var firstThree []int
+	for v := range tree.All() {
+		firstThree = append(firstThree, v)
+		if len(firstThree) == 3 {
+			break
+		}
+	}
+	require.Equal(t, ref.InOrder()[:3], firstThree)
+}
+
+// 4. Extreme keys are handled without overflow.
+func TestTreapExtremeKeys(t *testing.T) {
+	tree := NewTreap(math.MinInt, 0, math.MaxInt)
+	require.True(t, tree.Delete(math.MaxInt))
+	require.Equal(t, []int{math.MinInt, 0}, tree.InOrder())
+	require.True(t, tree.Delete(math.MinInt))
+	require.Equal(t, []int{0}, tree.InOrder())
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Both formats write the documented text.
+func TestWriteTree(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, nil, 4, nil, nil, -5))
+	var buf bytes.Buffer
+	require.NoError(t, WriteTree(&buf, root, FormatLevelOrder))
+	require.Equal(t, "1\n2\n3\nnull\n4\nnull\nnull\n-5\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteTree(&buf, root, FormatParen))
+	require.Equal(t, "1(2(,4(-5,)),3)\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteTree(&buf, nil, FormatParen))
+	require.NoError(t, WriteTree(&buf, nil, FormatLevelOrder))
+	require.Empty(t, buf.String())
+	require.Error(t, WriteTree(&buf, root, Format(9)))
+}
+
+// 2. Trees round-trip in both formats, read a byte at a time.
+func TestReadTreeRoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatLevelOrder, FormatParen} {
+		for _, root := range []*Node{
+			nil,
+			&Node{Val: 7},
+			GenerateRandomTree(500, WithSeed(4)),
+			GenerateRandomTree(50_000, WithShape(ShapeLeftSkewed)),
+		} {
+			var buf bytes.Buffer
+			require.NoError(t, WriteTree(&buf, root, format))
+			got, err := ReadTree(iotest.OneByteReader(&buf), format)
+			require.NoError(t, err, format)
+			require.True(t, Equal(root, got), format)
+		}
+	}
+}
+
+// 3. Hand-written input may use blank lines, null spellings and spaces.
+func TestReadTreeLenient(t *testing.T) {
+	want := BuildFromLevelOrder(levelOrderInts(1, nil, 2, 3))
+	got, err := ReadTree(strings.NewReader("1\n\n nil\r\n2\n3\n#\nnull\n"), FormatLevelOrder)
+	require.NoError(t, err)
+	require.True(t, Equal(want, got))
+
+	got, err = ReadTree(strings.NewReader(" 1 (\n  ,\n  2 ( 3 , ) )\n"), FormatParen)
+	require.NoError(t, err)
+	require.True(t, Equal(want, got))
+}
+
+// 4. Malformed input and reader failures are reported.
+func TestReadTreeErrors(t *testing.T) {
+	for _, tc := range []struct {
+		in     string
+		format Format
+		msg    string
+	}{
+		{"1\nx\n", FormatLevelOrder, `line 2: invalid value "x"`},
+		{"null\n1\n", FormatLevelOrder, `line 2: value "1" after the tree is complete`},
+		{"1(2,3", FormatParen, `offset 5: expected ')', found end of input`},
+		{"1(2)", FormatParen, `offset 3: expected ',', found ')'`},
+		{"1(2,3)4", FormatParen, `offset 6: unexpected '4' after the tree`},
+		{"1(-,)", FormatParen, `offset 2: invalid value "-"`},
+	} {
+		_, err := ReadTree(strings.NewReader(tc.in), tc.format)
+		require.ErrorContains(t, err, tc.msg, tc.in)
+	}
+
+	boom := errors.New("boom")
+	for _, format := range []Format{FormatLevelOrder, FormatParen} {
+		_, err := ReadTree(io.MultiReader(strings.NewReader("1"), iotest.ErrReader(boom)), format)
+		require.ErrorIs(t, err, boom)
+	}
+	_, err := ReadTree(strings.NewReader(""), Format(9))
+	require.Error(t, err)
+}
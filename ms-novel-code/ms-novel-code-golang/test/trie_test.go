@@ -0,0 +1,53 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Insert, Contains, and HasPrefix distinguish words from prefixes.
+func TestTrieInsertContains(t *testing.T) {
+	trie := NewTrie("car", "cart", "care", "dog")
+	require.False(t, trie.Insert("car"))
+	require.Equal(t, 4, trie.Len())
+
+	require.True(t, trie.Contains("car"))
+	require.False(t, trie.Contains("ca"))
+	require.True(t, trie.HasPrefix("ca"))
+	require.True(t, trie.HasPrefix(""))
+	require.False(t, trie.HasPrefix("cat"))
+}
+
+// 2. WordsWithPrefix returns sorted completions, including the prefix itself.
+func TestTrieWordsWithPrefix(t *testing.T) {
+	trie := NewTrie("car", "cart", "care", "dog", "héllo", "hélium")
+	require.Equal(t, []string{"car", "care", "cart"}, trie.WordsWithPrefix("car"))
+	require.Equal(t, []string{"hélium", "héllo"}, trie.WordsWithPrefix("hé"))
+	require.Empty(t, trie.WordsWithPrefix("x"))
+	require.Len(t, trie.WordsWithPrefix(""), 6)
+}
+
+// 3. Delete removes only the word and prunes dead branches.
+func TestTrieDelete(t *testing.T) {
+	trie := NewTrie("car", "cart")
+	require.False(t, trie.Delete("ca"))
+	require.True(t, trie.Delete("cart"))
+	require.False(t, trie.Delete("cart"))
+	require.True(t, trie.Contains("car"))
+	require.False(t, trie.HasPrefix("cart"))
+
+	require.True(t, trie.Delete("car"))
+	require.False(t, trie.HasPrefix("c"))
+	require.Zero(t, trie.Len())
+}
+
+// 4. The empty string is an ordinary word.
+func TestTrieEmptyWord(t *testing.T) {
+	var trie Trie
+	require.False(t, trie.Contains(""))
+	require.True(t, trie.Insert(""))
+	require.True(t, trie.Contains(""))
+	require.True(t, trie.Delete(""))
+	require.False(t, trie.Contains(""))
+}
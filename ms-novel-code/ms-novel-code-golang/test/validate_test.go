@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Valid trees, including the empty one, report no violations.
+func TestValidateBSTValid(t *testing.T) {
+	ok, violations := ValidateBST(nil)
+	require.True(t, ok)
+	require.NotNil(t, violations)
+
+	ok, violations = ValidateBST(NewBST(50, 30, 70, 20, 40, 60, 80).Root)
+	require.True(t, ok)
+	require.Empty(t, violations)
+}
+
+// 2. A grandchild breaking an ancestor's bound is reported with that ancestor.
+func TestValidateBSTAncestorBound(t *testing.T) {
+	//     5
+	//    / \
+	//   1   8
+	//      /
+	//     4   <- less than 8 but not greater than 5
+	root := BuildFromLevelOrder(levelOrderInts(5, 1, 8, nil, nil, 4))
+	ok, violations := ValidateBST(root)
+	require.False(t, ok)
+	require.Len(t, violations, 1)
+
+	v := violations[0]
+	require.Same(t, root.Right.Left, v.Node)
+	require.Equal(t, BelowMin, v.Kind)
+	require.Equal(t, 5, v.Bound)
+	require.Same(t, root, v.Ancestor)
+	require.Equal(t, "node 4 is not greater than 5 (it is in the right subtree of 5)", v.String())
+}
+
+// 3. Every offending node is reported, including duplicates.
+func TestValidateBSTMultipleViolations(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(10, 12, 10))
+	ok, violations := ValidateBST(root)
+	require.False(t, ok)
+	require.Len(t, violations, 2)
+	require.Equal(t, AboveMax, violations[0].Kind)
+	require.Equal(t, 12, violations[0].Node.Val)
+	require.Equal(t, BelowMin, violations[1].Kind)
+	require.Equal(t, 10, violations[1].Node.Val)
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Empty tree yields non-nil empty results.
+func TestVerticalOrderEmptyTree(t *testing.T) {
+	require.NotNil(t, VerticalOrder[int](nil))
+	require.Empty(t, VerticalOrder[int](nil))
+	require.NotNil(t, ColumnWiseMax[int](nil))
+	require.Empty(t, ColumnWiseMax[int](nil))
+}
+
+// 2. Columns are ordered left-to-right and filled top-to-bottom.
+func TestVerticalOrderMixedTree(t *testing.T) {
+	//        3
+	//      /   \
+	//     9     20
+	//    / \   /  \
+	//   1   4 15   7
+	root := BuildFromLevelOrder(levelOrderInts(3, 9, 20, 1, 4, 15, 7))
+
+	require.Equal(t, [][]int{{1}, {9}, {3, 4, 15}, {20}, {7}}, VerticalOrder(root))
+	require.Equal(t, []int{1, 9, 15, 20, 7}, ColumnWiseMax(root))
+}
+
+// 3. A right-skewed tree puts each node in its own column.
+func TestVerticalOrderRightSkewed(t *testing.T) {
+	root := BuildFromLevelOrder(levelOrderInts(3, nil, 1, nil, 0))
+
+	require.Equal(t, [][]int{{3}, {1}, {0}}, VerticalOrder(root))
+}
+
+// 4. Diagonals follow right spines; left children start the next one.
+func TestDiagonalOrder(t *testing.T) {
+	//          8
+	//        /   \
+	//       3     10
+	//      / \      \
+	//     1   6      14
+	//        / \    /
+	//       4   7  13
+	root := BuildFromLevelOrder(levelOrderInts(8, 3, 10, 1, 6, nil, 14, nil, nil, 4, 7, 13))
+
+	require.Equal(t, [][]int{{8, 10, 14}, {3, 6, 7, 13}, {1, 4}}, DiagonalOrder(root))
+	require.Equal(t, []int{14, 13, 4}, DiagonalMax(root))
+}
+
+// 5. Empty trees give non-nil empty diagonals.
+func TestDiagonalOrderEmptyTree(t *testing.T) {
+	require.NotNil(t, DiagonalOrder[int](nil))
+	require.Empty(t, DiagonalOrder[int](nil))
+	require.NotNil(t, DiagonalMax[int](nil))
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 1. Empty tree yields non-nil empty views.
+func TestViewsEmptyTree(t *testing.T) {
+	require.NotNil(t, RightView[int](nil))
+	require.Empty(t, RightView[int](nil))
+	require.NotNil(t, LeftView[int](nil))
+	require.Empty(t, LeftView[int](nil))
+}
+
+// 2. Views pick the outermost node on each level.
+func TestViewsMixedTree(t *testing.T) {
+	root := &Node{Val: 1}
+	root.Left = &Node{Val: 2}
+	root.Right = &Node{Val: 3}
+	root.Left.Right = &Node{Val: 5}
+	root.Right.Right = &Node{Val: 4}
+	root.Left.Right.Left = &Node{Val: 6}
+
+	require.Equal(t, []int{1, 3, 4, 6}, RightView(root))
+	require.Equal(t, []int{1, 2, 5, 6}, LeftView(root))
+}
+
+// 3. A left-only node is visible from the right when nothing hides it.
+func TestRightViewLeftSkewed(t *testing.T) {
+	root := &Node{Val: 3}
+	root.Left = &Node{Val: 4}
+	root.Left.Left = &Node{Val: 10}
+
+	require.Equal(t, []int{3, 4, 10}, RightView(root))
+}
+
+// 4. Boundary traversal goes left edge, leaves, then right edge upwards.
+func TestBoundaryTraversal(t *testing.T) {
+	//          1
+	//        /   \
+	//       2     3
+	//      / \   /
+	//     4   5 6
+	//        / \ \
+	//       7   8 9   (9 is 6's right child)
+	root := BuildFromLevelOrder(levelOrderInts(1, 2, 3, 4, 5, 6, nil, nil, nil, 7, 8, nil, 9))
+	require.Equal(t, []int{1, 2, 4, 7, 8, 9, 6, 3}, BoundaryTraversal(root))
+}
+
+// 5. Boundaries of degenerate trees list each node once.
+func TestBoundaryTraversalDegenerate(t *testing.T) {
+	require.NotNil(t, BoundaryTraversal[int](nil))
+	require.Empty(t, BoundaryTraversal[int](nil))
+	require.Equal(t, []int{1}, BoundaryTraversal(&Node{Val: 1}))
+	// No left subtree: the left boundary is just the root.
+	require.Equal(t, []int{1, 3, 4, 2}, BoundaryTraversal(BuildFromLevelOrder(levelOrderInts(1, nil, 2, 3, 4))))
+}